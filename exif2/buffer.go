@@ -9,6 +9,9 @@ import (
 const (
 	tagMaxCount  = 84
 	bufferLength = 1024
+
+	// makerNoteMaxLength is the maximum length of a Makernote read into memory
+	makerNoteMaxLength = 256 * 1024
 )
 
 // buffer for data and tags
@@ -29,9 +32,15 @@ func (b *buffer) currentTag() Tag {
 	return b.tag[b.pos]
 }
 
-// nextTag returns the next tag in tagBuffer
-func (b *buffer) nextTag() Tag {
-	return b.tag[b.pos+1]
+// overlapsTagValue returns true if the value of a tag in tagBuffer begins
+// within the next n bytes of the reader.
+func (ir *ifdReader) overlapsTagValue(n uint32) bool {
+	for i := ir.buffer.pos; i < ir.buffer.len; i++ {
+		if offset := ir.buffer.tag[i].ValueOffset; offset >= ir.po {
+			return offset < ir.po+n
+		}
+	}
+	return false
 }
 
 // nextTag increments the position by 1
@@ -51,6 +60,9 @@ func (b *buffer) validTag() bool {
 // readTagValue discards until tag.ValueOffset and reads length of tag
 func (ir *ifdReader) readTagValue() (buf []byte, err error) {
 	t := ir.buffer.currentTag()
+	if buf, ok := ir.cachedValue(t); ok {
+		return buf, nil // value was read by retainTag or a TagVisitor
+	}
 	if err := ir.discard(int(t.ValueOffset) - int(ir.po)); err != nil {
 		return nil, err
 	}
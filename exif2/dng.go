@@ -0,0 +1,361 @@
+package exif2
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// Errors
+var (
+	ErrDNGOpcodeList  = errors.New("error invalid DNG opcode list")
+	ErrDNGPrivateData = errors.New("error invalid DNG private data")
+)
+
+const (
+	// dngOpcodeListMaxLength is the maximum length of an OpcodeList read into memory
+	dngOpcodeListMaxLength = 1024 * 1024
+
+	// dngMakerNoteHeaderLength is the length of the "Adobe\0MakN" header of
+	// DNGPrivateData: "Adobe\0" "MakN" count(4) byteOrder(2) offset(4)
+	dngMakerNoteHeaderLength = 20
+)
+
+// DNGInfo is the DNG specific metadata of IFD0 and the raw image Ifd.
+// Derived from the DNG Specification 1.6
+type DNGInfo struct {
+	UniqueCameraModel      string           // IFD0 / 0xc614
+	LocalizedCameraModel   string           // IFD0 / 0xc615
+	OriginalRawFileName    string           // IFD0 / 0xc68b
+	ColorMatrix1           []float32        // IFD0 / 0xc621
+	ColorMatrix2           []float32        // IFD0 / 0xc622
+	CameraCalibration1     []float32        // IFD0 / 0xc623
+	CameraCalibration2     []float32        // IFD0 / 0xc624
+	ForwardMatrix1         []float32        // IFD0 / 0xc714
+	ForwardMatrix2         []float32        // IFD0 / 0xc715
+	AsShotNeutral          []float32        // IFD0 / 0xc628
+	OpcodeList1            []DNGOpcode      // RawIfd / 0xc740 (applied to the raw image as read from the file)
+	OpcodeList2            []DNGOpcode      // RawIfd / 0xc741 (applied after linearization)
+	OpcodeList3            []DNGOpcode      // RawIfd / 0xc74e (applied after demosaicing)
+	AsShotWhiteXY          [2]float32       // IFD0 / 0xc629
+	DefaultCropOrigin      [2]float32       // RawIfd / 0xc61f
+	DefaultCropSize        [2]float32       // RawIfd / 0xc620
+	ActiveArea             [4]uint32        // RawIfd / 0xc68d (top, left, bottom, right)
+	BaselineExposure       float32          // IFD0 / 0xc62a
+	BaselineNoise          float32          // IFD0 / 0xc62b
+	LinearResponseLimit    float32          // IFD0 / 0xc62e
+	Version                DNGVersion       // IFD0 / 0xc612
+	BackwardVersion        DNGVersion       // IFD0 / 0xc613
+	CalibrationIlluminant1 meta.LightSource // IFD0 / 0xc65a
+	CalibrationIlluminant2 meta.LightSource // IFD0 / 0xc65b
+}
+
+// DNGVersion is a DNG version, ex: 1.4.0.0
+type DNGVersion [4]uint8
+
+// String returns a DNGVersion as a string
+func (v DNGVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v[0], v[1], v[2], v[3])
+}
+
+// IsZero returns true if the DNGVersion is not set
+func (v DNGVersion) IsZero() bool {
+	return v == DNGVersion{}
+}
+
+// DNGOpcodeID is the ID of a DNG Opcode
+type DNGOpcodeID uint32
+
+// DNGOpcodeIDs
+const (
+	DNGOpcodeWarpRectilinear      DNGOpcodeID = 1
+	DNGOpcodeWarpFisheye          DNGOpcodeID = 2
+	DNGOpcodeFixVignetteRadial    DNGOpcodeID = 3
+	DNGOpcodeFixBadPixelsConstant DNGOpcodeID = 4
+	DNGOpcodeFixBadPixelsList     DNGOpcodeID = 5
+	DNGOpcodeTrimBounds           DNGOpcodeID = 6
+	DNGOpcodeMapTable             DNGOpcodeID = 7
+	DNGOpcodeMapPolynomial        DNGOpcodeID = 8
+	DNGOpcodeGainMap              DNGOpcodeID = 9
+	DNGOpcodeDeltaPerRow          DNGOpcodeID = 10
+	DNGOpcodeDeltaPerColumn       DNGOpcodeID = 11
+	DNGOpcodeScalePerRow          DNGOpcodeID = 12
+	DNGOpcodeScalePerColumn       DNGOpcodeID = 13
+	DNGOpcodeWarpRectilinear2     DNGOpcodeID = 14
+)
+
+// dngOpcodeIDStrings are the names of the DNGOpcodeIDs
+var dngOpcodeIDStrings = [...]string{"Unknown", "WarpRectilinear", "WarpFisheye", "FixVignetteRadial", "FixBadPixelsConstant", "FixBadPixelsList", "TrimBounds", "MapTable", "MapPolynomial", "GainMap", "DeltaPerRow", "DeltaPerColumn", "ScalePerRow", "ScalePerColumn", "WarpRectilinear2"}
+
+// String returns a DNGOpcodeID as a string
+func (id DNGOpcodeID) String() string {
+	if id > 0 && int(id) < len(dngOpcodeIDStrings) {
+		return dngOpcodeIDStrings[id]
+	}
+	return fmt.Sprintf("Unknown (%d)", uint32(id))
+}
+
+// DNGOpcode is an Opcode of a DNG OpcodeList. Params are big endian.
+type DNGOpcode struct {
+	Params  []byte
+	ID      DNGOpcodeID
+	Version DNGVersion
+	Flags   uint32
+}
+
+// Optional returns true if the Opcode may be skipped by readers that do not
+// support it.
+func (op DNGOpcode) Optional() bool {
+	return op.Flags&1 == 1
+}
+
+// SkipIfPreview returns true if the Opcode may be skipped for preview
+// quality rendering.
+func (op DNGOpcode) SkipIfPreview() bool {
+	return op.Flags&2 == 2
+}
+
+// ParseDNGOpcodeList parses the value of an OpcodeList tag. OpcodeLists
+// are always big endian.
+func ParseDNGOpcodeList(buf []byte) ([]DNGOpcode, error) {
+	if len(buf) < 4 {
+		return nil, ErrDNGOpcodeList
+	}
+	bo := utils.BigEndian
+	count := bo.Uint32(buf)
+	if count > uint32(len(buf)/16) {
+		return nil, ErrDNGOpcodeList
+	}
+	ops := make([]DNGOpcode, 0, count)
+	buf = buf[4:]
+	for i := uint32(0); i < count; i++ {
+		if len(buf) < 16 {
+			return ops, ErrDNGOpcodeList
+		}
+		op := DNGOpcode{
+			ID:      DNGOpcodeID(bo.Uint32(buf)),
+			Version: DNGVersion{buf[4], buf[5], buf[6], buf[7]},
+			Flags:   bo.Uint32(buf[8:]),
+		}
+		n := bo.Uint32(buf[12:])
+		if uint32(len(buf)-16) < n {
+			return ops, ErrDNGOpcodeList
+		}
+		op.Params = buf[16 : 16+n]
+		buf = buf[16+n:]
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// parseDNGMakerNote parses the value of DNGPrivateData with the
+// "Adobe\0MakN" header. The returned MakerNote has the Offset and ByteOrder
+// of the original Makernote, tag value offsets of the original Makernote
+// are relative to Offset.
+func parseDNGMakerNote(buf []byte) (MakerNote, error) {
+	if len(buf) < dngMakerNoteHeaderLength || !bytes.HasPrefix(buf, []byte("Adobe\x00MakN")) {
+		return MakerNote{}, ErrDNGPrivateData
+	}
+	count := utils.BigEndian.Uint32(buf[10:]) // byteOrder, offset and Makernote
+	byteOrder := utils.UnknownEndian
+	switch string(buf[14:16]) {
+	case "II":
+		byteOrder = utils.LittleEndian
+	case "MM":
+		byteOrder = utils.BigEndian
+	}
+	if byteOrder == utils.UnknownEndian || count < 6 || count > uint32(len(buf)-14) {
+		return MakerNote{}, ErrDNGPrivateData
+	}
+	return MakerNote{
+		Buf:       buf[dngMakerNoteHeaderLength : 14+count],
+		Offset:    utils.BigEndian.Uint32(buf[16:]),
+		ByteOrder: byteOrder,
+	}, nil
+}
+
+// parseDNGTag parses the DNG tags of IFD0 and the raw image Ifd.
+func (ir *ifdReader) parseDNGTag(t Tag) {
+	dng := &ir.Exif.DNG
+	switch t.ID {
+	case ifds.DNGVersion:
+		dng.Version = ir.parseDNGVersion(t)
+	case ifds.DNGBackwardVersion:
+		dng.BackwardVersion = ir.parseDNGVersion(t)
+	case ifds.UniqueCameraModel:
+		dng.UniqueCameraModel = ir.ParseString(t)
+	case ifds.LocalizedCameraModel:
+		dng.LocalizedCameraModel = ir.ParseString(t)
+	case ifds.OriginalRawFileName:
+		dng.OriginalRawFileName = ir.ParseString(t)
+	case ifds.ColorMatrix1:
+		dng.ColorMatrix1 = ir.parseFloats(t)
+	case ifds.ColorMatrix2:
+		dng.ColorMatrix2 = ir.parseFloats(t)
+	case ifds.CameraCalibration1:
+		dng.CameraCalibration1 = ir.parseFloats(t)
+	case ifds.CameraCalibration2:
+		dng.CameraCalibration2 = ir.parseFloats(t)
+	case ifds.ForwardMatrix1:
+		dng.ForwardMatrix1 = ir.parseFloats(t)
+	case ifds.ForwardMatrix2:
+		dng.ForwardMatrix2 = ir.parseFloats(t)
+	case ifds.AsShotNeutral:
+		dng.AsShotNeutral = ir.parseFloats(t)
+	case ifds.AsShotWhiteXY:
+		copy(dng.AsShotWhiteXY[:], ir.parseFloats(t))
+	case ifds.CalibrationIlluminant1:
+		dng.CalibrationIlluminant1 = meta.LightSource(ir.ParseUint16(t))
+	case ifds.CalibrationIlluminant2:
+		dng.CalibrationIlluminant2 = meta.LightSource(ir.ParseUint16(t))
+	case ifds.BaselineExposure:
+		dng.BaselineExposure = ir.parseRationalFloat(t)
+	case ifds.BaselineNoise:
+		dng.BaselineNoise = ir.parseRationalFloat(t)
+	case ifds.LinearResponseLimit:
+		dng.LinearResponseLimit = ir.parseRationalFloat(t)
+	case ifds.DefaultCropOrigin:
+		copy(dng.DefaultCropOrigin[:], ir.parseFloats(t))
+	case ifds.DefaultCropSize:
+		copy(dng.DefaultCropSize[:], ir.parseFloats(t))
+	case ifds.ActiveArea:
+		for i, f := range ir.parseFloats(t) {
+			if i < len(dng.ActiveArea) {
+				dng.ActiveArea[i] = uint32(f)
+			}
+		}
+	case ifds.OpcodeList1:
+		dng.OpcodeList1 = ir.parseDNGOpcodeList(t)
+	case ifds.OpcodeList2:
+		dng.OpcodeList2 = ir.parseDNGOpcodeList(t)
+	case ifds.OpcodeList3:
+		dng.OpcodeList3 = ir.parseDNGOpcodeList(t)
+	case ifds.DNGPrivateData:
+		ir.readDNGPrivateData(t)
+	}
+}
+
+// parseDNGVersion parses a DNGVersion.
+// Embedded tag with 4 BYTE values.
+func (ir *ifdReader) parseDNGVersion(t Tag) (v DNGVersion) {
+	if t.IsEmbedded() && t.IsType(tag.TypeByte) {
+		t.EmbeddedValue(v[:])
+		return v
+	}
+	if ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+	}
+	return v
+}
+
+// parseFloats parses SHORT, LONG, RATIONAL, SRATIONAL, FLOAT or DOUBLE
+// values as float32. Rationals with a 0 denominator are 0.
+// This function allocates.
+func (ir *ifdReader) parseFloats(t Tag) []float32 {
+	var buf []byte
+	if t.IsEmbedded() {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		buf = ir.buffer.buf[:t.Size()]
+	} else {
+		var err error
+		if buf, err = ir.readTagValue(); err != nil {
+			return nil
+		}
+	}
+	bo := t.ByteOrder
+	f := make([]float32, t.UnitCount)
+	for i := range f {
+		switch t.Type {
+		case tag.TypeShort:
+			f[i] = float32(bo.Uint16(buf[2*i:]))
+		case tag.TypeLong:
+			f[i] = float32(bo.Uint32(buf[4*i:]))
+		case tag.TypeRational:
+			if d := bo.Uint32(buf[8*i+4:]); d != 0 {
+				f[i] = float32(bo.Uint32(buf[8*i:])) / float32(d)
+			}
+		case tag.TypeSignedRational:
+			if d := int32(bo.Uint32(buf[8*i+4:])); d != 0 {
+				f[i] = float32(int32(bo.Uint32(buf[8*i:]))) / float32(d)
+			}
+		case tag.TypeFloat:
+			f[i] = math.Float32frombits(bo.Uint32(buf[4*i:]))
+		case tag.TypeDouble:
+			f[i] = float32(math.Float64frombits(bo.Uint64(buf[8*i:])))
+		default:
+			if ir.logLevelWarn() {
+				t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+			}
+			return nil
+		}
+	}
+	return f
+}
+
+// parseDNGOpcodeList parses an OpcodeList.
+// Non-embedded UNDEFINED tag with variable length.
+func (ir *ifdReader) parseDNGOpcodeList(t Tag) []DNGOpcode {
+	if t.IsEmbedded() || !t.IsType(tag.TypeUndefined) || t.Size() > dngOpcodeListMaxLength {
+		return nil
+	}
+	buf, err := ir.readValue(t)
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return nil
+	}
+	ops, err := ParseDNGOpcodeList(buf)
+	if err != nil && ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Err(err).Send()
+	}
+	return ops
+}
+
+// readDNGPrivateData reads the original Makernote from DNGPrivateData with
+// the "Adobe\0MakN" header. The Makernote is read at its original offset
+// so that tag value offsets of the original Makernote remain valid.
+// Sony writes a LONG pointer to the SR2Private Ifd in DNGPrivateData, it is
+// ignored.
+func (ir *ifdReader) readDNGPrivateData(t Tag) {
+	if t.IsEmbedded() || !(t.IsType(tag.TypeByte) || t.IsType(tag.TypeUndefined)) || t.Size() > makerNoteMaxLength+dngMakerNoteHeaderLength {
+		return
+	}
+	buf, err := ir.readValue(t)
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return
+	}
+	mn, err := parseDNGMakerNote(buf)
+	if err != nil {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Err(err).Send()
+		}
+		return
+	}
+
+	jr := NewIfdReader(ir.logger)
+	defer jr.Close()
+	jr.ResetReader(bufio.NewReaderSize(bytes.NewReader(mn.Buf), len(mn.Buf)))
+	jr.Exif = ir.Exif
+	jr.customTagParser = ir.customTagParser
+	jr.retainTags = ir.retainTags
+	jr.visitor = ir.visitor
+	jr.po = mn.Offset
+	jr.exifLength = mn.Offset + uint32(len(mn.Buf))
+
+	jr.addTagBuffer(NewTag(exififd.MakerNote, tag.TypeIfd, uint32(len(mn.Buf)), mn.Offset, ifds.DNGAdobeDataIFD, 0, mn.ByteOrder))
+	if err = jr.readTags(); err != nil {
+		t.logTag(ir.logError(err)).Send()
+	}
+	imageType := ir.Exif.ImageType
+	ir.Exif = jr.Exif
+	ir.Exif.ImageType = imageType
+	ir.visitErr = jr.visitErr
+}
@@ -0,0 +1,158 @@
+package exif2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta"
+)
+
+func TestParseDNG(t *testing.T) {
+	f, err := os.Open("../testImages/Hero8.GPR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	e, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dng := e.DNG
+	if dng.Version.String() != "1.3.0.0" || dng.BackwardVersion.String() != "1.1.0.0" {
+		t.Errorf("Incorrect DNGVersion got %s %s", dng.Version, dng.BackwardVersion)
+	}
+	if dng.UniqueCameraModel != "GoPro HERO8 Black" {
+		t.Errorf("Incorrect UniqueCameraModel got %s", dng.UniqueCameraModel)
+	}
+	if len(dng.ColorMatrix1) != 9 || math.Abs(float64(dng.ColorMatrix1[0])-1.8331) > 1e-4 {
+		t.Errorf("Incorrect ColorMatrix1 got %v", dng.ColorMatrix1)
+	}
+	if len(dng.AsShotNeutral) != 3 || math.Abs(float64(dng.AsShotNeutral[0])-0.489484) > 1e-4 {
+		t.Errorf("Incorrect AsShotNeutral got %v", dng.AsShotNeutral)
+	}
+	if dng.CalibrationIlluminant1 != meta.LightSource(3) || dng.CalibrationIlluminant2 != meta.LightSource(23) {
+		t.Errorf("Incorrect CalibrationIlluminants got %d %d", dng.CalibrationIlluminant1, dng.CalibrationIlluminant2)
+	}
+	if dng.BaselineNoise != 1 || dng.LinearResponseLimit != 1 {
+		t.Errorf("Incorrect BaselineNoise %f LinearResponseLimit %f", dng.BaselineNoise, dng.LinearResponseLimit)
+	}
+	if dng.DefaultCropSize != [2]float32{4000, 3000} || dng.ActiveArea != [4]uint32{0, 0, 3000, 4000} {
+		t.Errorf("Incorrect DefaultCropSize %v ActiveArea %v", dng.DefaultCropSize, dng.ActiveArea)
+	}
+	if dng.OriginalRawFileName != "RAW FILE" {
+		t.Errorf("Incorrect OriginalRawFileName got %s", dng.OriginalRawFileName)
+	}
+	if len(dng.OpcodeList2) != 4 {
+		t.Fatalf("Incorrect OpcodeList2 length wanted %d got %d", 4, len(dng.OpcodeList2))
+	}
+	op := dng.OpcodeList2[0]
+	if op.ID != DNGOpcodeGainMap || op.Version.String() != "1.4.0.0" || !op.Optional() || op.SkipIfPreview() || len(op.Params) != 0x834 {
+		t.Errorf("Incorrect Opcode got %s %s %d %d", op.ID, op.Version, op.Flags, len(op.Params))
+	}
+}
+
+func TestParseDNGOpcodeList(t *testing.T) {
+	bo := binary.BigEndian
+	buf := bo.AppendUint32(nil, 1)
+	buf = bo.AppendUint32(buf, uint32(DNGOpcodeFixBadPixelsConstant))
+	buf = append(buf, 1, 3, 0, 0)
+	buf = bo.AppendUint32(buf, 3)
+	buf = bo.AppendUint32(buf, 8)
+	buf = append(buf, 0, 0, 0, 0, 0, 0, 0, 1)
+
+	ops, err := ParseDNGOpcodeList(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || ops[0].ID != DNGOpcodeFixBadPixelsConstant || !ops[0].Optional() || !ops[0].SkipIfPreview() || len(ops[0].Params) != 8 {
+		t.Errorf("Incorrect Opcodes got %v", ops)
+	}
+	if ops[0].ID.String() != "FixBadPixelsConstant" || DNGOpcodeID(99).String() != "Unknown (99)" {
+		t.Errorf("Incorrect DNGOpcodeID String got %s", ops[0].ID)
+	}
+
+	for _, b := range [][]byte{
+		{0, 0},                     // short buffer
+		bo.AppendUint32(nil, 1000), // count larger than the buffer
+		buf[:len(buf)-1],           // truncated params
+	} {
+		if _, err := ParseDNGOpcodeList(b); err != ErrDNGOpcodeList {
+			t.Errorf("Incorrect error wanted %v got %v", ErrDNGOpcodeList, err)
+		}
+	}
+}
+
+func TestParseDNGPrivateData(t *testing.T) {
+	const origOffset = 5000
+	bo := binary.LittleEndian
+	// Canon Makernote with a ModelName tag. Value offsets are relative to
+	// the original Tiff.
+	mn := bo.AppendUint16(nil, 1)
+	mn = bo.AppendUint16(mn, 0x0006)
+	mn = bo.AppendUint16(mn, uint16(tag.TypeASCII))
+	mn = bo.AppendUint32(mn, 10)
+	mn = bo.AppendUint32(mn, origOffset+18)
+	mn = bo.AppendUint32(mn, 0)
+	mn = append(mn, "Canon EOS\x00"...)
+
+	pd := []byte("Adobe\x00MakN")
+	pd = binary.BigEndian.AppendUint32(pd, uint32(6+len(mn)))
+	pd = append(pd, 'I', 'I')
+	pd = binary.BigEndian.AppendUint32(pd, origOffset)
+	pd = append(pd, mn...)
+
+	buf := buildTiff([]testTag{
+		asciiTag(ifds.Make, tag.TypeASCII, "Canon"),
+		{id: ifds.DNGVersion, t: tag.TypeByte, count: 4, value: []byte{1, 4, 0, 0}},
+		{id: ifds.DNGPrivateData, t: tag.TypeByte, count: uint32(len(pd)), value: pd},
+	}, nil)
+	buf = append(buf, make([]byte, 32)...)
+
+	e, err := ParseAllTags(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.DNG.Version.String() != "1.4.0.0" {
+		t.Errorf("Incorrect DNGVersion got %s", e.DNG.Version)
+	}
+	if rt, ok := e.Ifds.Tag(ifds.MknoteIFD, 0, 0x0006); !ok || rt.String() != "Canon EOS" {
+		t.Errorf("Incorrect Makernote ModelName got %s", rt.String())
+	}
+
+	// Canon Makernote with more tags than fit in the reader buffer
+	entries := []tifftest.Entry{tifftest.ASCII(0x0006, "Canon EOS R5")}
+	for i := 0; i < 100; i++ {
+		entries = append(entries, tifftest.Shorts(bo, tag.ID(0x4100+i), uint16(i)))
+	}
+	pd = []byte("Adobe\x00MakN")
+	mn = tifftest.AppendIfd(nil, bo, origOffset, entries)
+	pd = binary.BigEndian.AppendUint32(pd, uint32(6+len(mn)))
+	pd = append(pd, 'I', 'I')
+	pd = binary.BigEndian.AppendUint32(pd, origOffset)
+	pd = append(pd, mn...)
+	buf = buildTiff([]testTag{
+		asciiTag(ifds.Make, tag.TypeASCII, "Canon"),
+		{id: ifds.DNGVersion, t: tag.TypeByte, count: 4, value: []byte{1, 4, 0, 0}},
+		{id: ifds.DNGPrivateData, t: tag.TypeByte, count: uint32(len(pd)), value: pd},
+	}, nil)
+	buf = append(buf, make([]byte, 32)...)
+	if e, err = ParseAllTags(bytes.NewReader(buf)); err != nil {
+		t.Fatal(err)
+	}
+	if rt, ok := e.Ifds.Tag(ifds.MknoteIFD, 0, 0x0006); !ok || rt.String() != "Canon EOS R5" {
+		t.Errorf("Incorrect Makernote ModelName got %s", rt.String())
+	}
+	if n := len(e.Ifds[ifds.MknoteIFD][0].Tags); n != 101 {
+		t.Errorf("Incorrect number of Makernote tags wanted %d got %d", 101, n)
+	}
+
+	if _, err := parseDNGMakerNote(pd[:dngMakerNoteHeaderLength-1]); err != ErrDNGPrivateData {
+		t.Errorf("Incorrect error wanted %v got %v", ErrDNGPrivateData, err)
+	}
+}
@@ -2,10 +2,23 @@ package exif2
 
 import (
 	"bytes"
+	"encoding/binary"
 	"io"
 	"log"
+	"math"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/iopifd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+	"github.com/evanoberholster/imagemeta/tiff"
 )
 
 func BenchmarkExif(b *testing.B) {
@@ -50,3 +63,354 @@ func BenchmarkExif(b *testing.B) {
 
 // Application Notes
 // BenchmarkExif-12    	  182654	      6498 ns/op	    1287 B/op	       9 allocs/op
+
+func TestParseExifIFD(t *testing.T) {
+	parse := func(name string) Exif {
+		f, err := os.Open("../testImages/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		e, err := Parse(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+
+	e := parse("ARW.exif")
+	if e.SensitivityType != meta.SensitivityTypeREI || e.RecommendedExposureIndex != 100 {
+		t.Errorf("Incorrect SensitivityType %s REI %d", e.SensitivityType, e.RecommendedExposureIndex)
+	}
+	if e.BrightnessValue != 9.12 || e.MaxApertureValue != 4.0 {
+		t.Errorf("Incorrect BrightnessValue %f MaxApertureValue %s", e.BrightnessValue, e.MaxApertureValue)
+	}
+	if e.WhiteBalance != meta.WhiteBalanceAuto || e.LightSource != meta.LightSourceUnknown || e.SceneType != meta.SceneTypeDirectlyPhotographed {
+		t.Errorf("Incorrect WhiteBalance %s LightSource %s SceneType %s", e.WhiteBalance, e.LightSource, e.SceneType)
+	}
+	if e.Contrast != meta.ContrastNormal || e.Saturation != meta.SaturationNormal || e.Sharpness != meta.SharpnessNormal || e.SceneCaptureType != meta.SceneCaptureTypeStandard {
+		t.Errorf("Incorrect Contrast %s Saturation %s Sharpness %s SceneCaptureType %s", e.Contrast, e.Saturation, e.Sharpness, e.SceneCaptureType)
+	}
+
+	e = parse("CR2.exif")
+	if e.WhiteBalance != meta.WhiteBalanceManual {
+		t.Errorf("Incorrect WhiteBalance wanted %s got %s", meta.WhiteBalanceManual, e.WhiteBalance)
+	}
+	if math.Abs(float64(e.ShutterSpeedValue)-math.Pow(2, -352256.0/65536)) > 1e-6 {
+		t.Errorf("Incorrect ShutterSpeedValue got %f", e.ShutterSpeedValue)
+	}
+	if e.FocalPlaneXResolution != float32(5616000)/1419 || e.FocalPlaneYResolution != float32(3744000)/945 || e.FocalPlaneResolutionUnit != 2 {
+		t.Errorf("Incorrect FocalPlaneResolution %f %f %d", e.FocalPlaneXResolution, e.FocalPlaneYResolution, e.FocalPlaneResolutionUnit)
+	}
+
+	e = parse("Heic.exif")
+	if e.ShutterSpeedValue.String() != "1/20" {
+		t.Errorf("Incorrect ShutterSpeedValue wanted %s got %s", "1/20", e.ShutterSpeedValue)
+	}
+}
+
+func TestParseInteropAndThumbnail(t *testing.T) {
+	f, err := os.Open("../testImages/CR2.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	e, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Interop.Index != iopifd.IndexR98 || e.Interop.Version != "0100" {
+		t.Errorf("Incorrect Interop wanted %s %s got %s %s", iopifd.IndexR98, "0100", e.Interop.Index, e.Interop.Version)
+	}
+	if e.Thumbnail.Offset != 36684 || e.Thumbnail.Length != 5622 || e.ThumbnailOffset != 36684 || e.ThumbnailLength != 5622 {
+		t.Errorf("Incorrect Thumbnail wanted %d %d got %d %d", 36684, 5622, e.Thumbnail.Offset, e.Thumbnail.Length)
+	}
+	// IFD1 must not overwrite IFD0
+	if e.Make != "Canon" || e.ImageWidth == 0 {
+		t.Errorf("Incorrect IFD0 Make %s ImageWidth %d", e.Make, e.ImageWidth)
+	}
+	if e.ColorSpace != ColorSpaceUncalibrated || e.EffectiveColorSpace() != ColorSpaceUncalibrated {
+		t.Errorf("Incorrect ColorSpace %s", e.ColorSpace)
+	}
+	e.Interop.Index = iopifd.IndexR03
+	if e.EffectiveColorSpace() != ColorSpaceAdobeRGB {
+		t.Errorf("Incorrect EffectiveColorSpace wanted %s got %s", ColorSpaceAdobeRGB, e.EffectiveColorSpace())
+	}
+}
+
+func TestParseTimeZoneOffset(t *testing.T) {
+	ir := NewIfdReader(Logger)
+	defer ir.Close()
+
+	// -5 hours and +2 hours, big endian
+	tg := NewTag(exififd.TimeZoneOffset, tag.TypeSignedShort, 2, 0xfffb0002, ifds.ExifIFD, 0, utils.BigEndian)
+	ir.parseTag(tg)
+	if _, offset := (time.Time{}).In(ir.Exif.Time.offsetTimeOriginal).Zone(); offset != -5*3600 {
+		t.Errorf("Incorrect DateTimeOriginal offset wanted %d got %d", -5*3600, offset)
+	}
+	if _, offset := (time.Time{}).In(ir.Exif.Time.offsetTime).Zone(); offset != 2*3600 {
+		t.Errorf("Incorrect ModifyDate offset wanted %d got %d", 2*3600, offset)
+	}
+}
+
+func TestParseGPS(t *testing.T) {
+	f, err := os.Open("../testImages/ARW.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	e, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gps := e.GPS
+	if !gps.HasFix() || gps.Status != meta.GPSStatusActive || gps.MeasureMode != meta.GPSMeasureMode3D {
+		t.Errorf("Incorrect GPS fix %t Status %s MeasureMode %s", gps.HasFix(), gps.Status, gps.MeasureMode)
+	}
+	if gps.SpeedRef != meta.GPSSpeedRefKilometersPerHour || gps.Speed != 1 || math.Abs(gps.SpeedMetresPerSecond()-1/3.6) > 1e-9 {
+		t.Errorf("Incorrect GPS Speed %f %s", gps.Speed, gps.SpeedRef)
+	}
+	if gps.TrackRef != meta.GPSDirectionRefTrueNorth || gps.Track != 130.52 {
+		t.Errorf("Incorrect GPS Track %f %s", gps.Track, gps.TrackRef)
+	}
+	if gps.MapDatum != "WGS-84" || gps.Differential {
+		t.Errorf("Incorrect GPS MapDatum %s Differential %t", gps.MapDatum, gps.Differential)
+	}
+	if gps.VersionID[0] != 2 {
+		t.Errorf("Incorrect GPS VersionID %v", gps.VersionID)
+	}
+
+	f2, err := os.Open("../testImages/CR2.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f2.Close()
+	if e, err = Parse(f2); err != nil {
+		t.Fatal(err)
+	}
+	if e.GPS.HasFix() {
+		t.Errorf("Incorrect GPS fix wanted %t got %t", false, e.GPS.HasFix())
+	}
+
+	// GPSProcessingMethod
+	if s := decodeUTF16([]byte{'G', 0, 'P', 0, 'S', 0, 0, 0}, utils.LittleEndian); s != "GPS" {
+		t.Errorf("Incorrect decodeUTF16 wanted %s got %s", "GPS", s)
+	}
+	if s := decodeUTF16([]byte{0xfe, 0xff, 0, 'G', 0, 'P', 0, 'S'}, utils.LittleEndian); s != "GPS" {
+		t.Errorf("Incorrect decodeUTF16 with BOM wanted %s got %s", "GPS", s)
+	}
+}
+
+// testTag is a tag of a Tiff built by buildTiff
+type testTag struct {
+	id    tag.ID
+	t     tag.Type
+	count uint32
+	value []byte
+}
+
+// buildTiff returns a little endian Tiff with the ifd0 tags in IFD0 and the
+// exif tags in an ExifIFD. Tag values longer than 4 bytes follow each Ifd.
+func buildTiff(ifd0 []testTag, exif []testTag) []byte {
+	bo := utils.LittleEndian
+	buf := []byte{'I', 'I', 0x2a, 0, 8, 0, 0, 0}
+	writeIfd := func(tags []testTag, exifTag bool) (exifTagOffset int) {
+		n := len(tags)
+		if exifTag {
+			n++
+		}
+		dataOffset := len(buf) + 2 + n*12 + 4
+		var data []byte
+		entry := make([]byte, 12)
+		buf = append(buf, byte(n), byte(n>>8))
+		for _, t := range tags {
+			bo.PutUint16(entry[0:], uint16(t.id))
+			bo.PutUint16(entry[2:], uint16(t.t))
+			bo.PutUint32(entry[4:], t.count)
+			copy(entry[8:], []byte{0, 0, 0, 0})
+			if len(t.value) <= 4 {
+				copy(entry[8:], t.value)
+			} else {
+				bo.PutUint32(entry[8:], uint32(dataOffset+len(data)))
+				data = append(data, t.value...)
+				if len(data)%2 == 1 {
+					data = append(data, 0)
+				}
+			}
+			buf = append(buf, entry...)
+		}
+		if exifTag {
+			bo.PutUint16(entry[0:], uint16(ifds.ExifTag))
+			bo.PutUint16(entry[2:], uint16(tag.TypeLong))
+			bo.PutUint32(entry[4:], 1)
+			exifTagOffset = len(buf) + 8
+			buf = append(buf, entry...)
+		}
+		buf = append(buf, 0, 0, 0, 0) // next Ifd
+		buf = append(buf, data...)
+		return exifTagOffset
+	}
+	exifTagOffset := writeIfd(ifd0, len(exif) > 0)
+	if len(exif) > 0 {
+		bo.PutUint32(buf[exifTagOffset:], uint32(len(buf)))
+		writeIfd(exif, false)
+	}
+	return buf
+}
+
+// asciiTag returns a testTag with the NUL terminated string s
+func asciiTag(id tag.ID, t tag.Type, s string) testTag {
+	return testTag{id: id, t: t, count: uint32(len(s) + 1), value: append([]byte(s), 0)}
+}
+
+func TestParseExif30(t *testing.T) {
+	buf := buildTiff([]testTag{
+		asciiTag(ifds.Make, tag.TypeASCII, "Canon"),
+		asciiTag(ifds.Artist, tag.TypeUTF8, "Zoë Çelik"),
+	}, []testTag{
+		asciiTag(exififd.ImageTitle, tag.TypeUTF8, "Château de Chambord"),
+		asciiTag(exififd.Photographer, tag.TypeUTF8, "Zoë Çelik"),
+		asciiTag(exififd.ImageEditor, tag.TypeUTF8, "José"),
+		asciiTag(exififd.CameraFirmware, tag.TypeASCII, "Firmware Version 1.1.0"),
+		asciiTag(exififd.RAWDevelopingSoftware, tag.TypeUTF8, "darktable 4.6.0"),
+		asciiTag(exififd.ImageEditingSoftware, tag.TypeUTF8, "GIMP 2.10"),
+		asciiTag(exififd.MetadataEditingSoftware, tag.TypeUTF8, "digiKam 8.2"),
+	})
+	e, err := ParseAllTags(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, got, wanted string
+	}{
+		{"Make", e.Make, "Canon"},
+		{"Artist", e.Artist, "Zoë Çelik"},
+		{"ImageTitle", e.ImageTitle, "Château de Chambord"},
+		{"Photographer", e.Photographer, "Zoë Çelik"},
+		{"ImageEditor", e.ImageEditor, "José"},
+		{"CameraFirmware", e.CameraFirmware, "Firmware Version 1.1.0"},
+		{"RAWDevelopingSoftware", e.RAWDevelopingSoftware, "darktable 4.6.0"},
+		{"ImageEditingSoftware", e.ImageEditingSoftware, "GIMP 2.10"},
+		{"MetadataEditingSoftware", e.MetadataEditingSoftware, "digiKam 8.2"},
+	}
+	for _, test := range tests {
+		if test.got != test.wanted {
+			t.Errorf("Incorrect %s wanted %s got %s", test.name, test.wanted, test.got)
+		}
+	}
+	rt, ok := e.Ifds.Tag(ifds.ExifIFD, 0, exififd.ImageTitle)
+	if !ok || rt.Type != tag.TypeUTF8 || rt.String() != "Château de Chambord" {
+		t.Errorf("Incorrect retained ImageTitle %s %v", rt.Type, rt.Val)
+	}
+}
+
+// ucs2Tag returns a testTag with the UCS-2 little endian string s
+func ucs2Tag(id tag.ID, s string) testTag {
+	var buf []byte
+	for _, r := range s {
+		buf = append(buf, byte(r), byte(r>>8))
+	}
+	buf = append(buf, 0, 0)
+	return testTag{id: id, t: tag.TypeByte, count: uint32(len(buf)), value: buf}
+}
+
+func TestParseXPAndUserComment(t *testing.T) {
+	userComment := append([]byte("UNICODE\x00"), 'B', 0, 'o', 0, 'n', 0, 'j', 0, 'o', 0, 'u', 0, 'r', 0)
+	buf := buildTiff([]testTag{
+		ucs2Tag(ifds.XPTitle, "Été à Paris"),
+		ucs2Tag(ifds.XPComment, "Vacances"),
+		ucs2Tag(ifds.XPAuthor, "Zoë"),
+		ucs2Tag(ifds.XPKeywords, "paris;summer"),
+		ucs2Tag(ifds.XPSubject, "Tour Eiffel"),
+	}, []testTag{
+		{id: exififd.UserComment, t: tag.TypeUndefined, count: uint32(len(userComment)), value: userComment},
+	})
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, got, wanted string
+	}{
+		{"XPTitle", e.XPTitle, "Été à Paris"},
+		{"XPComment", e.XPComment, "Vacances"},
+		{"XPAuthor", e.XPAuthor, "Zoë"},
+		{"XPSubject", e.XPSubject, "Tour Eiffel"},
+		{"UserComment", e.UserComment, "Bonjour"},
+	}
+	for _, test := range tests {
+		if test.got != test.wanted {
+			t.Errorf("Incorrect %s wanted %s got %s", test.name, test.wanted, test.got)
+		}
+	}
+	if len(e.XPKeywords) != 2 || e.XPKeywords[0] != "paris" || e.XPKeywords[1] != "summer" {
+		t.Errorf("Incorrect XPKeywords got %v", e.XPKeywords)
+	}
+	if e.UserCommentCharset != meta.CharacterCodeUnicode {
+		t.Errorf("Incorrect UserCommentCharset wanted %s got %s", meta.CharacterCodeUnicode, e.UserCommentCharset)
+	}
+}
+
+func TestParseRationals(t *testing.T) {
+	f, err := os.Open("../testImages/ARW.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	e, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := e.Rationals
+	if r.ExposureTime.String() != "1/100" || r.FNumber.String() != "130/10" || r.FocalLength.String() != "300/10" || r.MaxApertureValue.String() != "400/100" || r.ExposureBias.String() != "0/10" {
+		t.Errorf("Incorrect Rationals got %+v", r)
+	}
+	if e.ExposureTime != meta.ExposureTime(0.01) || e.FNumber != 13 || e.FocalLength != 30 {
+		t.Errorf("Incorrect ExposureTime %s FNumber %s FocalLength %s", e.ExposureTime, e.FNumber, e.FocalLength)
+	}
+	if e.XResolution != meta.NewRational(350, 1) || e.Thumbnail.XResolution != meta.NewRational(72, 1) {
+		t.Errorf("Incorrect XResolution %s Thumbnail XResolution %s", e.XResolution, e.Thumbnail.XResolution)
+	}
+	if e.ImageWidth != 4928 || e.ImageHeight != 3280 {
+		t.Errorf("Incorrect Image Size %dx%d", e.ImageWidth, e.ImageHeight)
+	}
+	g := e.GPS.Rationals
+	if g.Latitude != [3]meta.Rational{{Numerator: 34, Denominator: 1}, {Numerator: 0, Denominator: 1}, {Numerator: 25385, Denominator: 1000}} || g.Altitude.String() != "11999/10" || g.TimeStamp[2].String() != "18840/1000" {
+		t.Errorf("Incorrect GPS Rationals got %+v", g)
+	}
+	if math.Abs(e.GPS.Latitude()-gpsCoord(g.Latitude)) > 1e-9 {
+		t.Errorf("Incorrect GPS Latitude %f", e.GPS.Latitude())
+	}
+}
+
+func TestParseLargeIfd(t *testing.T) {
+	le := binary.LittleEndian
+	entries := []tifftest.Entry{tifftest.ASCII(ifds.Make, "Canon")}
+	for i := 0; i < 100; i++ {
+		entries = append(entries, tifftest.Shorts(le, tag.ID(0xc000+i), uint16(i)))
+	}
+	entries = append(entries, tifftest.ASCII(0xc800, "last"))
+	buf := tifftest.Tiff(le, 0x2a, entries)
+
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.CameraMake != ifds.Canon {
+		t.Errorf("Incorrect CameraMake wanted %s got %s", ifds.Canon, e.CameraMake)
+	}
+
+	// Unbuffered reader
+	h, err := tiff.ScanTiffHeader(bytes.NewReader(buf), imagetype.ImageUnknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := NewIfdReader(Logger)
+	defer ir.Close()
+	if err = ir.DecodeTiff(bytes.NewReader(buf), h); err != nil {
+		t.Fatal(err)
+	}
+	if ir.Exif.CameraMake != ifds.Canon {
+		t.Errorf("Incorrect CameraMake wanted %s got %s", ifds.Canon, ir.Exif.CameraMake)
+	}
+}
@@ -22,6 +22,7 @@ var TagIDMap = map[tag.ID]string{
 	SpectralSensitivity:        "SpectralSensitivity",
 	ISOSpeedRatings:            "ISOSpeedRatings",
 	OECF:                       "OECF",
+	TimeZoneOffset:             "TimeZoneOffset",
 	SensitivityType:            "SensitivityType",
 	StandardOutputSensitivity:  "StandardOutputSensitivity",
 	RecommendedExposureIndex:   "RecommendedExposureIndex",
@@ -85,12 +86,20 @@ var TagIDMap = map[tag.ID]string{
 	LensMake:                   "LensMake",
 	LensModel:                  "LensModel",
 	LensSerialNumber:           "LensSerialNumber",
+	ImageTitle:                 "ImageTitle",
+	Photographer:               "Photographer",
+	ImageEditor:                "ImageEditor",
+	CameraFirmware:             "CameraFirmware",
+	RAWDevelopingSoftware:      "RAWDevelopingSoftware",
+	ImageEditingSoftware:       "ImageEditingSoftware",
+	MetadataEditingSoftware:    "MetadataEditingSoftware",
 	OffsetTime:                 "OffsetTime",
 	OffsetTimeOriginal:         "OffsetTimeOriginal",
 	OffsetTimeDigitized:        "OffsetTimeDigitized",
 	CompositeImage:             "CompositeImage",
 	CompositeImageCount:        "CompositeImageCount",
 	CompositeImageExposureTime: "CompositeImageExposureTime",
+	Gamma:                      "Gamma",
 }
 
 // ExifIFD TagIDs
@@ -101,6 +110,7 @@ const (
 	SpectralSensitivity        tag.ID = 0x8824
 	ISOSpeedRatings            tag.ID = 0x8827
 	OECF                       tag.ID = 0x8828
+	TimeZoneOffset             tag.ID = 0x882a // 1 or 2 values: time zone offset of DateTimeOriginal and ModifyDate in hours
 	SensitivityType            tag.ID = 0x8830
 	StandardOutputSensitivity  tag.ID = 0x8831
 	RecommendedExposureIndex   tag.ID = 0x8832
@@ -167,7 +177,15 @@ const (
 	LensMake                   tag.ID = 0xa433
 	LensModel                  tag.ID = 0xa434
 	LensSerialNumber           tag.ID = 0xa435
+	ImageTitle                 tag.ID = 0xa436
+	Photographer               tag.ID = 0xa437
+	ImageEditor                tag.ID = 0xa438
+	CameraFirmware             tag.ID = 0xa439
+	RAWDevelopingSoftware      tag.ID = 0xa43a
+	ImageEditingSoftware       tag.ID = 0xa43b
+	MetadataEditingSoftware    tag.ID = 0xa43c
 	CompositeImage             tag.ID = 0xa460
 	CompositeImageCount        tag.ID = 0xa461
 	CompositeImageExposureTime tag.ID = 0xa462
+	Gamma                      tag.ID = 0xa500
 )
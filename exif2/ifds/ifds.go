@@ -6,6 +6,7 @@ import (
 
 	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/gpsifd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/iopifd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/apple"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/canon"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/nikon"
@@ -65,6 +66,23 @@ func (ifdType IfdType) String() string {
 	return NullIFD.String()
 }
 
+// MarshalText implements the TextMarshaler interface
+func (ifdType IfdType) MarshalText() (text []byte, err error) {
+	return []byte(ifdType.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface. Makernote IfdTypes
+// share a name and are unmarshalled as MknoteIFD.
+func (ifdType *IfdType) UnmarshalText(text []byte) error {
+	for it := IfdType(0); int(it) < len(_IFDStringerIndex)-1; it++ {
+		if it.String() == string(text) {
+			*ifdType = it
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown IfdType: %s", text)
+}
+
 // TagName returns the tagName for the given IFD and tag.ID
 // if tag name is not known returns uint32 representation
 func (ifdType IfdType) TagName(id tag.ID) string {
@@ -75,6 +93,8 @@ func (ifdType IfdType) TagName(id tag.ID) string {
 		return exififd.TagString(id)
 	case GPSIFD:
 		return gpsifd.TagString(id)
+	case IopIFD:
+		return iopifd.TagString(id)
 	case MkNoteCanonIFD:
 		return canon.TagCanonString(id)
 	case MkNoteNikonIFD:
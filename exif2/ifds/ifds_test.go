@@ -5,6 +5,7 @@ import (
 
 	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/gpsifd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/iopifd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/canon"
 	"github.com/evanoberholster/imagemeta/exif2/tag"
 	"github.com/evanoberholster/imagemeta/meta/utils"
@@ -53,6 +54,7 @@ func TestIfdString(t *testing.T) {
 		tagTest(t, ifd, IFD0, ExifTag, "ExifTag")
 		tagTest(t, ifd, ExifIFD, exififd.ApertureValue, "ApertureValue")
 		tagTest(t, ifd, GPSIFD, gpsifd.GPSAltitude, "GPSAltitude")
+		tagTest(t, ifd, IopIFD, iopifd.InteropIndex, "InteropIndex")
 		tagTest(t, ifd, MkNoteCanonIFD, canon.CanonAFInfo, "CanonAFInfo")
 		tagTest(t, ifd, 255, ExifTag, "0x8769")
 
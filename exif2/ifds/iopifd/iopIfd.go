@@ -0,0 +1,41 @@
+// Package iopifd provides types for "RootIfd/ExifIfd/IopIfd"
+package iopifd
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagString returns the string representation of a tag.ID
+func TagString(id tag.ID) string {
+	name, ok := TagIDMap[id]
+	if !ok {
+		return id.String()
+	}
+	return name
+}
+
+// TagIDMap is a Map of tag.ID to string for the InteropIfd tags
+var TagIDMap = map[tag.ID]string{
+	InteropIndex:           "InteropIndex",
+	InteropVersion:         "InteropVersion",
+	RelatedImageFileFormat: "RelatedImageFileFormat",
+	RelatedImageWidth:      "RelatedImageWidth",
+	RelatedImageHeight:     "RelatedImageHeight",
+}
+
+// Interoperability Tags; InteropIfd
+const (
+	InteropIndex           tag.ID = 0x0001
+	InteropVersion         tag.ID = 0x0002
+	RelatedImageFileFormat tag.ID = 0x1000
+	RelatedImageWidth      tag.ID = 0x1001
+	RelatedImageHeight     tag.ID = 0x1002
+)
+
+// InteropIndex values
+const (
+	// IndexR98 is "R98 - DCF basic file (sRGB)"
+	IndexR98 = "R98"
+	// IndexR03 is "R03 - DCF option file (Adobe RGB)"
+	IndexR03 = "R03"
+	// IndexTHM is "THM - DCF thumbnail file"
+	IndexTHM = "THM"
+)
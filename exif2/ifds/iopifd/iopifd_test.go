@@ -0,0 +1,12 @@
+package iopifd
+
+import "testing"
+
+func TestString(t *testing.T) {
+	if TagString(InteropIndex) != "InteropIndex" {
+		t.Errorf("Expected %s got %s", "InteropIndex", TagString(InteropIndex))
+	}
+	if TagString(0x1234) != "0x1234" {
+		t.Errorf("Expected %s got %s", "0x1234", TagString(0x1234))
+	}
+}
@@ -82,57 +82,67 @@ func CameraMakeFromString(str string) (CameraMake, bool) {
 }
 
 var mapStringCameraMake = map[string]CameraMake{
-	"":                  CameraMakeUnknown,
-	"Acer":              Acer,
-	"Agfa":              Agfa,
-	"Aiptek":            Aiptek,
-	"Apple":             Apple,
-	"Asus":              Asus,
-	"BenQ":              BenQ,
-	"Canon":             Canon,
-	"Casio":             Casio,
-	"DJI":               DJI,
-	"FujiFilm":          FujiFilm,
-	"Ge":                Ge,
-	"Genius":            Genius,
-	"Google":            Google,
-	"GoPro":             GoPro,
-	"Hasselblad":        Hasselblad,
-	"HP":                HP,
-	"Hitachi":           Hitachi,
-	"HTC":               HTC,
-	"HUAWEI":            Huawei,
-	"Insta360":          Insta360,
-	"Kodak":             Kodak,
-	"Konica":            Konica,
-	"Kyocera":           Kyocera,
-	"Leica":             Leica,
-	"LG":                LG,
-	"Mamyia":            Mamyia,
-	"Microsoft":         Microsoft,
-	"Minolta":           Minolta,
-	"Motorola":          Motorola,
-	"Nikon":             Nikon,
-	"NIKON CORPORATION": Nikon,
-	"Nokia":             Nokia,
-	"Olympus":           Olympus,
-	"OnePlus":           OnePlus,
-	"Panasonic":         Panasonic,
-	"Pentax":            Pentax,
-	"PhaseOne":          PhaseOne,
-	"Polaroid":          Polaroid,
-	"RIM":               RIM,
-	"Ricoh":             Ricoh,
-	"Samsung":           Samsung,
-	"Sanyo":             Sanyo,
-	"Sharp":             Sharp,
-	"Sigma":             Sigma,
-	"Sony":              Sony,
-	"SONY":              Sony,
-	"SonyEricsson":      SonyEricsson,
-	"Toshiba":           Toshiba,
-	"Vivitar":           Vivitar,
-	"Xiamoi":            Xiamoi,
-	"ZTE":               ZTE,
-	"Hisilicon":         Hisilicon,
+	"":                            CameraMakeUnknown,
+	"Acer":                        Acer,
+	"Agfa":                        Agfa,
+	"Aiptek":                      Aiptek,
+	"Apple":                       Apple,
+	"Asus":                        Asus,
+	"BenQ":                        BenQ,
+	"Canon":                       Canon,
+	"Casio":                       Casio,
+	"DJI":                         DJI,
+	"FujiFilm":                    FujiFilm,
+	"FUJIFILM":                    FujiFilm,
+	"Ge":                          Ge,
+	"Genius":                      Genius,
+	"Google":                      Google,
+	"GoPro":                       GoPro,
+	"Hasselblad":                  Hasselblad,
+	"HP":                          HP,
+	"Hitachi":                     Hitachi,
+	"HTC":                         HTC,
+	"HUAWEI":                      Huawei,
+	"Insta360":                    Insta360,
+	"Kodak":                       Kodak,
+	"Konica":                      Konica,
+	"Kyocera":                     Kyocera,
+	"Leica":                       Leica,
+	"LG":                          LG,
+	"Mamyia":                      Mamyia,
+	"Microsoft":                   Microsoft,
+	"Minolta":                     Minolta,
+	"Motorola":                    Motorola,
+	"Nikon":                       Nikon,
+	"NIKON CORPORATION":           Nikon,
+	"Nokia":                       Nokia,
+	"Olympus":                     Olympus,
+	"OLYMPUS CORPORATION":         Olympus,
+	"OLYMPUS IMAGING CORP.":       Olympus,
+	"OLYMPUS OPTICAL CO.,LTD":     Olympus,
+	"OM Digital Solutions":        Olympus,
+	"OnePlus":                     OnePlus,
+	"Panasonic":                   Panasonic,
+	"Pentax":                      Pentax,
+	"PENTAX":                      Pentax,
+	"PENTAX Corporation":          Pentax,
+	"PENTAX RICOH IMAGING":        Pentax,
+	"PhaseOne":                    PhaseOne,
+	"Polaroid":                    Polaroid,
+	"RIM":                         RIM,
+	"Ricoh":                       Ricoh,
+	"RICOH":                       Ricoh,
+	"RICOH IMAGING COMPANY, LTD.": Ricoh,
+	"Samsung":                     Samsung,
+	"Sanyo":                       Sanyo,
+	"Sharp":                       Sharp,
+	"Sigma":                       Sigma,
+	"Sony":                        Sony,
+	"SONY":                        Sony,
+	"SonyEricsson":                SonyEricsson,
+	"Toshiba":                     Toshiba,
+	"Vivitar":                     Vivitar,
+	"Xiamoi":                      Xiamoi,
+	"ZTE":                         ZTE,
+	"Hisilicon":                   Hisilicon,
 }
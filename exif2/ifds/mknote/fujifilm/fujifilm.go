@@ -0,0 +1,74 @@
+// Package fujifilm provides types and functions for decoding Fujifilm Makernotes
+package fujifilm
+
+import (
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/meta/fujifilm"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+const (
+	// HeaderLength is the length of the Fujifilm Makernote header
+	// "FUJIFILM" followed by a uint32 little endian Ifd offset.
+	HeaderLength = 12
+)
+
+// IsFujifilmMkNoteHeaderBytes represents "FUJIFILM" the first 8 bytes of the
+// Fujifilm Makernote
+func IsFujifilmMkNoteHeaderBytes(buf []byte) bool {
+	return len(buf) >= 8 && string(buf[:8]) == "FUJIFILM"
+}
+
+// DecodeMakerNote decodes a Fujifilm Makernote from buf. offset is the
+// offset of buf from the beginning of the Tiff Header. Fujifilm Makernote
+// value offsets are relative to the beginning of the Makernote and are
+// always little endian. v, if not nil, is called with every Makernote entry.
+func DecodeMakerNote(buf []byte, offset uint32, v mknote.Visitor) (mn fujifilm.MakerNote, err error) {
+	if len(buf) < HeaderLength || !IsFujifilmMkNoteHeaderBytes(buf) {
+		return mn, mknote.ErrMakerNoteHeader
+	}
+	r := mknote.NewReader(buf, utils.LittleEndian, offset, offset)
+	r.Visitor = v
+	entries, _, err := r.ReadIfd(utils.LittleEndian.Uint32(buf[8:12]))
+	if err != nil {
+		return mn, err
+	}
+	var filmMode, saturation uint16
+	var hasFilmMode bool
+	for _, e := range entries {
+		switch e.ID {
+		case Version:
+			mn.Version = e.String()
+		case InternalSerialNumber:
+			mn.InternalSerialNumber = e.String()
+		case Saturation:
+			saturation = e.Uint16()
+		case FilmMode:
+			filmMode = e.Uint16()
+			hasFilmMode = true
+		case WhiteBalanceFineTune:
+			mn.WhiteBalanceFineTune.Red = fujifilm.WBFineTune(e.Int32At(0))
+			mn.WhiteBalanceFineTune.Blue = fujifilm.WBFineTune(e.Int32At(1))
+		case DynamicRange:
+			mn.DynamicRange = fujifilm.DynamicRange(e.Uint16())
+		case DynamicRangeSetting:
+			mn.DynamicRangeSetting = fujifilm.DynamicRangeSetting(e.Uint16())
+		case DevelopmentDynamicRange:
+			mn.DevelopmentDynamicRange = e.Uint16()
+		case GrainEffectRoughness:
+			mn.GrainEffectRoughness = fujifilm.NewGrainEffect(e.Uint32())
+		case GrainEffectSize:
+			mn.GrainEffectSize = fujifilm.NewGrainSize(e.Uint32())
+		case ShutterType:
+			mn.ShutterType = fujifilm.ShutterType(e.Uint16())
+		case ImageCount:
+			mn.ImageCount = e.Uint16() & 0x7fff
+		case SequenceNumber:
+			mn.SequenceNumber = e.Uint16()
+		case Rating:
+			mn.Rating = e.Uint32()
+		}
+	}
+	mn.FilmSimulation = fujifilm.NewFilmSimulation(filmMode, saturation, hasFilmMode)
+	return mn, nil
+}
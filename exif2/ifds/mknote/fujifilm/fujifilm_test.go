@@ -0,0 +1,63 @@
+package fujifilm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/fujifilm"
+)
+
+func testMakerNote() []byte {
+	le := binary.LittleEndian
+	buf := append([]byte("FUJIFILM"), tifftest.U32s(le, HeaderLength)...)
+	return tifftest.AppendIfd(buf, le, 0, []tifftest.Entry{
+		tifftest.Bytes(Version, tag.TypeUndefined, []byte("0130")),
+		tifftest.Bytes(WhiteBalanceFineTune, tag.TypeSignedLong, tifftest.U32s(le, 40, 0xffffffc4)), // 40, -60
+		tifftest.Longs(le, GrainEffectRoughness, 64),
+		tifftest.Longs(le, GrainEffectSize, 16),
+		tifftest.Shorts(le, ShutterType, 1),
+		tifftest.Shorts(le, DynamicRange, 1),
+		tifftest.Shorts(le, FilmMode, 0x600),
+		tifftest.Shorts(le, ImageCount, 0x8000|1234),
+	})
+}
+
+func TestDecodeMakerNote(t *testing.T) {
+	mn, err := DecodeMakerNote(testMakerNote(), 0x1000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mn.Version != "0130" {
+		t.Errorf("Incorrect Version wanted %s got %s", "0130", mn.Version)
+	}
+	if mn.FilmSimulation != fujifilm.FilmSimulationClassicChrome {
+		t.Errorf("Incorrect FilmSimulation wanted %s got %s", fujifilm.FilmSimulationClassicChrome, mn.FilmSimulation)
+	}
+	if mn.WhiteBalanceFineTune.Red != 40 || mn.WhiteBalanceFineTune.Blue != -60 || mn.WhiteBalanceFineTune.String() != "Red +2, Blue -3" {
+		t.Errorf("Incorrect WhiteBalanceFineTune got %d %d %s", mn.WhiteBalanceFineTune.Red, mn.WhiteBalanceFineTune.Blue, mn.WhiteBalanceFineTune)
+	}
+	if mn.GrainEffectRoughness != fujifilm.GrainEffectStrong || mn.GrainEffectSize != fujifilm.GrainSizeSmall {
+		t.Errorf("Incorrect GrainEffect got %s %s", mn.GrainEffectRoughness, mn.GrainEffectSize)
+	}
+	if mn.ShutterType != fujifilm.ShutterTypeElectronic || mn.ShutterType.String() != "Electronic" {
+		t.Errorf("Incorrect ShutterType got %s", mn.ShutterType)
+	}
+	if mn.DynamicRange.String() != "Standard" {
+		t.Errorf("Incorrect DynamicRange got %s", mn.DynamicRange)
+	}
+	if mn.ImageCount != 1234 {
+		t.Errorf("Incorrect ImageCount wanted %d got %d", 1234, mn.ImageCount)
+	}
+
+	// Monochrome Film Simulation
+	if fs := fujifilm.NewFilmSimulation(0, 0x502, false); fs != fujifilm.FilmSimulationAcrosYe || !fs.IsMonochrome() {
+		t.Errorf("Incorrect FilmSimulation got %s", fs)
+	}
+
+	// Error Header
+	if _, err = DecodeMakerNote([]byte("NOTFUJIFILM!"), 0, nil); err == nil {
+		t.Errorf("Wanted error for invalid header")
+	}
+}
@@ -0,0 +1,179 @@
+package fujifilm
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagFujifilmString returns the string representation of a tag.ID for Fujifilm Makernotes
+func TagFujifilmString(id tag.ID) string {
+	if name, ok := TagFujifilmIDMap[id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// TagFujifilmIDMap is a Map of tag.ID to string for the FujifilmMakerNote tags
+var TagFujifilmIDMap = map[tag.ID]string{
+	Version:                 "Version",
+	InternalSerialNumber:    "InternalSerialNumber",
+	Quality:                 "Quality",
+	Sharpness:               "Sharpness",
+	WhiteBalance:            "WhiteBalance",
+	Saturation:              "Saturation",
+	Contrast:                "Contrast",
+	ColorTemperature:        "ColorTemperature",
+	Contrast2:               "Contrast",
+	WhiteBalanceFineTune:    "WhiteBalanceFineTune",
+	NoiseReduction:          "NoiseReduction",
+	HighISONoiseReduction:   "HighISONoiseReduction",
+	FujiFlashMode:           "FujiFlashMode",
+	FlashExposureComp:       "FlashExposureComp",
+	Macro:                   "Macro",
+	FocusMode:               "FocusMode",
+	AFMode:                  "AFMode",
+	FocusPixel:              "FocusPixel",
+	PrioritySettings:        "PrioritySettings",
+	FocusSettings:           "FocusSettings",
+	AFCSettings:             "AFCSettings",
+	SlowSync:                "SlowSync",
+	PictureMode:             "PictureMode",
+	ExposureCount:           "ExposureCount",
+	EXRAuto:                 "EXRAuto",
+	EXRMode:                 "EXRMode",
+	ShadowTone:              "ShadowTone",
+	HighlightTone:           "HighlightTone",
+	DigitalZoom:             "DigitalZoom",
+	LensModulationOptimizer: "LensModulationOptimizer",
+	GrainEffectRoughness:    "GrainEffectRoughness",
+	ColorChromeEffect:       "ColorChromeEffect",
+	BWAdjustment:            "BWAdjustment",
+	BWMagentaGreen:          "BWMagentaGreen",
+	GrainEffectSize:         "GrainEffectSize",
+	CropMode:                "CropMode",
+	ColorChromeFXBlue:       "ColorChromeFXBlue",
+	ShutterType:             "ShutterType",
+	AutoBracketing:          "AutoBracketing",
+	SequenceNumber:          "SequenceNumber",
+	DriveSettings:           "DriveSettings",
+	PanoramaAngle:           "PanoramaAngle",
+	PanoramaDirection:       "PanoramaDirection",
+	AdvancedFilter:          "AdvancedFilter",
+	ColorMode:               "ColorMode",
+	BlurWarning:             "BlurWarning",
+	FocusWarning:            "FocusWarning",
+	ExposureWarning:         "ExposureWarning",
+	GEImageSize:             "GEImageSize",
+	DynamicRange:            "DynamicRange",
+	FilmMode:                "FilmMode",
+	DynamicRangeSetting:     "DynamicRangeSetting",
+	DevelopmentDynamicRange: "DevelopmentDynamicRange",
+	MinFocalLength:          "MinFocalLength",
+	MaxFocalLength:          "MaxFocalLength",
+	MaxApertureAtMinFocal:   "MaxApertureAtMinFocal",
+	MaxApertureAtMaxFocal:   "MaxApertureAtMaxFocal",
+	AutoDynamicRange:        "AutoDynamicRange",
+	ImageStabilization:      "ImageStabilization",
+	SceneRecognition:        "SceneRecognition",
+	Rating:                  "Rating",
+	ImageGeneration:         "ImageGeneration",
+	ImageCount:              "ImageCount",
+	DRangePriority:          "DRangePriority",
+	DRangePriorityAuto:      "DRangePriorityAuto",
+	DRangePriorityFixed:     "DRangePriorityFixed",
+	FlickerReduction:        "FlickerReduction",
+	VideoRecordingMode:      "VideoRecordingMode",
+	FrameRate:               "FrameRate",
+	FrameWidth:              "FrameWidth",
+	FrameHeight:             "FrameHeight",
+	FacesDetected:           "FacesDetected",
+	FacePositions:           "FacePositions",
+	NumFaceElements:         "NumFaceElements",
+	FaceRecInfo:             "FaceRecInfo",
+	FileSource:              "FileSource",
+	OrderNumber:             "OrderNumber",
+	FrameNumber:             "FrameNumber",
+	Parallax:                "Parallax",
+}
+
+// Fujifilm Makernote Tags
+//
+// Derived from https://exiftool.org/TagNames/FujiFilm.html
+const (
+	Version                 tag.ID = 0x0000
+	InternalSerialNumber    tag.ID = 0x0010
+	Quality                 tag.ID = 0x1000
+	Sharpness               tag.ID = 0x1001
+	WhiteBalance            tag.ID = 0x1002
+	Saturation              tag.ID = 0x1003
+	Contrast                tag.ID = 0x1004
+	ColorTemperature        tag.ID = 0x1005
+	Contrast2               tag.ID = 0x1006
+	WhiteBalanceFineTune    tag.ID = 0x100a
+	NoiseReduction          tag.ID = 0x100b
+	HighISONoiseReduction   tag.ID = 0x100e
+	FujiFlashMode           tag.ID = 0x1010
+	FlashExposureComp       tag.ID = 0x1011
+	Macro                   tag.ID = 0x1020
+	FocusMode               tag.ID = 0x1021
+	AFMode                  tag.ID = 0x1022
+	FocusPixel              tag.ID = 0x1023
+	PrioritySettings        tag.ID = 0x102b
+	FocusSettings           tag.ID = 0x102d
+	AFCSettings             tag.ID = 0x102e
+	SlowSync                tag.ID = 0x1030
+	PictureMode             tag.ID = 0x1031
+	ExposureCount           tag.ID = 0x1032
+	EXRAuto                 tag.ID = 0x1033
+	EXRMode                 tag.ID = 0x1034
+	ShadowTone              tag.ID = 0x1040
+	HighlightTone           tag.ID = 0x1041
+	DigitalZoom             tag.ID = 0x1044
+	LensModulationOptimizer tag.ID = 0x1045
+	GrainEffectRoughness    tag.ID = 0x1047
+	ColorChromeEffect       tag.ID = 0x1048
+	BWAdjustment            tag.ID = 0x1049
+	BWMagentaGreen          tag.ID = 0x104b
+	GrainEffectSize         tag.ID = 0x104c
+	CropMode                tag.ID = 0x104d
+	ColorChromeFXBlue       tag.ID = 0x104e
+	ShutterType             tag.ID = 0x1050
+	AutoBracketing          tag.ID = 0x1100
+	SequenceNumber          tag.ID = 0x1101
+	DriveSettings           tag.ID = 0x1103
+	PanoramaAngle           tag.ID = 0x1153
+	PanoramaDirection       tag.ID = 0x1154
+	AdvancedFilter          tag.ID = 0x1201
+	ColorMode               tag.ID = 0x1210
+	BlurWarning             tag.ID = 0x1300
+	FocusWarning            tag.ID = 0x1301
+	ExposureWarning         tag.ID = 0x1302
+	GEImageSize             tag.ID = 0x1304
+	DynamicRange            tag.ID = 0x1400
+	FilmMode                tag.ID = 0x1401
+	DynamicRangeSetting     tag.ID = 0x1402
+	DevelopmentDynamicRange tag.ID = 0x1403
+	MinFocalLength          tag.ID = 0x1404
+	MaxFocalLength          tag.ID = 0x1405
+	MaxApertureAtMinFocal   tag.ID = 0x1406
+	MaxApertureAtMaxFocal   tag.ID = 0x1407
+	AutoDynamicRange        tag.ID = 0x140b
+	ImageStabilization      tag.ID = 0x1422
+	SceneRecognition        tag.ID = 0x1425
+	Rating                  tag.ID = 0x1431
+	ImageGeneration         tag.ID = 0x1436
+	ImageCount              tag.ID = 0x1438
+	DRangePriority          tag.ID = 0x1443
+	DRangePriorityAuto      tag.ID = 0x1444
+	DRangePriorityFixed     tag.ID = 0x1445
+	FlickerReduction        tag.ID = 0x1446
+	VideoRecordingMode      tag.ID = 0x3803
+	FrameRate               tag.ID = 0x3820
+	FrameWidth              tag.ID = 0x3821
+	FrameHeight             tag.ID = 0x3822
+	FacesDetected           tag.ID = 0x4100
+	FacePositions           tag.ID = 0x4103
+	NumFaceElements         tag.ID = 0x4200
+	FaceRecInfo             tag.ID = 0x4282
+	FileSource              tag.ID = 0x8000
+	OrderNumber             tag.ID = 0x8002
+	FrameNumber             tag.ID = 0x8003
+	Parallax                tag.ID = 0xb211
+)
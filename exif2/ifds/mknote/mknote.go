@@ -0,0 +1,257 @@
+// Package mknote provides types and functions for decoding Exif Makernotes
+package mknote
+
+import (
+	"errors"
+	"math"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// Errors
+var (
+	ErrMakerNoteHeader = errors.New("error makernote header not valid")
+	ErrIfdOffset       = errors.New("error makernote ifd offset out of bounds")
+	ErrIfdTagCount     = errors.New("error makernote ifd tag count not valid")
+)
+
+const (
+	// maxTagCount is the maximum number of tags read from a single Makernote Ifd
+	maxTagCount = 512
+
+	// typeTiffIfd is the Tiff IFD tag type (13) used by some Makernotes for sub-Ifds
+	typeTiffIfd tag.Type = 13
+)
+
+// Visitor is called by a Reader with every Entry that it reads. ifd is the
+// name of the sub-Ifd of the Entry or "" for the Makernote Ifd. An error
+// returned by the Visitor is returned by ReadIfd.
+type Visitor func(ifd string, e Entry) error
+
+// Reader reads Ifds and tag values from a Makernote buffer.
+//
+// Offset is the offset of Buf[0] from the beginning of the Tiff Header.
+// Base is the offset from the beginning of the Tiff Header that tag value
+// offsets are relative to. Makernotes with offsets relative to the Makernote
+// have Base == Offset. Makernotes with offsets relative to the Tiff Header
+// have Base == 0.
+//
+// Visitor, if not nil, is called with the entries of every Ifd that is read
+// and Ifd is the name of the Ifd that is passed to it.
+type Reader struct {
+	Buf       []byte
+	Offset    uint32
+	Base      uint32
+	ByteOrder utils.ByteOrder
+	Visitor   Visitor
+	Ifd       string
+}
+
+// NewReader returns a new Makernote Reader
+func NewReader(buf []byte, byteOrder utils.ByteOrder, offset uint32, base uint32) Reader {
+	return Reader{Buf: buf, ByteOrder: byteOrder, Offset: offset, Base: base}
+}
+
+// index returns the position in Buf of an offset relative to Base.
+func (r Reader) index(offset uint32) int {
+	return int(int64(r.Base) + int64(offset) - int64(r.Offset))
+}
+
+// ReadIfd reads the Ifd at offset (relative to Base) and returns its entries
+// and the offset of the next Ifd.
+func (r Reader) ReadIfd(offset uint32) (entries []Entry, next uint32, err error) {
+	i := r.index(offset)
+	if i < 0 || i+2 > len(r.Buf) {
+		return nil, 0, ErrIfdOffset
+	}
+	count := int(r.ByteOrder.Uint16(r.Buf[i:]))
+	i += 2
+	if count == 0 || count > maxTagCount || i+count*12 > len(r.Buf) {
+		return nil, 0, ErrIfdTagCount
+	}
+	entries = make([]Entry, 0, count)
+	for j := 0; j < count; j++ {
+		if e, ok := r.entryFromBuffer(r.Buf[i+j*12:]); ok {
+			entries = append(entries, e)
+		}
+	}
+	i += count * 12
+	if i+4 <= len(r.Buf) {
+		next = r.ByteOrder.Uint32(r.Buf[i:])
+	}
+	if r.Visitor != nil {
+		for _, e := range entries {
+			if err = r.Visitor(r.Ifd, e); err != nil {
+				return entries, next, err
+			}
+		}
+	}
+	return entries, next, nil
+}
+
+// entryFromBuffer returns an Entry from 12 bytes of an Ifd
+func (r Reader) entryFromBuffer(buf []byte) (e Entry, ok bool) {
+	e.ID = tag.ID(r.ByteOrder.Uint16(buf[:2]))
+	e.Type = tag.Type(r.ByteOrder.Uint16(buf[2:4]))
+	e.UnitCount = r.ByteOrder.Uint32(buf[4:8])
+	e.ValueOffset = r.ByteOrder.Uint32(buf[8:12])
+	e.ByteOrder = r.ByteOrder
+	if e.Type == typeTiffIfd {
+		e.Type = tag.TypeIfd
+	}
+	if !e.Type.IsValid() {
+		return e, false
+	}
+	size := int64(e.Type.Size()) * int64(e.UnitCount)
+	if size <= 4 {
+		e.value = buf[8 : 8+size]
+		return e, true
+	}
+	i := int64(r.index(e.ValueOffset))
+	if i >= 0 && i+size <= int64(len(r.Buf)) {
+		e.value = r.Buf[i : i+size]
+	}
+	return e, true
+}
+
+// SubReader returns a Reader with the same Buf and ByteOrder and a new Base.
+func (r Reader) SubReader(base uint32) Reader {
+	return Reader{Buf: r.Buf, ByteOrder: r.ByteOrder, Offset: r.Offset, Base: base, Visitor: r.Visitor, Ifd: r.Ifd}
+}
+
+// WithIfd returns a copy of the Reader that passes the Ifd name to the
+// Visitor, ex: "Equipment" for an Olympus sub-Ifd.
+func (r Reader) WithIfd(name string) Reader {
+	r.Ifd = name
+	return r
+}
+
+// Entry is a Makernote Ifd entry with its value.
+type Entry struct {
+	value       []byte
+	ValueOffset uint32
+	UnitCount   uint32
+	ID          tag.ID
+	Type        tag.Type
+	ByteOrder   utils.ByteOrder
+}
+
+// Bytes returns the raw value of the Entry. Returns nil if the value
+// is outside of the Makernote buffer.
+func (e Entry) Bytes() []byte {
+	return e.value
+}
+
+// Uint16 returns the first value of the Entry as a uint16.
+// Supports tag types Byte, Short and Long.
+func (e Entry) Uint16() uint16 {
+	return uint16(e.Uint32())
+}
+
+// Uint32 returns the first value of the Entry as a uint32.
+// Supports tag types Byte, Short and Long.
+func (e Entry) Uint32() uint32 {
+	return e.Uint32At(0)
+}
+
+// Uint32At returns the value at index i of the Entry as a uint32.
+// Supports tag types Byte, Undefined, Short, SShort, Long, SLong and Ifd.
+func (e Entry) Uint32At(i int) uint32 {
+	switch e.Type {
+	case tag.TypeByte, tag.TypeUndefined:
+		if i < len(e.value) {
+			return uint32(e.value[i])
+		}
+	case tag.TypeShort, tag.TypeSignedShort:
+		if 2*i+2 <= len(e.value) {
+			return uint32(e.ByteOrder.Uint16(e.value[2*i:]))
+		}
+	case tag.TypeLong, tag.TypeSignedLong, tag.TypeIfd:
+		if 4*i+4 <= len(e.value) {
+			return e.ByteOrder.Uint32(e.value[4*i:])
+		}
+	}
+	return 0
+}
+
+// Int32At returns the value at index i of the Entry as an int32.
+// Signed types are sign extended.
+func (e Entry) Int32At(i int) int32 {
+	switch e.Type {
+	case tag.TypeSignedShort:
+		return int32(int16(e.Uint32At(i)))
+	}
+	return int32(e.Uint32At(i))
+}
+
+// Uint16s returns the values of the Entry as a []uint16.
+// This function allocates.
+func (e Entry) Uint16s() []uint16 {
+	n := e.Len()
+	vals := make([]uint16, n)
+	for i := 0; i < n; i++ {
+		vals[i] = uint16(e.Uint32At(i))
+	}
+	return vals
+}
+
+// Len returns the number of values available in the Entry.
+func (e Entry) Len() int {
+	if s := int(e.Type.Size()); s > 0 {
+		return len(e.value) / s
+	}
+	return 0
+}
+
+// Rational returns the first value of the Entry as a numerator and denominator.
+func (e Entry) Rational() (n uint32, d uint32) {
+	if (e.Type == tag.TypeRational || e.Type == tag.TypeSignedRational) && len(e.value) >= 8 {
+		return e.ByteOrder.Uint32(e.value[:4]), e.ByteOrder.Uint32(e.value[4:8])
+	}
+	return 0, 0
+}
+
+// Float returns the first value of the Entry as a float64.
+// Supports tag types Rational, SRational, Float and Double.
+func (e Entry) Float() float64 {
+	switch e.Type {
+	case tag.TypeRational:
+		if n, d := e.Rational(); d != 0 {
+			return float64(n) / float64(d)
+		}
+	case tag.TypeSignedRational:
+		if n, d := e.Rational(); d != 0 {
+			return float64(int32(n)) / float64(int32(d))
+		}
+	case tag.TypeFloat:
+		if len(e.value) >= 4 {
+			return float64(math.Float32frombits(e.ByteOrder.Uint32(e.value)))
+		}
+	case tag.TypeDouble:
+		if len(e.value) >= 8 {
+			return math.Float64frombits(e.ByteOrder.Uint64(e.value))
+		}
+	}
+	return 0
+}
+
+// String returns the value of the Entry as a string with trailing
+// NUL bytes and spaces removed. This function allocates.
+func (e Entry) String() string {
+	return string(TrimNUL(e.value))
+}
+
+// TrimNUL removes trailing NUL bytes and spaces from buf
+func TrimNUL(buf []byte) []byte {
+	for i := 0; i < len(buf); i++ {
+		if buf[i] == 0 {
+			buf = buf[:i]
+			break
+		}
+	}
+	for len(buf) > 0 && buf[len(buf)-1] == ' ' {
+		buf = buf[:len(buf)-1]
+	}
+	return buf
+}
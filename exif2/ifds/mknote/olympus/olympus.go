@@ -0,0 +1,239 @@
+// Package olympus provides types and functions for decoding Olympus and OM System Makernotes
+package olympus
+
+import (
+	"bytes"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/meta/olympus"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// Olympus Makernote headers
+var (
+	// olympusHeader is "OLYMPUS\0" followed by the byte order and version (12 bytes).
+	// Value offsets are relative to the beginning of the Makernote.
+	olympusHeader = []byte("OLYMPUS\x00")
+
+	// omSystemHeader is "OM SYSTEM\0\0\0" followed by the byte order and version (16 bytes).
+	// Value offsets are relative to the beginning of the Makernote.
+	omSystemHeader = []byte("OM SYSTEM\x00\x00\x00")
+
+	// olympHeader is the older "OLYMP\0" header (8 bytes).
+	// Value offsets are relative to the Tiff Header.
+	olympHeader = []byte("OLYMP\x00")
+)
+
+// IsOlympusMkNoteHeaderBytes returns true if buf begins with one of the
+// Olympus or OM System Makernote headers
+func IsOlympusMkNoteHeaderBytes(buf []byte) bool {
+	return bytes.HasPrefix(buf, olympusHeader) || bytes.HasPrefix(buf, omSystemHeader) || bytes.HasPrefix(buf, olympHeader)
+}
+
+// NewReader returns a Makernote Reader and the offset of the first Ifd for
+// the Olympus Makernote in buf. offset is the offset of buf from the beginning
+// of the Tiff Header and byteOrder is the byte order of the Tiff Header.
+func NewReader(buf []byte, offset uint32, byteOrder utils.ByteOrder) (r mknote.Reader, ifdOffset uint32, err error) {
+	switch {
+	case bytes.HasPrefix(buf, omSystemHeader) && len(buf) > 16:
+		byteOrder = headerByteOrder(buf[12:14], byteOrder)
+		return mknote.NewReader(buf, byteOrder, offset, offset), 16, nil
+	case bytes.HasPrefix(buf, olympusHeader) && len(buf) > 12:
+		byteOrder = headerByteOrder(buf[8:10], byteOrder)
+		return mknote.NewReader(buf, byteOrder, offset, offset), 12, nil
+	case bytes.HasPrefix(buf, olympHeader) && len(buf) > 8:
+		return mknote.NewReader(buf, byteOrder, offset, 0), offset + 8, nil
+	}
+	return r, 0, mknote.ErrMakerNoteHeader
+}
+
+func headerByteOrder(buf []byte, byteOrder utils.ByteOrder) utils.ByteOrder {
+	switch string(buf) {
+	case "II":
+		return utils.LittleEndian
+	case "MM":
+		return utils.BigEndian
+	}
+	return byteOrder
+}
+
+// DecodeMakerNote decodes an Olympus Makernote from buf. offset is the offset
+// of buf from the beginning of the Tiff Header and byteOrder is the byte order
+// of the Tiff Header. v, if not nil, is called with every Makernote entry
+// and the name of its sub-Ifd.
+func DecodeMakerNote(buf []byte, offset uint32, byteOrder utils.ByteOrder, v mknote.Visitor) (mn olympus.MakerNote, err error) {
+	r, ifdOffset, err := NewReader(buf, offset, byteOrder)
+	if err != nil {
+		return mn, err
+	}
+	r.Visitor = v
+	entries, _, err := r.ReadIfd(ifdOffset)
+	if err != nil {
+		return mn, err
+	}
+	for _, e := range entries {
+		switch e.ID {
+		case CameraType:
+			mn.CameraType = e.String()
+		case SerialNumber:
+			if mn.SerialNumber == "" {
+				mn.SerialNumber = e.String()
+			}
+		case Equipment, CameraSettings, RawDevelopment, RawDevelopment2, ImageProcessing:
+			// Sub-Ifds are either of type Ifd or Undefined with the
+			// Ifd at ValueOffset. Offsets within are relative to the same base.
+			sub, _, err := r.WithIfd(TagOlympusIDMap[e.ID]).ReadIfd(e.ValueOffset)
+			if err == mknote.ErrIfdOffset || err == mknote.ErrIfdTagCount {
+				continue
+			}
+			if err != nil {
+				return mn, err
+			}
+			switch e.ID {
+			case Equipment:
+				decodeEquipment(&mn, sub)
+			case CameraSettings:
+				decodeCameraSettings(&mn, sub)
+			case RawDevelopment:
+				decodeRawDevelopment(&mn, sub)
+			case RawDevelopment2:
+				decodeRawDevelopment2(&mn, sub)
+			case ImageProcessing:
+				decodeImageProcessing(&mn, sub)
+			}
+		}
+	}
+	return mn, nil
+}
+
+// decodeEquipment decodes the Olympus Equipment Ifd
+func decodeEquipment(mn *olympus.MakerNote, entries []mknote.Entry) {
+	for _, e := range entries {
+		switch e.ID {
+		case EquipmentCameraType2:
+			mn.CameraType = e.String()
+		case EquipmentSerialNumber:
+			if str := e.String(); str != "" {
+				mn.SerialNumber = str
+			}
+		case EquipmentInternalSerialNumber:
+			mn.InternalSerialNumber = e.String()
+		case EquipmentBodyFirmwareVersion:
+			mn.BodyFirmwareVersion = versionString(e)
+		case EquipmentLensType:
+			mn.LensType = olympus.NewLensType(e.Bytes())
+		case EquipmentLensSerialNumber:
+			mn.LensSerialNumber = e.String()
+		case EquipmentLensModel:
+			mn.LensModel = e.String()
+		}
+	}
+}
+
+// decodeCameraSettings decodes the Olympus CameraSettings Ifd
+func decodeCameraSettings(mn *olympus.MakerNote, entries []mknote.Entry) {
+	for _, e := range entries {
+		switch e.ID {
+		case CameraSettingsFocusBracketStepSize:
+			mn.FocusBracketStepSize = uint8(e.Uint32())
+		case CameraSettingsArtFilter:
+			mn.ArtFilter = olympus.ArtFilter(e.Uint16())
+		case CameraSettingsDriveMode:
+			mn.DriveMode = olympus.NewDriveMode(e.Uint16s())
+		case CameraSettingsImageStabilization:
+			mn.ImageStabilization = olympus.ImageStabilization(e.Uint32())
+		case CameraSettingsStackedImage:
+			mn.StackedImage = olympus.StackedImage{Type: e.Uint32At(0), Count: e.Uint32At(1)}
+		}
+	}
+}
+
+// decodeRawDevelopment decodes the Olympus RawDevelopment Ifd
+func decodeRawDevelopment(mn *olympus.MakerNote, entries []mknote.Entry) {
+	for _, e := range entries {
+		switch e.ID {
+		case RawDevExposureBiasValue:
+			mn.RawDevelopment.ExposureBias = float32(e.Float())
+		case RawDevWhiteBalanceValue:
+			mn.RawDevelopment.WhiteBalanceValue = e.Uint16()
+		case RawDevWBFineAdjustment:
+			mn.RawDevelopment.WBFineAdjustment = int16(e.Int32At(0))
+		case RawDevGrayPoint:
+			mn.RawDevelopment.GrayPoint = grayPoint(e)
+		case RawDevColorSpace:
+			mn.RawDevelopment.ColorSpace = olympus.RawDevColorSpace(e.Uint16())
+		case RawDevEngine:
+			mn.RawDevelopment.Engine = olympus.RawDevEngine(e.Uint16())
+		}
+	}
+}
+
+// decodeRawDevelopment2 decodes the Olympus RawDevelopment2 Ifd. It records
+// the same values as the RawDevelopment Ifd with different tag IDs.
+func decodeRawDevelopment2(mn *olympus.MakerNote, entries []mknote.Entry) {
+	for _, e := range entries {
+		switch e.ID {
+		case RawDev2ExposureBiasValue:
+			mn.RawDevelopment.ExposureBias = float32(e.Float())
+		case RawDev2WhiteBalanceValue:
+			mn.RawDevelopment.WhiteBalanceValue = e.Uint16()
+		case RawDev2WBFineAdjustment:
+			mn.RawDevelopment.WBFineAdjustment = int16(e.Int32At(0))
+		case RawDev2GrayPoint:
+			mn.RawDevelopment.GrayPoint = grayPoint(e)
+		case RawDev2ColorSpace:
+			mn.RawDevelopment.ColorSpace = olympus.RawDevColorSpace(e.Uint16())
+		case RawDev2Engine:
+			mn.RawDevelopment.Engine = olympus.RawDevEngine(e.Uint16())
+		}
+	}
+}
+
+// decodeImageProcessing decodes the Olympus ImageProcessing Ifd
+func decodeImageProcessing(mn *olympus.MakerNote, entries []mknote.Entry) {
+	ip := &mn.ImageProcessing
+	for _, e := range entries {
+		switch e.ID {
+		case ImageProcessingWB_RBLevels:
+			ip.WBRBLevels = [2]uint16{uint16(e.Uint32At(0)), uint16(e.Uint32At(1))}
+		case ImageProcessingBlackLevel2:
+			for i := range ip.BlackLevel {
+				ip.BlackLevel[i] = uint16(e.Uint32At(i))
+			}
+		case ImageProcessingValidBits:
+			ip.ValidBits = e.Uint16()
+		case ImageProcessingCropLeft:
+			ip.CropLeft = e.Uint32()
+		case ImageProcessingCropTop:
+			ip.CropTop = e.Uint32()
+		case ImageProcessingCropWidth:
+			ip.CropWidth = e.Uint32()
+		case ImageProcessingCropHeight:
+			ip.CropHeight = e.Uint32()
+		case ImageProcessingDistortionCorrection2:
+			ip.DistortionCorrection = e.Uint32() == 1
+		case ImageProcessingShadingCompensation2:
+			ip.ShadingCompensation = e.Uint32() == 1
+		}
+	}
+}
+
+// grayPoint returns the 3 values of a RawDevGrayPoint
+func grayPoint(e mknote.Entry) (gp [3]uint16) {
+	for i := range gp {
+		gp[i] = uint16(e.Uint32At(i))
+	}
+	return gp
+}
+
+// versionString returns the firmware version as a string. Olympus records
+// firmware versions as int32u (e.g. 0x1200 = "1.200") or as a string.
+func versionString(e mknote.Entry) string {
+	if e.Type == tag.TypeLong {
+		v := e.Uint32()
+		const hex = "0123456789abcdef"
+		return string([]byte{hex[(v>>12)&0xf], '.', hex[(v>>8)&0xf], hex[(v>>4)&0xf], hex[v&0xf]})
+	}
+	return e.String()
+}
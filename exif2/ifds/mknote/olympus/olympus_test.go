@@ -0,0 +1,137 @@
+package olympus
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/olympus"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// subIfd returns an Olympus sub-Ifd Entry of tag type Ifd (13)
+func subIfd(id tag.ID, entries ...tifftest.Entry) tifftest.Entry {
+	e := tifftest.SubIfd(id, entries...)
+	e.Type = 13
+	return e
+}
+
+func testMakerNote() []byte {
+	le := binary.LittleEndian
+	return tifftest.AppendIfd([]byte("OLYMPUS\x00II\x03\x00"), le, 0, []tifftest.Entry{
+		subIfd(Equipment,
+			tifftest.ASCII(EquipmentCameraType2, "E-M1X"),
+			tifftest.ASCII(EquipmentSerialNumber, "BHX123456"),
+			tifftest.Bytes(EquipmentLensType, tag.TypeByte, []byte{0, 0, 0x19, 0x10, 0, 0}),
+			tifftest.ASCII(EquipmentLensModel, "OLYMPUS M.12-40mm F2.8            "),
+		),
+		subIfd(CameraSettings,
+			tifftest.Shorts(le, CameraSettingsArtFilter, 24, 0, 0, 0),
+			tifftest.Shorts(le, CameraSettingsDriveMode, 5, 1, 1<<6),
+			tifftest.Longs(le, CameraSettingsImageStabilization, 4),
+			tifftest.Longs(le, CameraSettingsStackedImage, 9, 8),
+		),
+		subIfd(RawDevelopment2,
+			tifftest.Bytes(RawDev2ExposureBiasValue, tag.TypeSignedRational, tifftest.U32s(le, 0xfffffffd, 10)),
+			tifftest.Shorts(le, RawDev2WhiteBalanceValue, 5300),
+			tifftest.Bytes(RawDev2WBFineAdjustment, tag.TypeSignedShort, tifftest.U16s(le, 0xfffe)),
+			tifftest.Shorts(le, RawDev2GrayPoint, 100, 110, 120),
+			tifftest.Shorts(le, RawDev2ColorSpace, 1),
+			tifftest.Shorts(le, RawDev2Engine, 3),
+		),
+		subIfd(ImageProcessing,
+			tifftest.Shorts(le, ImageProcessingWB_RBLevels, 498, 420, 256, 256),
+			tifftest.Shorts(le, ImageProcessingBlackLevel2, 254, 256, 256, 255),
+			tifftest.Shorts(le, ImageProcessingValidBits, 12, 12),
+			tifftest.Shorts(le, ImageProcessingCropLeft, 8, 0),
+			tifftest.Shorts(le, ImageProcessingCropTop, 4, 0),
+			tifftest.Shorts(le, ImageProcessingCropWidth, 5184, 0),
+			tifftest.Shorts(le, ImageProcessingCropHeight, 3888, 0),
+			tifftest.Bytes(ImageProcessingDistortionCorrection2, tag.TypeByte, []byte{1}),
+			tifftest.Bytes(ImageProcessingShadingCompensation2, tag.TypeByte, []byte{0}),
+		),
+	})
+}
+
+func TestDecodeMakerNote(t *testing.T) {
+	mn, err := DecodeMakerNote(testMakerNote(), 0x400, utils.BigEndian, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mn.CameraType != "E-M1X" || mn.SerialNumber != "BHX123456" {
+		t.Errorf("Incorrect Equipment got %s %s", mn.CameraType, mn.SerialNumber)
+	}
+	if lens := mn.LensType.String(); lens != "Olympus M.Zuiko Digital ED 12-40mm F2.8 Pro" {
+		t.Errorf("Incorrect LensType got %s", lens)
+	}
+	if mn.LensModel != "OLYMPUS M.12-40mm F2.8" {
+		t.Errorf("Incorrect LensModel got %q", mn.LensModel)
+	}
+	if mn.ArtFilter.String() != "Watercolor" {
+		t.Errorf("Incorrect ArtFilter got %s", mn.ArtFilter)
+	}
+	if !mn.IsFocusBracketing() || mn.DriveMode.String() != "Focus Bracketing" {
+		t.Errorf("Incorrect DriveMode got %s", mn.DriveMode)
+	}
+	if !mn.IsFocusStacked() || mn.StackedImage.Count != 8 {
+		t.Errorf("Incorrect StackedImage got %s", mn.StackedImage)
+	}
+	if mn.ImageStabilization != olympus.ImageStabilization(4) {
+		t.Errorf("Incorrect ImageStabilization got %s", mn.ImageStabilization)
+	}
+
+	wantedRawDev := olympus.RawDevelopment{
+		ExposureBias:      -0.3,
+		WhiteBalanceValue: 5300,
+		WBFineAdjustment:  -2,
+		GrayPoint:         [3]uint16{100, 110, 120},
+		ColorSpace:        1,
+		Engine:            3,
+	}
+	if mn.RawDevelopment != wantedRawDev {
+		t.Errorf("Incorrect RawDevelopment wanted %+v got %+v", wantedRawDev, mn.RawDevelopment)
+	}
+	if mn.RawDevelopment.ColorSpace.String() != "Adobe RGB" || mn.RawDevelopment.Engine.String() != "Advanced High Function" {
+		t.Errorf("Incorrect RawDevelopment got %s %s", mn.RawDevelopment.ColorSpace, mn.RawDevelopment.Engine)
+	}
+	wantedIP := olympus.ImageProcessing{
+		WBRBLevels:           [2]uint16{498, 420},
+		BlackLevel:           [4]uint16{254, 256, 256, 255},
+		ValidBits:            12,
+		CropLeft:             8,
+		CropTop:              4,
+		CropWidth:            5184,
+		CropHeight:           3888,
+		DistortionCorrection: true,
+	}
+	if mn.ImageProcessing != wantedIP {
+		t.Errorf("Incorrect ImageProcessing wanted %+v got %+v", wantedIP, mn.ImageProcessing)
+	}
+
+	// RawDevelopment Ifd
+	le := binary.LittleEndian
+	buf := tifftest.AppendIfd([]byte("OLYMPUS\x00II\x03\x00"), le, 0, []tifftest.Entry{
+		subIfd(RawDevelopment,
+			tifftest.Rationals(le, RawDevExposureBiasValue, 7, 10),
+			tifftest.Shorts(le, RawDevColorSpace, 2),
+			tifftest.Shorts(le, RawDevEngine, 1),
+		),
+	})
+	if mn, err = DecodeMakerNote(buf, 0, utils.LittleEndian, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rd := mn.RawDevelopment; rd.ExposureBias != 0.7 || rd.ColorSpace.String() != "Pro Photo RGB" || rd.Engine.String() != "High Function" {
+		t.Errorf("Incorrect RawDevelopment got %+v", rd)
+	}
+
+	// Unknown LensType
+	if lt := olympus.NewLensType([]byte{0, 0, 0x99, 0x10, 0, 0}); lt.String() != "0 99 10" {
+		t.Errorf("Incorrect LensType got %s", lt)
+	}
+
+	// Error Header
+	if _, err = DecodeMakerNote([]byte("NOTOLYMPUSMAKERNOTE"), 0, utils.LittleEndian, nil); err == nil {
+		t.Errorf("Wanted error for invalid header")
+	}
+}
@@ -0,0 +1,397 @@
+package olympus
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagOlympusString returns the string representation of a tag.ID for Olympus Makernotes
+func TagOlympusString(id tag.ID) string {
+	if name, ok := TagOlympusIDMap[id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// SubIfdTagString returns the string representation of a tag.ID for the
+// Olympus Makernote sub-Ifd with the given tag.ID
+func SubIfdTagString(ifd tag.ID, id tag.ID) string {
+	var m map[tag.ID]string
+	switch ifd {
+	case Equipment:
+		m = TagEquipmentIDMap
+	case CameraSettings:
+		m = TagCameraSettingsIDMap
+	case RawDevelopment:
+		m = TagRawDevelopmentIDMap
+	case RawDevelopment2:
+		m = TagRawDevelopment2IDMap
+	case ImageProcessing:
+		m = TagImageProcessingIDMap
+	}
+	if name, ok := m[id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// TagOlympusIDMap is a Map of tag.ID to string for the OlympusMakerNote tags
+var TagOlympusIDMap = map[tag.ID]string{
+	MakerNoteVersion:    "MakerNoteVersion",
+	ThumbnailImage:      "ThumbnailImage",
+	BodyFirmwareVersion: "BodyFirmwareVersion",
+	SpecialMode:         "SpecialMode",
+	Quality:             "Quality",
+	Macro:               "Macro",
+	DigitalZoom:         "DigitalZoom",
+	CameraType:          "CameraType",
+	TextInfo:            "TextInfo",
+	CameraID:            "CameraID",
+	SerialNumber:        "SerialNumber",
+	PrintIM:             "PrintIM",
+	Equipment:           "Equipment",
+	CameraSettings:      "CameraSettings",
+	RawDevelopment:      "RawDevelopment",
+	RawDevelopment2:     "RawDevelopment2",
+	ImageProcessing:     "ImageProcessing",
+	FocusInfo:           "FocusInfo",
+	RawInfo:             "RawInfo",
+	MainInfo:            "MainInfo",
+}
+
+// TagEquipmentIDMap is a Map of tag.ID to string for the Olympus Equipment Ifd tags
+var TagEquipmentIDMap = map[tag.ID]string{
+	EquipmentVersion:                 "EquipmentVersion",
+	EquipmentCameraType2:             "CameraType2",
+	EquipmentSerialNumber:            "SerialNumber",
+	EquipmentInternalSerialNumber:    "InternalSerialNumber",
+	EquipmentFocalPlaneDiagonal:      "FocalPlaneDiagonal",
+	EquipmentBodyFirmwareVersion:     "BodyFirmwareVersion",
+	EquipmentLensType:                "LensType",
+	EquipmentLensSerialNumber:        "LensSerialNumber",
+	EquipmentLensModel:               "LensModel",
+	EquipmentLensFirmwareVersion:     "LensFirmwareVersion",
+	EquipmentMaxApertureAtMinFocal:   "MaxApertureAtMinFocal",
+	EquipmentMaxApertureAtMaxFocal:   "MaxApertureAtMaxFocal",
+	EquipmentMinFocalLength:          "MinFocalLength",
+	EquipmentMaxFocalLength:          "MaxFocalLength",
+	EquipmentMaxAperture:             "MaxAperture",
+	EquipmentLensProperties:          "LensProperties",
+	EquipmentExtender:                "Extender",
+	EquipmentExtenderSerialNumber:    "ExtenderSerialNumber",
+	EquipmentExtenderModel:           "ExtenderModel",
+	EquipmentExtenderFirmwareVersion: "ExtenderFirmwareVersion",
+	EquipmentConversionLens:          "ConversionLens",
+	EquipmentFlashType:               "FlashType",
+	EquipmentFlashModel:              "FlashModel",
+	EquipmentFlashFirmwareVersion:    "FlashFirmwareVersion",
+	EquipmentFlashSerialNumber:       "FlashSerialNumber",
+}
+
+// TagCameraSettingsIDMap is a Map of tag.ID to string for the Olympus CameraSettings Ifd tags
+var TagCameraSettingsIDMap = map[tag.ID]string{
+	CameraSettingsVersion:                 "CameraSettingsVersion",
+	CameraSettingsPreviewImageValid:       "PreviewImageValid",
+	CameraSettingsPreviewImageStart:       "PreviewImageStart",
+	CameraSettingsPreviewImageLength:      "PreviewImageLength",
+	CameraSettingsExposureMode:            "ExposureMode",
+	CameraSettingsAELock:                  "AELock",
+	CameraSettingsMeteringMode:            "MeteringMode",
+	CameraSettingsExposureShift:           "ExposureShift",
+	CameraSettingsNDFilter:                "NDFilter",
+	CameraSettingsMacroMode:               "MacroMode",
+	CameraSettingsFocusMode:               "FocusMode",
+	CameraSettingsFocusProcess:            "FocusProcess",
+	CameraSettingsAFSearch:                "AFSearch",
+	CameraSettingsAFAreas:                 "AFAreas",
+	CameraSettingsAFPointSelected:         "AFPointSelected",
+	CameraSettingsAFFineTune:              "AFFineTune",
+	CameraSettingsAFFineTuneAdj:           "AFFineTuneAdj",
+	CameraSettingsFocusBracketStepSize:    "FocusBracketStepSize",
+	CameraSettingsFlashMode:               "FlashMode",
+	CameraSettingsFlashExposureComp:       "FlashExposureComp",
+	CameraSettingsFlashRemoteControl:      "FlashRemoteControl",
+	CameraSettingsFlashControlMode:        "FlashControlMode",
+	CameraSettingsFlashIntensity:          "FlashIntensity",
+	CameraSettingsManualFlashStrength:     "ManualFlashStrength",
+	CameraSettingsWhiteBalance2:           "WhiteBalance2",
+	CameraSettingsWhiteBalanceTemperature: "WhiteBalanceTemperature",
+	CameraSettingsWhiteBalanceBracket:     "WhiteBalanceBracket",
+	CameraSettingsCustomSaturation:        "CustomSaturation",
+	CameraSettingsModifiedSaturation:      "ModifiedSaturation",
+	CameraSettingsContrastSetting:         "ContrastSetting",
+	CameraSettingsSharpnessSetting:        "SharpnessSetting",
+	CameraSettingsColorSpace:              "ColorSpace",
+	CameraSettingsSceneMode:               "SceneMode",
+	CameraSettingsNoiseReduction:          "NoiseReduction",
+	CameraSettingsDistortionCorrection:    "DistortionCorrection",
+	CameraSettingsShadingCompensation:     "ShadingCompensation",
+	CameraSettingsCompressionFactor:       "CompressionFactor",
+	CameraSettingsGradation:               "Gradation",
+	CameraSettingsPictureMode:             "PictureMode",
+	CameraSettingsPictureModeSaturation:   "PictureModeSaturation",
+	CameraSettingsPictureModeContrast:     "PictureModeContrast",
+	CameraSettingsPictureModeSharpness:    "PictureModeSharpness",
+	CameraSettingsPictureModeBWFilter:     "PictureModeBWFilter",
+	CameraSettingsPictureModeTone:         "PictureModeTone",
+	CameraSettingsNoiseFilter:             "NoiseFilter",
+	CameraSettingsArtFilter:               "ArtFilter",
+	CameraSettingsMagicFilter:             "MagicFilter",
+	CameraSettingsPictureModeEffect:       "PictureModeEffect",
+	CameraSettingsToneLevel:               "ToneLevel",
+	CameraSettingsArtFilterEffect:         "ArtFilterEffect",
+	CameraSettingsColorCreatorEffect:      "ColorCreatorEffect",
+	CameraSettingsDriveMode:               "DriveMode",
+	CameraSettingsPanoramaMode:            "PanoramaMode",
+	CameraSettingsImageQuality2:           "ImageQuality2",
+	CameraSettingsImageStabilization:      "ImageStabilization",
+	CameraSettingsStackedImage:            "StackedImage",
+	CameraSettingsManometerPressure:       "ManometerPressure",
+	CameraSettingsManometerReading:        "ManometerReading",
+	CameraSettingsExtendedWBDetect:        "ExtendedWBDetect",
+	CameraSettingsRollAngle:               "RollAngle",
+	CameraSettingsPitchAngle:              "PitchAngle",
+	CameraSettingsDateTimeUTC:             "DateTimeUTC",
+}
+
+// TagRawDevelopmentIDMap is a Map of tag.ID to string for the Olympus RawDevelopment Ifd tags
+var TagRawDevelopmentIDMap = map[tag.ID]string{
+	RawDevVersion:             "RawDevVersion",
+	RawDevExposureBiasValue:   "RawDevExposureBiasValue",
+	RawDevWhiteBalanceValue:   "RawDevWhiteBalanceValue",
+	RawDevWBFineAdjustment:    "RawDevWBFineAdjustment",
+	RawDevGrayPoint:           "RawDevGrayPoint",
+	RawDevSaturationEmphasis:  "RawDevSaturationEmphasis",
+	RawDevMemoryColorEmphasis: "RawDevMemoryColorEmphasis",
+	RawDevContrastValue:       "RawDevContrastValue",
+	RawDevSharpnessValue:      "RawDevSharpnessValue",
+	RawDevColorSpace:          "RawDevColorSpace",
+	RawDevEngine:              "RawDevEngine",
+	RawDevNoiseReduction:      "RawDevNoiseReduction",
+	RawDevEditStatus:          "RawDevEditStatus",
+	RawDevSettings:            "RawDevSettings",
+}
+
+// TagRawDevelopment2IDMap is a Map of tag.ID to string for the Olympus RawDevelopment2 Ifd tags
+var TagRawDevelopment2IDMap = map[tag.ID]string{
+	RawDev2Version:             "RawDevVersion",
+	RawDev2ExposureBiasValue:   "RawDevExposureBiasValue",
+	RawDev2WhiteBalance:        "RawDevWhiteBalance",
+	RawDev2WhiteBalanceValue:   "RawDevWhiteBalanceValue",
+	RawDev2WBFineAdjustment:    "RawDevWBFineAdjustment",
+	RawDev2GrayPoint:           "RawDevGrayPoint",
+	RawDev2ContrastValue:       "RawDevContrastValue",
+	RawDev2SharpnessValue:      "RawDevSharpnessValue",
+	RawDev2SaturationEmphasis:  "RawDevSaturationEmphasis",
+	RawDev2MemoryColorEmphasis: "RawDevMemoryColorEmphasis",
+	RawDev2ColorSpace:          "RawDevColorSpace",
+	RawDev2NoiseReduction:      "RawDevNoiseReduction",
+	RawDev2Engine:              "RawDevEngine",
+	RawDev2PictureMode:         "RawDevPictureMode",
+}
+
+// TagImageProcessingIDMap is a Map of tag.ID to string for the Olympus ImageProcessing Ifd tags
+var TagImageProcessingIDMap = map[tag.ID]string{
+	ImageProcessingVersion:               "ImageProcessingVersion",
+	ImageProcessingWB_RBLevels:           "WB_RBLevels",
+	ImageProcessingColorMatrix:           "ColorMatrix",
+	ImageProcessingEnhancer:              "Enhancer",
+	ImageProcessingEnhancerValues:        "EnhancerValues",
+	ImageProcessingCoringFilter:          "CoringFilter",
+	ImageProcessingCoringValues:          "CoringValues",
+	ImageProcessingBlackLevel2:           "BlackLevel2",
+	ImageProcessingGainBase:              "GainBase",
+	ImageProcessingValidBits:             "ValidBits",
+	ImageProcessingCropLeft:              "CropLeft",
+	ImageProcessingCropTop:               "CropTop",
+	ImageProcessingCropWidth:             "CropWidth",
+	ImageProcessingCropHeight:            "CropHeight",
+	ImageProcessingNoiseReduction2:       "NoiseReduction2",
+	ImageProcessingDistortionCorrection2: "DistortionCorrection2",
+	ImageProcessingShadingCompensation2:  "ShadingCompensation2",
+	ImageProcessingMultipleExposureMode:  "MultipleExposureMode",
+	ImageProcessingAspectRatio:           "AspectRatio",
+	ImageProcessingAspectFrame:           "AspectFrame",
+	ImageProcessingFacesDetected:         "FacesDetected",
+	ImageProcessingFaceDetectArea:        "FaceDetectArea",
+}
+
+// Olympus Makernote Tags
+//
+// Derived from https://exiftool.org/TagNames/Olympus.html
+const (
+	MakerNoteVersion    tag.ID = 0x0000
+	ThumbnailImage      tag.ID = 0x0100
+	BodyFirmwareVersion tag.ID = 0x0104
+	SpecialMode         tag.ID = 0x0200
+	Quality             tag.ID = 0x0201
+	Macro               tag.ID = 0x0202
+	DigitalZoom         tag.ID = 0x0204
+	CameraType          tag.ID = 0x0207
+	TextInfo            tag.ID = 0x0208
+	CameraID            tag.ID = 0x0209
+	SerialNumber        tag.ID = 0x0404
+	PrintIM             tag.ID = 0x0e00
+	Equipment           tag.ID = 0x2010
+	CameraSettings      tag.ID = 0x2020
+	RawDevelopment      tag.ID = 0x2030
+	RawDevelopment2     tag.ID = 0x2031
+	ImageProcessing     tag.ID = 0x2040
+	FocusInfo           tag.ID = 0x2050
+	RawInfo             tag.ID = 0x3000
+	MainInfo            tag.ID = 0x4000
+)
+
+// Olympus Equipment Ifd Tags
+const (
+	EquipmentVersion                 tag.ID = 0x0000
+	EquipmentCameraType2             tag.ID = 0x0100
+	EquipmentSerialNumber            tag.ID = 0x0101
+	EquipmentInternalSerialNumber    tag.ID = 0x0102
+	EquipmentFocalPlaneDiagonal      tag.ID = 0x0103
+	EquipmentBodyFirmwareVersion     tag.ID = 0x0104
+	EquipmentLensType                tag.ID = 0x0201
+	EquipmentLensSerialNumber        tag.ID = 0x0202
+	EquipmentLensModel               tag.ID = 0x0203
+	EquipmentLensFirmwareVersion     tag.ID = 0x0204
+	EquipmentMaxApertureAtMinFocal   tag.ID = 0x0205
+	EquipmentMaxApertureAtMaxFocal   tag.ID = 0x0206
+	EquipmentMinFocalLength          tag.ID = 0x0207
+	EquipmentMaxFocalLength          tag.ID = 0x0208
+	EquipmentMaxAperture             tag.ID = 0x020a
+	EquipmentLensProperties          tag.ID = 0x020b
+	EquipmentExtender                tag.ID = 0x0301
+	EquipmentExtenderSerialNumber    tag.ID = 0x0302
+	EquipmentExtenderModel           tag.ID = 0x0303
+	EquipmentExtenderFirmwareVersion tag.ID = 0x0304
+	EquipmentConversionLens          tag.ID = 0x0403
+	EquipmentFlashType               tag.ID = 0x1000
+	EquipmentFlashModel              tag.ID = 0x1001
+	EquipmentFlashFirmwareVersion    tag.ID = 0x1002
+	EquipmentFlashSerialNumber       tag.ID = 0x1003
+)
+
+// Olympus CameraSettings Ifd Tags
+const (
+	CameraSettingsVersion                 tag.ID = 0x0000
+	CameraSettingsPreviewImageValid       tag.ID = 0x0100
+	CameraSettingsPreviewImageStart       tag.ID = 0x0101
+	CameraSettingsPreviewImageLength      tag.ID = 0x0102
+	CameraSettingsExposureMode            tag.ID = 0x0200
+	CameraSettingsAELock                  tag.ID = 0x0201
+	CameraSettingsMeteringMode            tag.ID = 0x0202
+	CameraSettingsExposureShift           tag.ID = 0x0203
+	CameraSettingsNDFilter                tag.ID = 0x0204
+	CameraSettingsMacroMode               tag.ID = 0x0300
+	CameraSettingsFocusMode               tag.ID = 0x0301
+	CameraSettingsFocusProcess            tag.ID = 0x0302
+	CameraSettingsAFSearch                tag.ID = 0x0303
+	CameraSettingsAFAreas                 tag.ID = 0x0304
+	CameraSettingsAFPointSelected         tag.ID = 0x0305
+	CameraSettingsAFFineTune              tag.ID = 0x0306
+	CameraSettingsAFFineTuneAdj           tag.ID = 0x0307
+	CameraSettingsFocusBracketStepSize    tag.ID = 0x0308
+	CameraSettingsFlashMode               tag.ID = 0x0400
+	CameraSettingsFlashExposureComp       tag.ID = 0x0401
+	CameraSettingsFlashRemoteControl      tag.ID = 0x0403
+	CameraSettingsFlashControlMode        tag.ID = 0x0404
+	CameraSettingsFlashIntensity          tag.ID = 0x0405
+	CameraSettingsManualFlashStrength     tag.ID = 0x0406
+	CameraSettingsWhiteBalance2           tag.ID = 0x0500
+	CameraSettingsWhiteBalanceTemperature tag.ID = 0x0501
+	CameraSettingsWhiteBalanceBracket     tag.ID = 0x0502
+	CameraSettingsCustomSaturation        tag.ID = 0x0503
+	CameraSettingsModifiedSaturation      tag.ID = 0x0504
+	CameraSettingsContrastSetting         tag.ID = 0x0505
+	CameraSettingsSharpnessSetting        tag.ID = 0x0506
+	CameraSettingsColorSpace              tag.ID = 0x0507
+	CameraSettingsSceneMode               tag.ID = 0x0509
+	CameraSettingsNoiseReduction          tag.ID = 0x050a
+	CameraSettingsDistortionCorrection    tag.ID = 0x050b
+	CameraSettingsShadingCompensation     tag.ID = 0x050c
+	CameraSettingsCompressionFactor       tag.ID = 0x050d
+	CameraSettingsGradation               tag.ID = 0x050f
+	CameraSettingsPictureMode             tag.ID = 0x0520
+	CameraSettingsPictureModeSaturation   tag.ID = 0x0521
+	CameraSettingsPictureModeContrast     tag.ID = 0x0523
+	CameraSettingsPictureModeSharpness    tag.ID = 0x0524
+	CameraSettingsPictureModeBWFilter     tag.ID = 0x0525
+	CameraSettingsPictureModeTone         tag.ID = 0x0526
+	CameraSettingsNoiseFilter             tag.ID = 0x0527
+	CameraSettingsArtFilter               tag.ID = 0x0529
+	CameraSettingsMagicFilter             tag.ID = 0x052c
+	CameraSettingsPictureModeEffect       tag.ID = 0x052d
+	CameraSettingsToneLevel               tag.ID = 0x052e
+	CameraSettingsArtFilterEffect         tag.ID = 0x052f
+	CameraSettingsColorCreatorEffect      tag.ID = 0x0532
+	CameraSettingsDriveMode               tag.ID = 0x0600
+	CameraSettingsPanoramaMode            tag.ID = 0x0601
+	CameraSettingsImageQuality2           tag.ID = 0x0603
+	CameraSettingsImageStabilization      tag.ID = 0x0604
+	CameraSettingsStackedImage            tag.ID = 0x0804
+	CameraSettingsManometerPressure       tag.ID = 0x0900
+	CameraSettingsManometerReading        tag.ID = 0x0901
+	CameraSettingsExtendedWBDetect        tag.ID = 0x0902
+	CameraSettingsRollAngle               tag.ID = 0x0903
+	CameraSettingsPitchAngle              tag.ID = 0x0904
+	CameraSettingsDateTimeUTC             tag.ID = 0x0908
+)
+
+// Olympus RawDevelopment Ifd Tags
+const (
+	RawDevVersion             tag.ID = 0x0000
+	RawDevExposureBiasValue   tag.ID = 0x0100
+	RawDevWhiteBalanceValue   tag.ID = 0x0101
+	RawDevWBFineAdjustment    tag.ID = 0x0102
+	RawDevGrayPoint           tag.ID = 0x0103
+	RawDevSaturationEmphasis  tag.ID = 0x0104
+	RawDevMemoryColorEmphasis tag.ID = 0x0105
+	RawDevContrastValue       tag.ID = 0x0106
+	RawDevSharpnessValue      tag.ID = 0x0107
+	RawDevColorSpace          tag.ID = 0x0108
+	RawDevEngine              tag.ID = 0x0109
+	RawDevNoiseReduction      tag.ID = 0x010a
+	RawDevEditStatus          tag.ID = 0x010b
+	RawDevSettings            tag.ID = 0x010c
+)
+
+// Olympus RawDevelopment2 Ifd Tags
+const (
+	RawDev2Version             tag.ID = 0x0000
+	RawDev2ExposureBiasValue   tag.ID = 0x0100
+	RawDev2WhiteBalance        tag.ID = 0x0101
+	RawDev2WhiteBalanceValue   tag.ID = 0x0102
+	RawDev2WBFineAdjustment    tag.ID = 0x0103
+	RawDev2GrayPoint           tag.ID = 0x0104
+	RawDev2ContrastValue       tag.ID = 0x0105
+	RawDev2SharpnessValue      tag.ID = 0x0106
+	RawDev2SaturationEmphasis  tag.ID = 0x0107
+	RawDev2MemoryColorEmphasis tag.ID = 0x0108
+	RawDev2ColorSpace          tag.ID = 0x0109
+	RawDev2NoiseReduction      tag.ID = 0x010a
+	RawDev2Engine              tag.ID = 0x010b
+	RawDev2PictureMode         tag.ID = 0x010c
+)
+
+// Olympus ImageProcessing Ifd Tags
+const (
+	ImageProcessingVersion               tag.ID = 0x0000
+	ImageProcessingWB_RBLevels           tag.ID = 0x0100
+	ImageProcessingColorMatrix           tag.ID = 0x0200
+	ImageProcessingEnhancer              tag.ID = 0x0300
+	ImageProcessingEnhancerValues        tag.ID = 0x0301
+	ImageProcessingCoringFilter          tag.ID = 0x0310
+	ImageProcessingCoringValues          tag.ID = 0x0311
+	ImageProcessingBlackLevel2           tag.ID = 0x0600
+	ImageProcessingGainBase              tag.ID = 0x0610
+	ImageProcessingValidBits             tag.ID = 0x0611
+	ImageProcessingCropLeft              tag.ID = 0x0612
+	ImageProcessingCropTop               tag.ID = 0x0613
+	ImageProcessingCropWidth             tag.ID = 0x0614
+	ImageProcessingCropHeight            tag.ID = 0x0615
+	ImageProcessingNoiseReduction2       tag.ID = 0x1010
+	ImageProcessingDistortionCorrection2 tag.ID = 0x1011
+	ImageProcessingShadingCompensation2  tag.ID = 0x1012
+	ImageProcessingMultipleExposureMode  tag.ID = 0x1103
+	ImageProcessingAspectRatio           tag.ID = 0x1112
+	ImageProcessingAspectFrame           tag.ID = 0x1113
+	ImageProcessingFacesDetected         tag.ID = 0x1200
+	ImageProcessingFaceDetectArea        tag.ID = 0x1201
+)
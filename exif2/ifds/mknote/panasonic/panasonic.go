@@ -0,0 +1,73 @@
+// Package panasonic provides types and functions for decoding Panasonic Makernotes
+package panasonic
+
+import (
+	"bytes"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/meta/panasonic"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+const (
+	// HeaderLength is the length of the Panasonic Makernote header
+	// "Panasonic\0\0\0". The Ifd follows the header.
+	HeaderLength = 12
+)
+
+var panasonicHeader = []byte("Panasonic\x00\x00\x00")
+
+// IsPanasonicMkNoteHeaderBytes represents "Panasonic\0\0\0" the first 12 bytes
+// of the Panasonic Makernote
+func IsPanasonicMkNoteHeaderBytes(buf []byte) bool {
+	return bytes.HasPrefix(buf, panasonicHeader)
+}
+
+// DecodeMakerNote decodes a Panasonic Makernote from buf. offset is the
+// offset of buf from the beginning of the Tiff Header and byteOrder is
+// the byte order of the Tiff Header. Panasonic Makernote value offsets are
+// relative to the Tiff Header. v, if not nil, is called with every Makernote
+// entry.
+func DecodeMakerNote(buf []byte, offset uint32, byteOrder utils.ByteOrder, v mknote.Visitor) (mn panasonic.MakerNote, err error) {
+	if len(buf) < HeaderLength || !IsPanasonicMkNoteHeaderBytes(buf) {
+		return mn, mknote.ErrMakerNoteHeader
+	}
+	r := mknote.NewReader(buf, byteOrder, offset, 0)
+	r.Visitor = v
+	entries, _, err := r.ReadIfd(offset + HeaderLength)
+	if err != nil {
+		return mn, err
+	}
+	for _, e := range entries {
+		switch e.ID {
+		case InternalSerialNumber:
+			mn.InternalSerialNumber = e.String()
+		case LensType:
+			mn.LensType = e.String()
+		case LensSerialNumber:
+			mn.LensSerialNumber = e.String()
+		case BurstMode:
+			mn.BurstMode = panasonic.BurstMode(e.Uint16())
+		case SequenceNumber:
+			mn.SequenceNumber = e.Uint32()
+		case FacesDetected:
+			mn.FacesDetected = uint8(e.Uint16())
+		case FaceDetInfo:
+			mn.Faces = panasonic.NewFaceInfo(faceDetInfo(e))
+		case ShutterType:
+			mn.ShutterType = panasonic.ShutterType(e.Uint16())
+		}
+	}
+	return mn, nil
+}
+
+// faceDetInfo returns the FaceDetInfo values. FaceDetInfo is stored as
+// Undefined and contains uint16 values in the Makernote byte order.
+func faceDetInfo(e mknote.Entry) []uint16 {
+	buf := e.Bytes()
+	vals := make([]uint16, len(buf)/2)
+	for i := range vals {
+		vals[i] = e.ByteOrder.Uint16(buf[2*i:])
+	}
+	return vals
+}
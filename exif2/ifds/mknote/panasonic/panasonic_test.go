@@ -0,0 +1,55 @@
+package panasonic
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/panasonic"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// testMakerNote returns a Panasonic Makernote at offset from the Tiff Header
+func testMakerNote(offset uint32) []byte {
+	le := binary.LittleEndian
+	return tifftest.AppendIfd(append([]byte{}, panasonicHeader...), le, offset, []tifftest.Entry{
+		tifftest.Bytes(InternalSerialNumber, tag.TypeUndefined, []byte("F541201130055\x00\x00\x00")),
+		tifftest.Shorts(le, BurstMode, 3),
+		tifftest.Longs(le, SequenceNumber, 2),
+		tifftest.Bytes(FacesDetected, tag.TypeByte, []byte{1}),
+		tifftest.Bytes(FaceDetInfo, tag.TypeUndefined, tifftest.U16s(le, 1, 160, 120, 40, 48)),
+		tifftest.ASCII(LensType, "LUMIX G VARIO 12-60"),
+		tifftest.Shorts(le, ShutterType, 1),
+	})
+}
+
+func TestDecodeMakerNote(t *testing.T) {
+	mn, err := DecodeMakerNote(testMakerNote(0x800), 0x800, utils.LittleEndian, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mn.InternalSerialNumber != "F541201130055" {
+		t.Errorf("Incorrect InternalSerialNumber wanted %s got %s", "F541201130055", mn.InternalSerialNumber)
+	}
+	if mn.BurstMode != panasonic.BurstModeFocusBracketing || !mn.BurstMode.IsBracketing() {
+		t.Errorf("Incorrect BurstMode got %s", mn.BurstMode)
+	}
+	if mn.SequenceNumber != 2 {
+		t.Errorf("Incorrect SequenceNumber wanted %d got %d", 2, mn.SequenceNumber)
+	}
+	if mn.FacesDetected != 1 || len(mn.Faces) != 1 || mn.Faces[0] != (panasonic.FaceInfo{X: 160, Y: 120, Width: 40, Height: 48}) {
+		t.Errorf("Incorrect Faces got %d %v", mn.FacesDetected, mn.Faces)
+	}
+	if mn.LensType != "LUMIX G VARIO 12-60" {
+		t.Errorf("Incorrect LensType wanted %s got %s", "LUMIX G VARIO 12-60", mn.LensType)
+	}
+	if mn.ShutterType.String() != "Electronic" {
+		t.Errorf("Incorrect ShutterType got %s", mn.ShutterType)
+	}
+
+	// Error Header
+	if _, err = DecodeMakerNote([]byte("NotPanasonic"), 0, utils.LittleEndian, nil); err == nil {
+		t.Errorf("Wanted error for invalid header")
+	}
+}
@@ -0,0 +1,213 @@
+package panasonic
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagPanasonicString returns the string representation of a tag.ID for Panasonic Makernotes
+func TagPanasonicString(id tag.ID) string {
+	if name, ok := TagPanasonicIDMap[id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// TagPanasonicIDMap is a Map of tag.ID to string for the PanasonicMakerNote tags
+var TagPanasonicIDMap = map[tag.ID]string{
+	ImageQuality:               "ImageQuality",
+	FirmwareVersion:            "FirmwareVersion",
+	WhiteBalance:               "WhiteBalance",
+	FocusMode:                  "FocusMode",
+	AFAreaMode:                 "AFAreaMode",
+	ImageStabilization:         "ImageStabilization",
+	MacroMode:                  "MacroMode",
+	ShootingMode:               "ShootingMode",
+	Audio:                      "Audio",
+	DataDump:                   "DataDump",
+	WhiteBalanceBias:           "WhiteBalanceBias",
+	FlashBias:                  "FlashBias",
+	InternalSerialNumber:       "InternalSerialNumber",
+	PanasonicExifVersion:       "PanasonicExifVersion",
+	ColorEffect:                "ColorEffect",
+	TimeSincePowerOn:           "TimeSincePowerOn",
+	BurstMode:                  "BurstMode",
+	SequenceNumber:             "SequenceNumber",
+	ContrastMode:               "ContrastMode",
+	NoiseReduction:             "NoiseReduction",
+	SelfTimer:                  "SelfTimer",
+	Rotation:                   "Rotation",
+	AFAssistLamp:               "AFAssistLamp",
+	ColorMode:                  "ColorMode",
+	BabyAge:                    "BabyAge",
+	OpticalZoomMode:            "OpticalZoomMode",
+	ConversionLens:             "ConversionLens",
+	TravelDay:                  "TravelDay",
+	Contrast:                   "Contrast",
+	WorldTimeLocation:          "WorldTimeLocation",
+	TextStamp:                  "TextStamp",
+	ProgramISO:                 "ProgramISO",
+	AdvancedSceneType:          "AdvancedSceneType",
+	FacesDetected:              "FacesDetected",
+	Saturation:                 "Saturation",
+	Sharpness:                  "Sharpness",
+	FilmMode:                   "FilmMode",
+	ColorTempKelvin:            "ColorTempKelvin",
+	BracketSettings:            "BracketSettings",
+	WBShiftAB:                  "WBShiftAB",
+	WBShiftGM:                  "WBShiftGM",
+	FlashCurtain:               "FlashCurtain",
+	LongExposureNoiseReduction: "LongExposureNoiseReduction",
+	PanasonicImageWidth:        "PanasonicImageWidth",
+	PanasonicImageHeight:       "PanasonicImageHeight",
+	AFPointPosition:            "AFPointPosition",
+	FaceDetInfo:                "FaceDetInfo",
+	LensType:                   "LensType",
+	LensSerialNumber:           "LensSerialNumber",
+	AccessoryType:              "AccessoryType",
+	AccessorySerialNumber:      "AccessorySerialNumber",
+	Transform:                  "Transform",
+	IntelligentExposure:        "IntelligentExposure",
+	LensFirmwareVersion:        "LensFirmwareVersion",
+	FaceRecInfo:                "FaceRecInfo",
+	FlashWarning:               "FlashWarning",
+	RecognizedFaceFlags:        "RecognizedFaceFlags",
+	Title:                      "Title",
+	BabyName:                   "BabyName",
+	Location:                   "Location",
+	Country:                    "Country",
+	State:                      "State",
+	City:                       "City",
+	Landmark:                   "Landmark",
+	IntelligentResolution:      "IntelligentResolution",
+	BurstSpeed:                 "BurstSpeed",
+	IntelligentDRange:          "IntelligentDRange",
+	ClearRetouch:               "ClearRetouch",
+	City2:                      "City2",
+	PhotoStyle:                 "PhotoStyle",
+	ShadingCompensation:        "ShadingCompensation",
+	AccelerometerZ:             "AccelerometerZ",
+	AccelerometerX:             "AccelerometerX",
+	AccelerometerY:             "AccelerometerY",
+	CameraOrientation:          "CameraOrientation",
+	RollAngle:                  "RollAngle",
+	PitchAngle:                 "PitchAngle",
+	SweepPanoramaDirection:     "SweepPanoramaDirection",
+	SweepPanoramaFieldOfView:   "SweepPanoramaFieldOfView",
+	TimerRecording:             "TimerRecording",
+	InternalNDFilter:           "InternalNDFilter",
+	HDR:                        "HDR",
+	ShutterType:                "ShutterType",
+	ClearRetouchValue:          "ClearRetouchValue",
+	TouchAE:                    "TouchAE",
+	LensTypeModel:              "LensTypeModel",
+	PrintIM:                    "PrintIM",
+	TimeInfo:                   "TimeInfo",
+	MakerNoteVersion:           "MakerNoteVersion",
+	SceneMode:                  "SceneMode",
+	WBRedLevel:                 "WBRedLevel",
+	WBGreenLevel:               "WBGreenLevel",
+	WBBlueLevel:                "WBBlueLevel",
+	FlashFired:                 "FlashFired",
+	BabyAge2:                   "BabyAge2",
+	Transform2:                 "Transform2",
+}
+
+// Panasonic Makernote Tags
+//
+// Derived from https://exiftool.org/TagNames/Panasonic.html (19/10/2026)
+const (
+	ImageQuality               tag.ID = 0x0001
+	FirmwareVersion            tag.ID = 0x0002
+	WhiteBalance               tag.ID = 0x0003
+	FocusMode                  tag.ID = 0x0007
+	AFAreaMode                 tag.ID = 0x000f
+	ImageStabilization         tag.ID = 0x001a
+	MacroMode                  tag.ID = 0x001c
+	ShootingMode               tag.ID = 0x001f
+	Audio                      tag.ID = 0x0020
+	DataDump                   tag.ID = 0x0021
+	WhiteBalanceBias           tag.ID = 0x0023
+	FlashBias                  tag.ID = 0x0024
+	InternalSerialNumber       tag.ID = 0x0025
+	PanasonicExifVersion       tag.ID = 0x0026
+	ColorEffect                tag.ID = 0x0028
+	TimeSincePowerOn           tag.ID = 0x0029
+	BurstMode                  tag.ID = 0x002a
+	SequenceNumber             tag.ID = 0x002b
+	ContrastMode               tag.ID = 0x002c
+	NoiseReduction             tag.ID = 0x002d
+	SelfTimer                  tag.ID = 0x002e
+	Rotation                   tag.ID = 0x0030
+	AFAssistLamp               tag.ID = 0x0031
+	ColorMode                  tag.ID = 0x0032
+	BabyAge                    tag.ID = 0x0033
+	OpticalZoomMode            tag.ID = 0x0034
+	ConversionLens             tag.ID = 0x0035
+	TravelDay                  tag.ID = 0x0036
+	Contrast                   tag.ID = 0x0039
+	WorldTimeLocation          tag.ID = 0x003a
+	TextStamp                  tag.ID = 0x003b
+	ProgramISO                 tag.ID = 0x003c
+	AdvancedSceneType          tag.ID = 0x003d
+	FacesDetected              tag.ID = 0x003f
+	Saturation                 tag.ID = 0x0040
+	Sharpness                  tag.ID = 0x0041
+	FilmMode                   tag.ID = 0x0042
+	ColorTempKelvin            tag.ID = 0x0044
+	BracketSettings            tag.ID = 0x0045
+	WBShiftAB                  tag.ID = 0x0046
+	WBShiftGM                  tag.ID = 0x0047
+	FlashCurtain               tag.ID = 0x0048
+	LongExposureNoiseReduction tag.ID = 0x0049
+	PanasonicImageWidth        tag.ID = 0x004b
+	PanasonicImageHeight       tag.ID = 0x004c
+	AFPointPosition            tag.ID = 0x004d
+	FaceDetInfo                tag.ID = 0x004e
+	LensType                   tag.ID = 0x0051
+	LensSerialNumber           tag.ID = 0x0052
+	AccessoryType              tag.ID = 0x0053
+	AccessorySerialNumber      tag.ID = 0x0054
+	Transform                  tag.ID = 0x0059
+	IntelligentExposure        tag.ID = 0x005d
+	LensFirmwareVersion        tag.ID = 0x0060
+	FaceRecInfo                tag.ID = 0x0061
+	FlashWarning               tag.ID = 0x0062
+	RecognizedFaceFlags        tag.ID = 0x0063
+	Title                      tag.ID = 0x0065
+	BabyName                   tag.ID = 0x0066
+	Location                   tag.ID = 0x0067
+	Country                    tag.ID = 0x0069
+	State                      tag.ID = 0x006b
+	City                       tag.ID = 0x006d
+	Landmark                   tag.ID = 0x006f
+	IntelligentResolution      tag.ID = 0x0070
+	BurstSpeed                 tag.ID = 0x0077
+	IntelligentDRange          tag.ID = 0x0079
+	ClearRetouch               tag.ID = 0x007c
+	City2                      tag.ID = 0x0080
+	PhotoStyle                 tag.ID = 0x0089
+	ShadingCompensation        tag.ID = 0x008a
+	AccelerometerZ             tag.ID = 0x008c
+	AccelerometerX             tag.ID = 0x008d
+	AccelerometerY             tag.ID = 0x008e
+	CameraOrientation          tag.ID = 0x008f
+	RollAngle                  tag.ID = 0x0090
+	PitchAngle                 tag.ID = 0x0091
+	SweepPanoramaDirection     tag.ID = 0x0093
+	SweepPanoramaFieldOfView   tag.ID = 0x0094
+	TimerRecording             tag.ID = 0x0096
+	InternalNDFilter           tag.ID = 0x009d
+	HDR                        tag.ID = 0x009e
+	ShutterType                tag.ID = 0x009f
+	ClearRetouchValue          tag.ID = 0x00a3
+	TouchAE                    tag.ID = 0x00ab
+	LensTypeModel              tag.ID = 0x00e4
+	PrintIM                    tag.ID = 0x0e00
+	TimeInfo                   tag.ID = 0x2003
+	MakerNoteVersion           tag.ID = 0x8000
+	SceneMode                  tag.ID = 0x8001
+	WBRedLevel                 tag.ID = 0x8004
+	WBGreenLevel               tag.ID = 0x8005
+	WBBlueLevel                tag.ID = 0x8006
+	FlashFired                 tag.ID = 0x8007
+	BabyAge2                   tag.ID = 0x8010
+	Transform2                 tag.ID = 0x8012
+)
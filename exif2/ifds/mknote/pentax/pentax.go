@@ -0,0 +1,114 @@
+// Package pentax provides types and functions for decoding Pentax and Ricoh Makernotes
+package pentax
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/meta/pentax"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// Pentax Makernote headers
+var (
+	// aocHeader is "AOC\0" followed by the byte order (6 bytes).
+	// Value offsets are relative to the Tiff Header.
+	aocHeader = []byte("AOC\x00")
+
+	// pentaxHeader is "PENTAX \0" followed by the byte order (10 bytes).
+	// Value offsets are relative to the beginning of the Makernote.
+	pentaxHeader = []byte("PENTAX \x00")
+)
+
+// IsPentaxMkNoteHeaderBytes returns true if buf begins with one of the
+// Pentax Makernote headers
+func IsPentaxMkNoteHeaderBytes(buf []byte) bool {
+	return bytes.HasPrefix(buf, aocHeader) || bytes.HasPrefix(buf, pentaxHeader)
+}
+
+// NewReader returns a Makernote Reader and the offset of the first Ifd for
+// the Pentax Makernote in buf. offset is the offset of buf from the beginning
+// of the Tiff Header and byteOrder is the byte order of the Tiff Header.
+func NewReader(buf []byte, offset uint32, byteOrder utils.ByteOrder) (r mknote.Reader, ifdOffset uint32, err error) {
+	switch {
+	case bytes.HasPrefix(buf, pentaxHeader) && len(buf) > 10:
+		byteOrder = headerByteOrder(buf[8:10], byteOrder)
+		return mknote.NewReader(buf, byteOrder, offset, offset), 10, nil
+	case bytes.HasPrefix(buf, aocHeader) && len(buf) > 6:
+		byteOrder = headerByteOrder(buf[4:6], byteOrder)
+		return mknote.NewReader(buf, byteOrder, offset, 0), offset + 6, nil
+	}
+	return r, 0, mknote.ErrMakerNoteHeader
+}
+
+// headerByteOrder returns the byte order of the Makernote header. Early
+// Makernotes have "\0\0" and use the byte order of the Tiff Header.
+func headerByteOrder(buf []byte, byteOrder utils.ByteOrder) utils.ByteOrder {
+	switch string(buf) {
+	case "II":
+		return utils.LittleEndian
+	case "MM":
+		return utils.BigEndian
+	}
+	return byteOrder
+}
+
+// DecodeMakerNote decodes a Pentax Makernote from buf. offset is the
+// offset of buf from the beginning of the Tiff Header and byteOrder is
+// the byte order of the Tiff Header. v, if not nil, is called with every
+// Makernote entry.
+func DecodeMakerNote(buf []byte, offset uint32, byteOrder utils.ByteOrder, v mknote.Visitor) (mn pentax.MakerNote, err error) {
+	r, ifdOffset, err := NewReader(buf, offset, byteOrder)
+	if err != nil {
+		return mn, err
+	}
+	r.Visitor = v
+	entries, _, err := r.ReadIfd(ifdOffset)
+	if err != nil {
+		return mn, err
+	}
+	var date, time, shutterCount []byte
+	var hasLensRec bool
+	for _, e := range entries {
+		switch e.ID {
+		case Date:
+			date = e.Bytes()
+		case Time:
+			time = e.Bytes()
+		case ShutterCount:
+			shutterCount = e.Bytes()
+		case AFPointSelected:
+			mn.AFPointSelected = pentax.AFPoint(e.Uint16())
+		case LensRec:
+			mn.LensType = pentax.NewLensType(e.Bytes())
+			hasLensRec = true
+		case LensInfo:
+			if !hasLensRec {
+				mn.LensType = pentax.NewLensType(e.Bytes())
+			}
+		case ShakeReductionInfo:
+			if b := e.Bytes(); len(b) >= 2 {
+				mn.ShakeReduction = pentax.ShakeReduction{Result: b[0], Mode: pentax.ShakeReductionMode(b[1])}
+			}
+		case LevelInfo:
+			mn.Level = pentax.NewLevelInfo(e.Bytes())
+		case SerialNumber:
+			mn.SerialNumber = e.String()
+		}
+	}
+	mn.ShutterCount = decodeShutterCount(shutterCount, date, time)
+	return mn, nil
+}
+
+// decodeShutterCount returns the ShutterCount (0x005d). The ShutterCount is
+// obfuscated with the Date (0x0006) and Time (0x0007) of the Makernote.
+// All values are big endian regardless of the Makernote byte order.
+func decodeShutterCount(val []byte, date []byte, time []byte) uint32 {
+	if len(val) != 4 || len(date) != 4 || len(time) < 3 {
+		return 0
+	}
+	d := binary.BigEndian.Uint32(date)
+	t := uint32(time[0])<<24 | uint32(time[1])<<16 | uint32(time[2])<<8
+	return binary.BigEndian.Uint32(val) ^ d ^ ^t
+}
@@ -0,0 +1,82 @@
+package pentax
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/pentax"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// testMakerNote returns a big endian "AOC\0" Pentax Makernote at offset
+// from the Tiff Header
+func testMakerNote(offset uint32, shutterCount uint32) []byte {
+	be := binary.BigEndian
+	date := []byte{0x07, 0xea, 0x0a, 0x13} // 2026:10:19
+	time := []byte{0x0c, 0x1e, 0x2d}       // 12:30:45
+	t := uint32(time[0])<<24 | uint32(time[1])<<16 | uint32(time[2])<<8
+	count := be.AppendUint32(nil, shutterCount^be.Uint32(date)^^t)
+
+	return tifftest.AppendIfd([]byte("AOC\x00MM"), be, offset, []tifftest.Entry{
+		tifftest.Bytes(Date, tag.TypeUndefined, date),
+		tifftest.Bytes(Time, tag.TypeUndefined, time),
+		tifftest.Shorts(be, AFPointSelected, 6),
+		tifftest.Bytes(LensRec, tag.TypeUndefined, []byte{4, 252, 0, 0}),
+		tifftest.Bytes(ShakeReductionInfo, tag.TypeUndefined, []byte{1, 1, 0, 0}),
+		tifftest.Bytes(ShutterCount, tag.TypeUndefined, count),
+		tifftest.ASCII(SerialNumber, "4012345"),
+		tifftest.Bytes(LevelInfo, tag.TypeUndefined, []byte{1, 0xfc, 0x02, 0}),
+	})
+}
+
+func TestDecodeMakerNote(t *testing.T) {
+	mn, err := DecodeMakerNote(testMakerNote(0x400, 123456), 0x400, utils.LittleEndian, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mn.ShutterCount != 123456 {
+		t.Errorf("Incorrect ShutterCount wanted %d got %d", 123456, mn.ShutterCount)
+	}
+	if mn.LensType.String() != "smc PENTAX-DA 18-55mm F3.5-5.6 AL" {
+		t.Errorf("Incorrect LensType got %s", mn.LensType)
+	}
+	if mn.AFPointSelected.String() != "Center" {
+		t.Errorf("Incorrect AFPointSelected wanted %s got %s", "Center", mn.AFPointSelected)
+	}
+	if !mn.ShakeReduction.IsStabilized() || !mn.ShakeReduction.Mode.IsOn() {
+		t.Errorf("Incorrect ShakeReduction got %v", mn.ShakeReduction)
+	}
+	if mn.Level != (pentax.LevelInfo{Orientation: 1, Roll: 2, Pitch: -1}) {
+		t.Errorf("Incorrect LevelInfo got %v", mn.Level)
+	}
+	if mn.SerialNumber != "4012345" {
+		t.Errorf("Incorrect SerialNumber wanted %s got %s", "4012345", mn.SerialNumber)
+	}
+	if lt := (pentax.LensType{Series: 9, Model: 99}); lt.String() != "9 99" {
+		t.Errorf("Incorrect LensType ID got %s", lt)
+	}
+	for _, tt := range []struct {
+		mode pentax.ShakeReductionMode
+		on   bool
+		str  string
+	}{
+		{0, false, "Off"},
+		{4, false, "Off (4)"},
+		{5, true, "On but Disabled"},
+		{6, true, "On (Video)"},
+		{15, true, "On (15)"},
+		{167, true, "On (mode 1)"},
+		{2, false, "Unknown (2)"},
+	} {
+		if tt.mode.IsOn() != tt.on || tt.mode.String() != tt.str {
+			t.Errorf("Incorrect ShakeReductionMode %d got %t %s", tt.mode, tt.mode.IsOn(), tt.mode)
+		}
+	}
+
+	// Error Header
+	if _, err = DecodeMakerNote([]byte("NOTPENTAX!"), 0, utils.BigEndian, nil); err == nil {
+		t.Errorf("Wanted error for invalid header")
+	}
+}
@@ -0,0 +1,281 @@
+package pentax
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagPentaxString returns the string representation of a tag.ID for Pentax Makernotes
+func TagPentaxString(id tag.ID) string {
+	if name, ok := TagPentaxIDMap[id]; ok {
+		return name
+	}
+	return id.String()
+}
+
+// TagPentaxIDMap is a Map of tag.ID to string for the PentaxMakerNote tags
+var TagPentaxIDMap = map[tag.ID]string{
+	PentaxVersion:              "PentaxVersion",
+	PentaxModelType:            "PentaxModelType",
+	PreviewImageSize:           "PreviewImageSize",
+	PreviewImageLength:         "PreviewImageLength",
+	PreviewImageStart:          "PreviewImageStart",
+	PentaxModelID:              "PentaxModelID",
+	Date:                       "Date",
+	Time:                       "Time",
+	Quality:                    "Quality",
+	PentaxImageSize:            "PentaxImageSize",
+	PictureMode:                "PictureMode",
+	FlashMode:                  "FlashMode",
+	FocusMode:                  "FocusMode",
+	AFPointSelected:            "AFPointSelected",
+	AFPointsInFocus:            "AFPointsInFocus",
+	FocusPosition:              "FocusPosition",
+	ExposureTime:               "ExposureTime",
+	FNumber:                    "FNumber",
+	ISO:                        "ISO",
+	LightReading:               "LightReading",
+	ExposureCompensation:       "ExposureCompensation",
+	MeteringMode:               "MeteringMode",
+	AutoBracketing:             "AutoBracketing",
+	WhiteBalance:               "WhiteBalance",
+	WhiteBalanceMode:           "WhiteBalanceMode",
+	BlueBalance:                "BlueBalance",
+	RedBalance:                 "RedBalance",
+	FocalLength:                "FocalLength",
+	DigitalZoom:                "DigitalZoom",
+	Saturation:                 "Saturation",
+	Contrast:                   "Contrast",
+	Sharpness:                  "Sharpness",
+	WorldTimeLocation:          "WorldTimeLocation",
+	HometownCity:               "HometownCity",
+	DestinationCity:            "DestinationCity",
+	HometownDST:                "HometownDST",
+	DestinationDST:             "DestinationDST",
+	DSPFirmwareVersion:         "DSPFirmwareVersion",
+	CPUFirmwareVersion:         "CPUFirmwareVersion",
+	FrameNumber:                "FrameNumber",
+	EffectiveLV:                "EffectiveLV",
+	ImageEditing:               "ImageEditing",
+	PictureMode2:               "PictureMode2",
+	DriveMode:                  "DriveMode",
+	SensorSize:                 "SensorSize",
+	ColorSpace:                 "ColorSpace",
+	ImageAreaOffset:            "ImageAreaOffset",
+	RawImageSize:               "RawImageSize",
+	AFPointsInFocus2:           "AFPointsInFocus2",
+	DataScaling:                "DataScaling",
+	PreviewImageBorders:        "PreviewImageBorders",
+	LensRec:                    "LensRec",
+	SensitivityAdjust:          "SensitivityAdjust",
+	ImageEditCount:             "ImageEditCount",
+	CameraTemperature:          "CameraTemperature",
+	AELock:                     "AELock",
+	NoiseReduction:             "NoiseReduction",
+	FlashExposureComp:          "FlashExposureComp",
+	ImageTone:                  "ImageTone",
+	ColorTemperature:           "ColorTemperature",
+	ShakeReductionInfo:         "ShakeReductionInfo",
+	ShutterCount:               "ShutterCount",
+	FaceInfo:                   "FaceInfo",
+	RawDevelopmentProcess:      "RawDevelopmentProcess",
+	Hue:                        "Hue",
+	AWBInfo:                    "AWBInfo",
+	DynamicRangeExpansion:      "DynamicRangeExpansion",
+	TimeInfo:                   "TimeInfo",
+	HighLowKeyAdj:              "HighLowKeyAdj",
+	ContrastHighlight:          "ContrastHighlight",
+	ContrastShadow:             "ContrastShadow",
+	ContrastHighlightShadowAdj: "ContrastHighlightShadowAdj",
+	FineSharpness:              "FineSharpness",
+	HighISONoiseReduction:      "HighISONoiseReduction",
+	AFAdjustment:               "AFAdjustment",
+	MonochromeFilterEffect:     "MonochromeFilterEffect",
+	MonochromeToning:           "MonochromeToning",
+	FaceDetect:                 "FaceDetect",
+	FaceDetectFrameSize:        "FaceDetectFrameSize",
+	ShadowCorrection:           "ShadowCorrection",
+	ISOAutoParameters:          "ISOAutoParameters",
+	CrossProcess:               "CrossProcess",
+	LensCorr:                   "LensCorr",
+	WhiteLevel:                 "WhiteLevel",
+	BleachBypassToning:         "BleachBypassToning",
+	AspectRatio:                "AspectRatio",
+	BlurControl:                "BlurControl",
+	HDR:                        "HDR",
+	ShutterType:                "ShutterType",
+	NeutralDensityFilter:       "NeutralDensityFilter",
+	ISO2:                       "ISO2",
+	IntervalShooting:           "IntervalShooting",
+	SkinToneCorrection:         "SkinToneCorrection",
+	ClarityControl:             "ClarityControl",
+	BlackPoint:                 "BlackPoint",
+	WhitePoint:                 "WhitePoint",
+	ColorMatrixA:               "ColorMatrixA",
+	ColorMatrixB:               "ColorMatrixB",
+	CameraSettings:             "CameraSettings",
+	AEInfo:                     "AEInfo",
+	LensInfo:                   "LensInfo",
+	FlashInfo:                  "FlashInfo",
+	AEMeteringSegments:         "AEMeteringSegments",
+	FlashMeteringSegments:      "FlashMeteringSegments",
+	SlaveFlashMeteringSegments: "SlaveFlashMeteringSegments",
+	AFInfo:                     "AFInfo",
+	HuffmanTable:               "HuffmanTable",
+	KelvinWB:                   "KelvinWB",
+	ColorInfo:                  "ColorInfo",
+	EVStepInfo:                 "EVStepInfo",
+	ShotInfo:                   "ShotInfo",
+	FacePos:                    "FacePos",
+	FaceSize:                   "FaceSize",
+	SerialNumber:               "SerialNumber",
+	FilterInfo:                 "FilterInfo",
+	LevelInfo:                  "LevelInfo",
+	Artist:                     "Artist",
+	Copyright:                  "Copyright",
+	FirmwareVersion:            "FirmwareVersion",
+	ContrastDetectAFArea:       "ContrastDetectAFArea",
+	CrossProcessParams:         "CrossProcessParams",
+	LensInfoQ:                  "LensInfoQ",
+	Model:                      "Model",
+	PixelShiftInfo:             "PixelShiftInfo",
+	AFPointInfo:                "AFPointInfo",
+	DataDump:                   "DataDump",
+	TempInfo:                   "TempInfo",
+	ToneCurve:                  "ToneCurve",
+	ToneCurves:                 "ToneCurves",
+	PrintIM:                    "PrintIM",
+}
+
+// Pentax Makernote Tags
+//
+// Derived from https://exiftool.org/TagNames/Pentax.html (19/10/2026)
+const (
+	PentaxVersion              tag.ID = 0x0000
+	PentaxModelType            tag.ID = 0x0001
+	PreviewImageSize           tag.ID = 0x0002
+	PreviewImageLength         tag.ID = 0x0003
+	PreviewImageStart          tag.ID = 0x0004
+	PentaxModelID              tag.ID = 0x0005
+	Date                       tag.ID = 0x0006
+	Time                       tag.ID = 0x0007
+	Quality                    tag.ID = 0x0008
+	PentaxImageSize            tag.ID = 0x0009
+	PictureMode                tag.ID = 0x000b
+	FlashMode                  tag.ID = 0x000c
+	FocusMode                  tag.ID = 0x000d
+	AFPointSelected            tag.ID = 0x000e
+	AFPointsInFocus            tag.ID = 0x000f
+	FocusPosition              tag.ID = 0x0010
+	ExposureTime               tag.ID = 0x0012
+	FNumber                    tag.ID = 0x0013
+	ISO                        tag.ID = 0x0014
+	LightReading               tag.ID = 0x0015
+	ExposureCompensation       tag.ID = 0x0016
+	MeteringMode               tag.ID = 0x0017
+	AutoBracketing             tag.ID = 0x0018
+	WhiteBalance               tag.ID = 0x0019
+	WhiteBalanceMode           tag.ID = 0x001a
+	BlueBalance                tag.ID = 0x001b
+	RedBalance                 tag.ID = 0x001c
+	FocalLength                tag.ID = 0x001d
+	DigitalZoom                tag.ID = 0x001e
+	Saturation                 tag.ID = 0x001f
+	Contrast                   tag.ID = 0x0020
+	Sharpness                  tag.ID = 0x0021
+	WorldTimeLocation          tag.ID = 0x0022
+	HometownCity               tag.ID = 0x0023
+	DestinationCity            tag.ID = 0x0024
+	HometownDST                tag.ID = 0x0025
+	DestinationDST             tag.ID = 0x0026
+	DSPFirmwareVersion         tag.ID = 0x0027
+	CPUFirmwareVersion         tag.ID = 0x0028
+	FrameNumber                tag.ID = 0x0029
+	EffectiveLV                tag.ID = 0x002d
+	ImageEditing               tag.ID = 0x0032
+	PictureMode2               tag.ID = 0x0033
+	DriveMode                  tag.ID = 0x0034
+	SensorSize                 tag.ID = 0x0035
+	ColorSpace                 tag.ID = 0x0037
+	ImageAreaOffset            tag.ID = 0x0038
+	RawImageSize               tag.ID = 0x0039
+	AFPointsInFocus2           tag.ID = 0x003c
+	DataScaling                tag.ID = 0x003d
+	PreviewImageBorders        tag.ID = 0x003e
+	LensRec                    tag.ID = 0x003f
+	SensitivityAdjust          tag.ID = 0x0040
+	ImageEditCount             tag.ID = 0x0041
+	CameraTemperature          tag.ID = 0x0047
+	AELock                     tag.ID = 0x0048
+	NoiseReduction             tag.ID = 0x0049
+	FlashExposureComp          tag.ID = 0x004d
+	ImageTone                  tag.ID = 0x004f
+	ColorTemperature           tag.ID = 0x0050
+	ShakeReductionInfo         tag.ID = 0x005c
+	ShutterCount               tag.ID = 0x005d
+	FaceInfo                   tag.ID = 0x0060
+	RawDevelopmentProcess      tag.ID = 0x0062
+	Hue                        tag.ID = 0x0067
+	AWBInfo                    tag.ID = 0x0068
+	DynamicRangeExpansion      tag.ID = 0x0069
+	TimeInfo                   tag.ID = 0x006b
+	HighLowKeyAdj              tag.ID = 0x006c
+	ContrastHighlight          tag.ID = 0x006d
+	ContrastShadow             tag.ID = 0x006e
+	ContrastHighlightShadowAdj tag.ID = 0x006f
+	FineSharpness              tag.ID = 0x0070
+	HighISONoiseReduction      tag.ID = 0x0071
+	AFAdjustment               tag.ID = 0x0072
+	MonochromeFilterEffect     tag.ID = 0x0073
+	MonochromeToning           tag.ID = 0x0074
+	FaceDetect                 tag.ID = 0x0076
+	FaceDetectFrameSize        tag.ID = 0x0077
+	ShadowCorrection           tag.ID = 0x0079
+	ISOAutoParameters          tag.ID = 0x007a
+	CrossProcess               tag.ID = 0x007b
+	LensCorr                   tag.ID = 0x007d
+	WhiteLevel                 tag.ID = 0x007e
+	BleachBypassToning         tag.ID = 0x007f
+	AspectRatio                tag.ID = 0x0080
+	BlurControl                tag.ID = 0x0082
+	HDR                        tag.ID = 0x0085
+	ShutterType                tag.ID = 0x0087
+	NeutralDensityFilter       tag.ID = 0x0088
+	ISO2                       tag.ID = 0x008b
+	IntervalShooting           tag.ID = 0x0092
+	SkinToneCorrection         tag.ID = 0x0095
+	ClarityControl             tag.ID = 0x0096
+	BlackPoint                 tag.ID = 0x0200
+	WhitePoint                 tag.ID = 0x0201
+	ColorMatrixA               tag.ID = 0x0203
+	ColorMatrixB               tag.ID = 0x0204
+	CameraSettings             tag.ID = 0x0205
+	AEInfo                     tag.ID = 0x0206
+	LensInfo                   tag.ID = 0x0207
+	FlashInfo                  tag.ID = 0x0208
+	AEMeteringSegments         tag.ID = 0x0209
+	FlashMeteringSegments      tag.ID = 0x020a
+	SlaveFlashMeteringSegments tag.ID = 0x020b
+	AFInfo                     tag.ID = 0x021f
+	HuffmanTable               tag.ID = 0x0220
+	KelvinWB                   tag.ID = 0x0221
+	ColorInfo                  tag.ID = 0x0222
+	EVStepInfo                 tag.ID = 0x0224
+	ShotInfo                   tag.ID = 0x0226
+	FacePos                    tag.ID = 0x0227
+	FaceSize                   tag.ID = 0x0228
+	SerialNumber               tag.ID = 0x0229
+	FilterInfo                 tag.ID = 0x022a
+	LevelInfo                  tag.ID = 0x022b
+	Artist                     tag.ID = 0x022e
+	Copyright                  tag.ID = 0x022f
+	FirmwareVersion            tag.ID = 0x0230
+	ContrastDetectAFArea       tag.ID = 0x0231
+	CrossProcessParams         tag.ID = 0x0235
+	LensInfoQ                  tag.ID = 0x0239
+	Model                      tag.ID = 0x023f
+	PixelShiftInfo             tag.ID = 0x0243
+	AFPointInfo                tag.ID = 0x0245
+	DataDump                   tag.ID = 0x03fe
+	TempInfo                   tag.ID = 0x03ff
+	ToneCurve                  tag.ID = 0x0402
+	ToneCurves                 tag.ID = 0x0403
+	PrintIM                    tag.ID = 0x0e00
+)
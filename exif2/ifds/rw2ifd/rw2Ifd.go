@@ -0,0 +1,134 @@
+// Package rw2ifd provides types for "RootIfd" of Panasonic RW2 images
+package rw2ifd
+
+import "github.com/evanoberholster/imagemeta/exif2/tag"
+
+// TagString returns the string representation of a tag.ID
+func TagString(id tag.ID) string {
+	name, ok := TagIDMap[id]
+	if !ok {
+		return id.String()
+	}
+	return name
+}
+
+// IsPanasonicRawTag returns true if the tag.ID is a Panasonic specific
+// RW2 IFD0 tag. These tags overlap with the standard Tiff IFD0 tags.
+// Make, Model, StripOffsets, Orientation, RowsPerStrip and StripByteCounts
+// are standard Tiff tags.
+func IsPanasonicRawTag(id tag.ID) bool {
+	switch id {
+	case PanasonicRawVersion, SensorWidth, SensorHeight, SensorTopBorder, SensorLeftBorder,
+		SensorBottomBorder, SensorRightBorder, SamplesPerPixel, CFAPattern, BitsPerSample,
+		Compression, LinearityLimitRed, LinearityLimitGreen, LinearityLimitBlue,
+		RedBalance, BlueBalance, WBInfo, ISO, HighISOMultiplierRed, HighISOMultiplierGreen,
+		HighISOMultiplierBlue, NoiseReductionParams, BlackLevelRed, BlackLevelGreen,
+		BlackLevelBlue, WBRedLevel, WBGreenLevel, WBBlueLevel, WBInfo2, RawFormat,
+		JpgFromRaw, CropTop, CropLeft, CropBottom, CropRight, RawDataOffset,
+		DistortionInfo, Gamma, CameraIFD, Multishot:
+		return true
+	}
+	return false
+}
+
+// TagIDMap is a Map of tag.ID to string for the Panasonic RW2 IFD0 tags
+var TagIDMap = map[tag.ID]string{
+	PanasonicRawVersion:    "PanasonicRawVersion",
+	SensorWidth:            "SensorWidth",
+	SensorHeight:           "SensorHeight",
+	SensorTopBorder:        "SensorTopBorder",
+	SensorLeftBorder:       "SensorLeftBorder",
+	SensorBottomBorder:     "SensorBottomBorder",
+	SensorRightBorder:      "SensorRightBorder",
+	SamplesPerPixel:        "SamplesPerPixel",
+	CFAPattern:             "CFAPattern",
+	BitsPerSample:          "BitsPerSample",
+	Compression:            "Compression",
+	LinearityLimitRed:      "LinearityLimitRed",
+	LinearityLimitGreen:    "LinearityLimitGreen",
+	LinearityLimitBlue:     "LinearityLimitBlue",
+	RedBalance:             "RedBalance",
+	BlueBalance:            "BlueBalance",
+	WBInfo:                 "WBInfo",
+	ISO:                    "ISO",
+	HighISOMultiplierRed:   "HighISOMultiplierRed",
+	HighISOMultiplierGreen: "HighISOMultiplierGreen",
+	HighISOMultiplierBlue:  "HighISOMultiplierBlue",
+	NoiseReductionParams:   "NoiseReductionParams",
+	BlackLevelRed:          "BlackLevelRed",
+	BlackLevelGreen:        "BlackLevelGreen",
+	BlackLevelBlue:         "BlackLevelBlue",
+	WBRedLevel:             "WBRedLevel",
+	WBGreenLevel:           "WBGreenLevel",
+	WBBlueLevel:            "WBBlueLevel",
+	WBInfo2:                "WBInfo2",
+	RawFormat:              "RawFormat",
+	JpgFromRaw:             "JpgFromRaw",
+	CropTop:                "CropTop",
+	CropLeft:               "CropLeft",
+	CropBottom:             "CropBottom",
+	CropRight:              "CropRight",
+	Make:                   "Make",
+	Model:                  "Model",
+	StripOffsets:           "StripOffsets",
+	Orientation:            "Orientation",
+	RowsPerStrip:           "RowsPerStrip",
+	StripByteCounts:        "StripByteCounts",
+	RawDataOffset:          "RawDataOffset",
+	DistortionInfo:         "DistortionInfo",
+	Gamma:                  "Gamma",
+	CameraIFD:              "CameraIFD",
+	Multishot:              "Multishot",
+}
+
+// Panasonic RW2 IFD0 Tags
+//
+// Derived from https://exiftool.org/TagNames/PanasonicRaw.html (19/10/2026)
+const (
+	PanasonicRawVersion    tag.ID = 0x0001
+	SensorWidth            tag.ID = 0x0002
+	SensorHeight           tag.ID = 0x0003
+	SensorTopBorder        tag.ID = 0x0004
+	SensorLeftBorder       tag.ID = 0x0005
+	SensorBottomBorder     tag.ID = 0x0006
+	SensorRightBorder      tag.ID = 0x0007
+	SamplesPerPixel        tag.ID = 0x0008
+	CFAPattern             tag.ID = 0x0009
+	BitsPerSample          tag.ID = 0x000a
+	Compression            tag.ID = 0x000b
+	LinearityLimitRed      tag.ID = 0x000e
+	LinearityLimitGreen    tag.ID = 0x000f
+	LinearityLimitBlue     tag.ID = 0x0010
+	RedBalance             tag.ID = 0x0011
+	BlueBalance            tag.ID = 0x0012
+	WBInfo                 tag.ID = 0x0013
+	ISO                    tag.ID = 0x0017
+	HighISOMultiplierRed   tag.ID = 0x0018
+	HighISOMultiplierGreen tag.ID = 0x0019
+	HighISOMultiplierBlue  tag.ID = 0x001a
+	NoiseReductionParams   tag.ID = 0x001b
+	BlackLevelRed          tag.ID = 0x001c
+	BlackLevelGreen        tag.ID = 0x001d
+	BlackLevelBlue         tag.ID = 0x001e
+	WBRedLevel             tag.ID = 0x0024
+	WBGreenLevel           tag.ID = 0x0025
+	WBBlueLevel            tag.ID = 0x0026
+	WBInfo2                tag.ID = 0x0027
+	RawFormat              tag.ID = 0x002d
+	JpgFromRaw             tag.ID = 0x002e
+	CropTop                tag.ID = 0x002f
+	CropLeft               tag.ID = 0x0030
+	CropBottom             tag.ID = 0x0031
+	CropRight              tag.ID = 0x0032
+	Make                   tag.ID = 0x010f
+	Model                  tag.ID = 0x0110
+	StripOffsets           tag.ID = 0x0111
+	Orientation            tag.ID = 0x0112
+	RowsPerStrip           tag.ID = 0x0116
+	StripByteCounts        tag.ID = 0x0117
+	RawDataOffset          tag.ID = 0x0118
+	DistortionInfo         tag.ID = 0x0119
+	Gamma                  tag.ID = 0x011c
+	CameraIFD              tag.ID = 0x0120
+	Multishot              tag.ID = 0x0121
+)
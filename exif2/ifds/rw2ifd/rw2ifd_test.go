@@ -0,0 +1,26 @@
+package rw2ifd
+
+import (
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+)
+
+func TestString(t *testing.T) {
+	if TagString(JpgFromRaw) != "JpgFromRaw" {
+		t.Errorf("Expected %s got %s", "JpgFromRaw", TagString(JpgFromRaw))
+	}
+	if TagString(0x1234) != "0x1234" {
+		t.Errorf("Expected %s got %s", "0x1234", TagString(0x1234))
+	}
+	for _, id := range []tag.ID{ISO, SensorWidth, BlackLevelRed, WBBlueLevel, RawDataOffset, DistortionInfo, Gamma, CameraIFD, Multishot} {
+		if !IsPanasonicRawTag(id) {
+			t.Errorf("Incorrect IsPanasonicRawTag(%s) wanted true", TagString(id))
+		}
+	}
+	for _, id := range []tag.ID{Make, Model, StripOffsets, Orientation, RowsPerStrip, StripByteCounts, 0x0014, 0x011a} {
+		if IsPanasonicRawTag(id) {
+			t.Errorf("Incorrect IsPanasonicRawTag(%s) wanted false", TagString(id))
+		}
+	}
+}
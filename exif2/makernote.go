@@ -0,0 +1,202 @@
+package exif2
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/fujifilm"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/nikon"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/olympus"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/panasonic"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/pentax"
+	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// MakerNote is an undecoded Makernote that is passed to a MakerNoteDecoder.
+type MakerNote struct {
+	Buf        []byte          // Complete Makernote including its header
+	Offset     uint32          // Offset of Buf from the beginning of the Tiff Header
+	ByteOrder  utils.ByteOrder // ByteOrder of the Tiff Header
+	CameraMake ifds.CameraMake // CameraMake from IFD0 / 0x010f
+	Make       string          // IFD0 / 0x010f
+	Model      string          // IFD0 / 0x0110
+
+	// Visitor passes the Makernote entries to the TagVisitor of the
+	// decoder. It is nil when the decoder has no TagVisitor.
+	Visitor mknote.Visitor
+}
+
+// Reader returns a Makernote Ifd Reader for the Makernote. base is the offset
+// from the beginning of the Tiff Header that tag value offsets are relative
+// to. Use mn.Offset for Makernotes with offsets relative to the Makernote
+// and 0 for Makernotes with offsets relative to the Tiff Header.
+func (mn MakerNote) Reader(base uint32) mknote.Reader {
+	r := mknote.NewReader(mn.Buf, mn.ByteOrder, mn.Offset, base)
+	r.Visitor = mn.Visitor
+	return r
+}
+
+// MakerNoteDecoder decodes a Makernote and returns a typed value that is
+// stored in Exif.Makernotes.
+type MakerNoteDecoder func(mn MakerNote) (MakerNotes, error)
+
+// readMakerNote reads the Makernote of t and decodes it with fn.
+func (fn MakerNoteDecoder) readMakerNote(ir *ifdReader, t Tag) {
+	ir.decodeMakerNote(t, fn)
+}
+
+// makerNoteReader reads the Makernote of t with the ifdReader. The ifdReader
+// is positioned at t.ValueOffset.
+type makerNoteReader func(ir *ifdReader, t Tag)
+
+// makerNoteHeaderDecoder is a MakerNoteDecoder for Makernotes that begin with header
+type makerNoteHeaderDecoder struct {
+	header []byte
+	fn     MakerNoteDecoder
+}
+
+var (
+	// makerNoteReaders are the registered makerNoteReaders by CameraMake
+	makerNoteReaders = map[ifds.CameraMake]makerNoteReader{}
+
+	// makerNoteHeaderDecoders are the registered MakerNoteDecoders by header.
+	// Headers are matched in order of registration.
+	makerNoteHeaderDecoders []makerNoteHeaderDecoder
+
+	// makerNoteHeaderLength is the length of the longest registered header
+	makerNoteHeaderLength int
+
+	mutexMakerNoteDecoders = sync.RWMutex{}
+)
+
+// RegisterMakerNoteDecoder registers a MakerNoteDecoder for the given
+// CameraMake. It replaces any MakerNoteDecoder previously registered for
+// the CameraMake, including the decoders of this package. A nil fn removes
+// the decoder for the CameraMake.
+func RegisterMakerNoteDecoder(cameraMake ifds.CameraMake, fn MakerNoteDecoder) {
+	if fn == nil {
+		registerMakerNoteReader(cameraMake, nil)
+		return
+	}
+	registerMakerNoteReader(cameraMake, fn.readMakerNote)
+}
+
+// registerMakerNoteReader registers a makerNoteReader for the given CameraMake.
+func registerMakerNoteReader(cameraMake ifds.CameraMake, fn makerNoteReader) {
+	mutexMakerNoteDecoders.Lock()
+	defer mutexMakerNoteDecoders.Unlock()
+	if fn == nil {
+		delete(makerNoteReaders, cameraMake)
+		return
+	}
+	makerNoteReaders[cameraMake] = fn
+}
+
+// RegisterMakerNoteHeader registers a MakerNoteDecoder for Makernotes that
+// begin with header. A decoder registered by header takes precedence over a
+// decoder registered by CameraMake.
+func RegisterMakerNoteHeader(header []byte, fn MakerNoteDecoder) {
+	mutexMakerNoteDecoders.Lock()
+	defer mutexMakerNoteDecoders.Unlock()
+	for i, d := range makerNoteHeaderDecoders {
+		if bytes.Equal(d.header, header) {
+			makerNoteHeaderDecoders = append(makerNoteHeaderDecoders[:i], makerNoteHeaderDecoders[i+1:]...)
+			break
+		}
+	}
+	if fn != nil {
+		makerNoteHeaderDecoders = append(makerNoteHeaderDecoders, makerNoteHeaderDecoder{header: append([]byte(nil), header...), fn: fn})
+	}
+	makerNoteHeaderLength = 0
+	for _, d := range makerNoteHeaderDecoders {
+		if len(d.header) > makerNoteHeaderLength {
+			makerNoteHeaderLength = len(d.header)
+		}
+	}
+}
+
+// makerNoteReaderFor returns the makerNoteReader for the given CameraMake
+// and the length of the longest registered Makernote header.
+func makerNoteReaderFor(cameraMake ifds.CameraMake) (makerNoteReader, int) {
+	mutexMakerNoteDecoders.RLock()
+	defer mutexMakerNoteDecoders.RUnlock()
+	return makerNoteReaders[cameraMake], makerNoteHeaderLength
+}
+
+// makerNoteHeaderDecoderFor returns the MakerNoteDecoder registered for the
+// header of the Makernote in buf.
+func makerNoteHeaderDecoderFor(buf []byte) MakerNoteDecoder {
+	mutexMakerNoteDecoders.RLock()
+	defer mutexMakerNoteDecoders.RUnlock()
+	for _, d := range makerNoteHeaderDecoders {
+		if bytes.HasPrefix(buf, d.header) {
+			return d.fn
+		}
+	}
+	return nil
+}
+
+// readCanonMakerNote reads a Canon Makernote. Canon Makernotes are an Ifd
+// without a header with offsets relative to the Tiff Header.
+func readCanonMakerNote(ir *ifdReader, t Tag) {
+	if err := ir.readIfdHeader(t.childIfd()); err != nil {
+		ir.logError(err).Send()
+	}
+}
+
+// readNikonMakerNote reads a Nikon Makernote. Nikon Makernotes begin with an
+// 18 byte header followed by a Tiff Header that offsets are relative to.
+func readNikonMakerNote(ir *ifdReader, t Tag) {
+	if t.Size() <= 18 {
+		return
+	}
+	buf, err := ir.fastRead(18) // read Nikon Makernotes header 18 bytes
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return
+	}
+	if nikon.IsNikonMkNoteHeaderBytes(buf[:5]) {
+		ir.Exif.ImageType = imagetype.ImageNEF
+		if byteOrder := utils.BinaryOrder(buf[10:14]); byteOrder != utils.UnknownEndian {
+			err = ir.readIfdHeader(ifds.NewIFD(byteOrder, ifds.MknoteIFD, t.IfdIndex, t.ValueOffset, t.ValueOffset+byteOrder.Uint32(buf[14:18])))
+			if err != nil {
+				ir.logError(err).Send()
+			}
+		}
+	}
+}
+
+// readPentaxMakerNote reads a Pentax or Ricoh Makernote. Tiff images with a
+// Pentax Makernote are Pentax PEF images.
+func readPentaxMakerNote(ir *ifdReader, t Tag) {
+	if ir.decodeMakerNote(t, decodePentax) && ir.Exif.ImageType == imagetype.ImageTiff {
+		ir.Exif.ImageType = imagetype.ImagePEF
+	}
+}
+
+// decodePentax decodes a Pentax Makernote that begins with a Pentax header.
+func decodePentax(mn MakerNote) (MakerNotes, error) {
+	if !pentax.IsPentaxMkNoteHeaderBytes(mn.Buf) {
+		return nil, nil
+	}
+	return pentax.DecodeMakerNote(mn.Buf, mn.Offset, mn.ByteOrder, mn.Visitor)
+}
+
+func init() {
+	registerMakerNoteReader(ifds.Canon, readCanonMakerNote)
+	registerMakerNoteReader(ifds.Nikon, readNikonMakerNote)
+	RegisterMakerNoteDecoder(ifds.FujiFilm, func(mn MakerNote) (MakerNotes, error) {
+		return fujifilm.DecodeMakerNote(mn.Buf, mn.Offset, mn.Visitor)
+	})
+	RegisterMakerNoteDecoder(ifds.Olympus, func(mn MakerNote) (MakerNotes, error) {
+		return olympus.DecodeMakerNote(mn.Buf, mn.Offset, mn.ByteOrder, mn.Visitor)
+	})
+	RegisterMakerNoteDecoder(ifds.Panasonic, func(mn MakerNote) (MakerNotes, error) {
+		return panasonic.DecodeMakerNote(mn.Buf, mn.Offset, mn.ByteOrder, mn.Visitor)
+	})
+	registerMakerNoteReader(ifds.Pentax, readPentaxMakerNote)
+	registerMakerNoteReader(ifds.Ricoh, readPentaxMakerNote)
+}
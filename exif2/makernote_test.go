@@ -0,0 +1,356 @@
+package exif2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/rw2ifd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/fujifilm"
+	"github.com/evanoberholster/imagemeta/meta/olympus"
+	"github.com/evanoberholster/imagemeta/meta/panasonic"
+	"github.com/evanoberholster/imagemeta/meta/pentax"
+	"github.com/evanoberholster/imagemeta/raf"
+)
+
+// parseSample parses the Exif of the sample image name in testImages
+func parseSample(t *testing.T, name string) Exif {
+	f, err := os.Open("../testImages/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if filepath.Ext(name) != ".raf" {
+		e, err := Parse(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	ir := NewIfdReader(Logger)
+	defer ir.Close()
+	if _, err = raf.ScanRAF(f, ir.DecodeJPEGIfd, nil); err != nil {
+		t.Fatal(err)
+	}
+	return ir.Exif
+}
+
+func TestFujifilmMakerNote(t *testing.T) {
+	le := binary.LittleEndian
+	buf := tifftest.MakerNote(le, "FUJIFILM", func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("FUJIFILM\x0c\x00\x00\x00"), le, 0, []tifftest.Entry{
+			tifftest.Shorts(le, 0x1401, 0x0700), // FilmMode
+		})
+	})
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.CameraMake != ifds.FujiFilm {
+		t.Errorf("Incorrect CameraMake wanted %s got %s", ifds.FujiFilm, e.CameraMake)
+	}
+	mn, ok := e.Makernotes.(fujifilm.MakerNote)
+	if !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if mn.FilmSimulation != fujifilm.FilmSimulationEterna {
+		t.Errorf("Incorrect FilmSimulation wanted %s got %s", fujifilm.FilmSimulationEterna, mn.FilmSimulation)
+	}
+
+	// Sample RAF
+	e = parseSample(t, "RAF.raf")
+	if mn, ok = e.Makernotes.(fujifilm.MakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	wanted := fujifilm.MakerNote{
+		Version:              "0130",
+		InternalSerialNumber: "FF02B4459617 Y55555 2018:09:06 5F3A3A31010A",
+		WhiteBalanceFineTune: fujifilm.WhiteBalanceFineTune{Red: 40, Blue: -60},
+		FilmSimulation:       fujifilm.FilmSimulationEterna,
+		DynamicRange:         1,
+		GrainEffectRoughness: fujifilm.NewGrainEffect(32),
+		GrainEffectSize:      fujifilm.NewGrainSize(16),
+		ShutterType:          1,
+		ImageCount:           4242,
+	}
+	if mn != wanted {
+		t.Errorf("Incorrect Makernotes wanted %+v got %+v", wanted, mn)
+	}
+	if mn.WhiteBalanceFineTune.String() != "Red +2, Blue -3" {
+		t.Errorf("Incorrect WhiteBalanceFineTune wanted %s got %s", "Red +2, Blue -3", mn.WhiteBalanceFineTune)
+	}
+}
+
+func TestOlympusMakerNote(t *testing.T) {
+	le := binary.LittleEndian
+	buf := tifftest.MakerNote(le, "OM Digital Solutions", func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("OLYMPUS\x00II\x03\x00"), le, 0, []tifftest.Entry{
+			tifftest.ASCII(0x0207, "OM-1"), // CameraType
+		})
+	})
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mn, ok := e.Makernotes.(olympus.MakerNote)
+	if !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if mn.CameraType != "OM-1" {
+		t.Errorf("Incorrect CameraType wanted %s got %s", "OM-1", mn.CameraType)
+	}
+
+	// Sample ORF
+	e = parseSample(t, "ORF.orf")
+	if e.CameraMake != ifds.Olympus || e.Model != "E-M1X" {
+		t.Errorf("Incorrect Camera got %s %s", e.CameraMake, e.Model)
+	}
+	if mn, ok = e.Makernotes.(olympus.MakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	wanted := olympus.MakerNote{
+		CameraType:          "E-M1X",
+		SerialNumber:        "BHX209613",
+		BodyFirmwareVersion: "1.300",
+		LensModel:           "OLYMPUS M.12-100mm F4.0",
+		LensType:            olympus.LensType{Model: 0x26, SubModel: 0x10},
+		RawDevelopment:      olympus.RawDevelopment{WhiteBalanceValue: 5300, Engine: 1},
+		ImageProcessing: olympus.ImageProcessing{
+			WBRBLevels: [2]uint16{498, 420},
+			BlackLevel: [4]uint16{254, 256, 256, 255},
+			ValidBits:  12,
+			CropLeft:   8,
+			CropTop:    4,
+			CropWidth:  5184,
+			CropHeight: 3888,
+		},
+		DriveMode:          olympus.DriveMode{Mode: 1, ShotNumber: 3},
+		ImageStabilization: 1,
+	}
+	if mn != wanted {
+		t.Errorf("Incorrect Makernotes wanted %+v got %+v", wanted, mn)
+	}
+}
+
+func TestPanasonicRW2(t *testing.T) {
+	le := binary.LittleEndian
+	// JpgFromRaw with an ExifIFD and a Panasonic Makernote
+	jpg := tifftest.JPEG(tifftest.MakerNote(le, "Panasonic", func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("Panasonic\x00\x00\x00"), le, offset, []tifftest.Entry{
+			tifftest.Shorts(le, 0x002a, 3), // BurstMode
+		})
+	}, tifftest.Shorts(le, exififd.ISOSpeedRatings, 400)))
+	buf := tifftest.RW2([]tifftest.Entry{
+		tifftest.Shorts(le, rw2ifd.ISO, 200),
+		tifftest.Data(rw2ifd.JpgFromRaw, func(uint32) []byte { return jpg }),
+		tifftest.ASCII(ifds.Make, "Panasonic"),
+	})
+
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ImageType != imagetype.ImagePanaRAW {
+		t.Errorf("Incorrect ImageType wanted %s got %s", imagetype.ImagePanaRAW, e.ImageType)
+	}
+	if e.CameraMake != ifds.Panasonic {
+		t.Errorf("Incorrect CameraMake wanted %s got %s", ifds.Panasonic, e.CameraMake)
+	}
+	if e.ISOSpeed != 400 {
+		t.Errorf("Incorrect ISOSpeed wanted %d got %d", 400, e.ISOSpeed)
+	}
+	pmn, ok := e.Makernotes.(panasonic.MakerNote)
+	if !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if pmn.BurstMode != panasonic.BurstModeFocusBracketing {
+		t.Errorf("Incorrect BurstMode wanted %s got %s", panasonic.BurstModeFocusBracketing, pmn.BurstMode)
+	}
+
+	// Sample RW2
+	e = parseSample(t, "RW2.rw2")
+	if e.CameraMake != ifds.Panasonic || e.Model != "DC-G9" || e.ISOSpeed != 200 {
+		t.Errorf("Incorrect Exif got %s %s %d", e.CameraMake, e.Model, e.ISOSpeed)
+	}
+	if pmn, ok = e.Makernotes.(panasonic.MakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if pmn.LensType != "LUMIX G VARIO 12-60/F3.5-5.6" || pmn.SequenceNumber != 7 {
+		t.Errorf("Incorrect Makernotes got %+v", pmn)
+	}
+	rw2 := e.RW2
+	rw2.CameraIFD = nil
+	wanted := RW2Info{
+		BlackLevel:         [3]uint16{143, 144, 145},
+		WBLevels:           [3]uint16{1960, 1024, 1698},
+		CFAPattern:         "BGGR",
+		RawDataOffset:      0x1000,
+		SensorWidth:        5248,
+		SensorHeight:       3920,
+		SensorTopBorder:    4,
+		SensorLeftBorder:   8,
+		SensorBottomBorder: 3892,
+		SensorRightBorder:  5192,
+		BitsPerSample:      12,
+		Compression:        34316,
+	}
+	if !reflect.DeepEqual(rw2, wanted) {
+		t.Errorf("Incorrect RW2 wanted %+v got %+v", wanted, rw2)
+	}
+	if e.RW2.Width() != 5184 || e.RW2.Height() != 3888 {
+		t.Errorf("Incorrect RW2 size wanted 5184x3888 got %dx%d", e.RW2.Width(), e.RW2.Height())
+	}
+	if len(e.RW2.CameraIFD) != 2 || e.RW2.CameraIFD[0].ID != 0x1101 || e.RW2.CameraIFD[1].Val.([]uint32)[1] != 2048 {
+		t.Errorf("Incorrect CameraIFD got %+v", e.RW2.CameraIFD)
+	}
+
+	// CameraIFD tags are visited
+	f, err := os.Open("../testImages/RW2.rw2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var visited []tag.ID
+	if _, err = decodeReaderWithVisitor(t, f, TagVisitorFn(func(path string, tg Tag, v TagValue) error {
+		if path == "Ifd/CameraIFD" {
+			visited = append(visited, tg.ID)
+		}
+		return nil
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if len(visited) != 2 || visited[0] != 0x1101 || visited[1] != 0x3200 {
+		t.Errorf("Incorrect CameraIFD tags visited got %v", visited)
+	}
+}
+
+func TestPentaxMakerNote(t *testing.T) {
+	buf := tifftest.MakerNote(binary.LittleEndian, "PENTAX", func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("PENTAX \x00MM"), binary.BigEndian, 0, []tifftest.Entry{
+			tifftest.Bytes(0x003f, tag.TypeUndefined, []byte{4, 252, 0, 0}), // LensRec
+		})
+	})
+	e, err := Parse(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ImageType != imagetype.ImagePEF {
+		t.Errorf("Incorrect ImageType wanted %s got %s", imagetype.ImagePEF, e.ImageType)
+	}
+	mn, ok := e.Makernotes.(pentax.MakerNote)
+	if !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if mn.LensType != (pentax.LensType{Series: 4, Model: 252}) {
+		t.Errorf("Incorrect LensType got %s", mn.LensType)
+	}
+
+	// Sample PEF
+	e = parseSample(t, "PEF.pef")
+	if e.ImageType != imagetype.ImagePEF || e.CameraMake != ifds.Pentax || e.Model != "PENTAX K-3" {
+		t.Errorf("Incorrect Exif got %s %s %s", e.ImageType, e.CameraMake, e.Model)
+	}
+	if mn, ok = e.Makernotes.(pentax.MakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	wanted := pentax.MakerNote{
+		SerialNumber:    "4250917",
+		LensType:        pentax.LensType{Series: 4, Model: 252},
+		ShutterCount:    48213,
+		Level:           pentax.LevelInfo{Orientation: 1, Roll: 2, Pitch: -1},
+		ShakeReduction:  pentax.ShakeReduction{Result: 1, Mode: 1},
+		AFPointSelected: 6,
+	}
+	if mn != wanted {
+		t.Errorf("Incorrect Makernotes wanted %+v got %+v", wanted, mn)
+	}
+}
+
+func TestRegisterMakerNoteDecoder(t *testing.T) {
+	type leicaMakerNote struct {
+		Model  string
+		Values []uint16
+	}
+	mkNote := func(offset uint32) []byte {
+		mn := []byte("LEICA\x00\x00\x00")
+		mn = binary.LittleEndian.AppendUint16(mn, 1)
+		mn = binary.LittleEndian.AppendUint16(mn, 0x0300)
+		mn = binary.LittleEndian.AppendUint16(mn, uint16(tag.TypeShort))
+		mn = binary.LittleEndian.AppendUint32(mn, 1)
+		mn = binary.LittleEndian.AppendUint32(mn, 7)
+		return binary.LittleEndian.AppendUint32(mn, 0)
+	}
+	decoder := func(mn MakerNote) (MakerNotes, error) {
+		entries, _, err := mn.Reader(mn.Offset).ReadIfd(8)
+		if err != nil {
+			return nil, err
+		}
+		lmn := leicaMakerNote{Model: mn.Make}
+		for _, e := range entries {
+			lmn.Values = append(lmn.Values, e.Uint16())
+		}
+		return lmn, nil
+	}
+
+	// Registered by CameraMake
+	RegisterMakerNoteDecoder(ifds.Leica, decoder)
+	e, err := Parse(bytes.NewReader(tifftest.MakerNote(binary.LittleEndian, "Leica", mkNote)))
+	RegisterMakerNoteDecoder(ifds.Leica, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mn, ok := e.Makernotes.(leicaMakerNote)
+	if !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+	if mn.Model != "Leica" || len(mn.Values) != 1 || mn.Values[0] != 7 {
+		t.Errorf("Incorrect Makernote got %v", mn)
+	}
+
+	// Registered by header
+	RegisterMakerNoteHeader([]byte("LEICA\x00"), decoder)
+	e, err = Parse(bytes.NewReader(tifftest.MakerNote(binary.LittleEndian, "Unknown", mkNote)))
+	RegisterMakerNoteHeader([]byte("LEICA\x00"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = e.Makernotes.(leicaMakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+
+	// Unregistered
+	e, _ = Parse(bytes.NewReader(tifftest.MakerNote(binary.LittleEndian, "Leica", mkNote)))
+	if e.Makernotes != nil {
+		t.Errorf("Incorrect Makernotes wanted nil got %T", e.Makernotes)
+	}
+
+	// Replaces the Canon decoder of this package
+	RegisterMakerNoteDecoder(ifds.Canon, decoder)
+	e, err = Parse(bytes.NewReader(tifftest.MakerNote(binary.LittleEndian, "Canon", mkNote)))
+	registerMakerNoteReader(ifds.Canon, readCanonMakerNote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = e.Makernotes.(leicaMakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+
+	// Header takes precedence over the Canon decoder of this package
+	RegisterMakerNoteHeader([]byte("LEICA\x00"), decoder)
+	e, err = Parse(bytes.NewReader(tifftest.MakerNote(binary.LittleEndian, "Canon", mkNote)))
+	RegisterMakerNoteHeader([]byte("LEICA\x00"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = e.Makernotes.(leicaMakerNote); !ok {
+		t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+	}
+}
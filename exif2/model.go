@@ -6,21 +6,37 @@ import (
 	"time"
 
 	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/iopifd"
 	"github.com/evanoberholster/imagemeta/imagetype"
 	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/raf"
 )
 
 // Exif data structure
 type Exif struct {
 	ApplicationNotes          []byte               // 0x02bc
 	GPS                       GPSInfo              // 0x8825
+	Thumbnail                 ThumbnailInfo        // IFD1
+	Interop                   InteropInfo          // ExifIFD / 0xa005
 	SubjectArea               SubjectArea          // ExifIFD / 0x9214
 	LensInfo                  LensInfo             // ExifIFD / 0xa432	(4 rational values giving focal and aperture ranges, called LensSpecification by the EXIF spec.)
 	Makernotes                MakerNotes           // ExifIFD / MakerNote
+	DNG                       DNGInfo              // DNG
+	Raw                       RawInfo              // IFD0 or SubIfd with NewSubfileType 0
+	RAF                       raf.RAF              // Fujifilm RAF Header and Directory
+	RW2                       RW2Info              // Panasonic RW2 IFD0
+	Rationals                 ExifRationals        // Rational values of ExposureTime, FNumber, FocalLength...
+	Ifds                      RawIfds              // Retained tags, see ParseAllTags
 	Time                      TimeTags             // TimeTags
 	ProcessingSoftware        string               // IFD0 / 0x000b
 	DocumentName              string               // IFD0 / 0x010d
 	ImageDescription          string               // IFD0 / 0x010e
+	XPTitle                   string               // IFD0 / 0x9c9b (Windows)
+	XPComment                 string               // IFD0 / 0x9c9c (Windows)
+	XPAuthor                  string               // IFD0 / 0x9c9d (Windows)
+	XPSubject                 string               // IFD0 / 0x9c9f (Windows)
+	XPKeywords                []string             // IFD0 / 0x9c9e (Windows)
+	UserComment               string               // ExifIFD / 0x9286 (without the character code prefix)
 	Software                  string               // IFD0 / 0x0131
 	Artist                    string               // IFD0 / 0x013b
 	Copyright                 string               // IFD0 / 0x8298
@@ -30,30 +46,47 @@ type Exif struct {
 	ImageUniqueID             string               // ExifIFD / 0xa420
 	OwnerName                 string               // ExifIFD / 0xa430	(called CameraOwnerName by the EXIF spec.)
 	CameraSerial              string               // ExifIFD / 0xa431	(called BodySerialNumber by the EXIF spec.)
+	ImageTitle                string               // ExifIFD / 0xa436 (Exif 3.0)
+	Photographer              string               // ExifIFD / 0xa437 (Exif 3.0)
+	ImageEditor               string               // ExifIFD / 0xa438 (Exif 3.0)
+	CameraFirmware            string               // ExifIFD / 0xa439 (Exif 3.0)
+	RAWDevelopingSoftware     string               // ExifIFD / 0xa43a (Exif 3.0)
+	ImageEditingSoftware      string               // ExifIFD / 0xa43b (Exif 3.0)
+	MetadataEditingSoftware   string               // ExifIFD / 0xa43c (Exif 3.0)
 	Make                      string               // IFD0 / 0x010f
 	Model                     string               // IFD0 / 0x0110
 	CameraModel               ifds.CameraModel     // CameraModel
 	CameraMake                ifds.CameraMake      // CameraMake
-	XResolution               uint32               // IFD0 / 0x011a rational64u
-	YResolution               uint32               // IFD0 / 0x011b
+	XResolution               meta.Rational        // IFD0 / 0x011a
+	YResolution               meta.Rational        // IFD0 / 0x011b
 	ExposureTime              meta.ExposureTime    // 0x829a
 	SubjectDistance           float32              // ExifIFD / 0x9206
+	BrightnessValue           float32              // ExifIFD / 0x9203 (APEX)
+	DigitalZoomRatio          float32              // ExifIFD / 0xa404 (0 if digital zoom was not used)
+	FocalPlaneXResolution     float32              // ExifIFD / 0xa20e
+	FocalPlaneYResolution     float32              // ExifIFD / 0xa20f
+	Gamma                     float32              // ExifIFD / 0xa500
+	ShutterSpeedValue         meta.ExposureTime    // ExifIFD / 0x9201 (converted from APEX)
+	MaxApertureValue          meta.Aperture        // ExifIFD / 0x9205 (converted from APEX)
 	FocalLength               meta.FocalLength     // ExifIFD / 0x920a
 	FocalLengthIn35mmFormat   meta.FocalLength     // ExifIFD / 0xa405
 	StripOffsets              uint32               // IFD0 / 0x0111 PreviewImageStart
 	StripByteCounts           uint32               // IFD0 / 0x0117 PreviewImageLength
-	ThumbnailOffset           uint32               // 0x0201
-	ThumbnailLength           uint32               // 0x0202
+	ThumbnailOffset           uint32               // IFD1 / 0x0201
+	ThumbnailLength           uint32               // IFD1 / 0x0202
 	SubfileType               uint32               // IFD0 / 0x00fe
 	FNumber                   meta.Aperture        // 0x829d
 	ISOSpeed                  uint32               // ExifIFD / 0x8833
 	ImageNumber               uint32               // ExifIFD / 0x9211
-	ImageWidth                uint16               // IFD0 / 0x0100 // ExifIFD	/ 0xa002	ExifImageWidth	int16u:	(called PixelXDimension by the EXIF spec.)
-	ImageHeight               uint16               // IFD0 / 0x0101 // ExifIFD	/ 0xa003	ExifImageHeight	int16u:	(called PixelYDimension by the EXIF spec.)
+	StandardOutputSensitivity uint32               // ExifIFD / 0x8831
+	RecommendedExposureIndex  uint32               // ExifIFD / 0x8832
+	ImageWidth                uint32               // IFD0 / 0x0100 // ExifIFD	/ 0xa002	ExifImageWidth	int32u:	(called PixelXDimension by the EXIF spec.)
+	ImageHeight               uint32               // IFD0 / 0x0101 // ExifIFD	/ 0xa003	ExifImageHeight	int32u:	(called PixelYDimension by the EXIF spec.)
 	Compression               meta.Compression     // IFD0 / 0x0103
 	PhotometricInterpretation uint16               // IFD0 / 0x0106
 	Orientation               meta.Orientation     // IFD0 / 0x0112
 	ResolutionUnit            uint16               // IFD0 / 0x0128
+	FocalPlaneResolutionUnit  uint16               // ExifIFD / 0xa210
 	Rating                    uint16               // 0x4746
 	ExposureProgram           meta.ExposureProgram // 0x8822
 	ExposureBias              meta.ExposureBias    // 0x0d34
@@ -62,11 +95,19 @@ type Exif struct {
 	SelfTimerMode             uint16               // ExifIFD / 0x882b
 	MeteringMode              meta.MeteringMode    // ExifIFD / 0x9207
 	Flash                     meta.Flash           // ExifIFD / 0x9209
+	LightSource               meta.LightSource     // ExifIFD / 0x9208
 	ColorSpace                ColorSpace           // ExifIFD / 0xa001
 	ImageType                 imagetype.ImageType
-
-	// 0xa20e	FocalPlaneXResolution	rational64u	ExifIFD
-	// 0xa20f	FocalPlaneYResolution	rational64u	ExifIFD
+	WhiteBalance              meta.WhiteBalance         // ExifIFD / 0xa403
+	SceneCaptureType          meta.SceneCaptureType     // ExifIFD / 0xa406
+	SceneType                 meta.SceneType            // ExifIFD / 0xa301
+	Contrast                  meta.Contrast             // ExifIFD / 0xa408
+	Saturation                meta.Saturation           // ExifIFD / 0xa409
+	Sharpness                 meta.Sharpness            // ExifIFD / 0xa40a
+	SubjectDistanceRange      meta.SubjectDistanceRange // ExifIFD / 0xa40c
+	SensitivityType           meta.SensitivityType      // ExifIFD / 0x8830
+	CompositeImage            meta.CompositeImage       // ExifIFD / 0xa460
+	UserCommentCharset        meta.CharacterCode        // ExifIFD / 0x9286 character code prefix
 }
 
 // ModifyDate return the exif modified date with subsec offset if present
@@ -150,22 +191,147 @@ func (e Exif) String() string {
 // LensInfo struct
 type LensInfo [8]uint32
 
+// ExifRationals are the original rational values of Exif fields that are
+// stored as floats.
+type ExifRationals struct {
+	ExposureTime      meta.Rational  // ExifIFD / 0x829a
+	FNumber           meta.Rational  // ExifIFD / 0x829d
+	ShutterSpeedValue meta.SRational // ExifIFD / 0x9201 (APEX)
+	ApertureValue     meta.Rational  // ExifIFD / 0x9202 (APEX)
+	ExposureBias      meta.SRational // ExifIFD / 0x9204
+	MaxApertureValue  meta.Rational  // ExifIFD / 0x9205 (APEX)
+	FocalLength       meta.Rational  // ExifIFD / 0x920a
+}
+
 // ColorSpace data
 type ColorSpace uint16
 
+// ColorSpace values
+const (
+	ColorSpaceUnknown      ColorSpace = 0
+	ColorSpaceSRGB         ColorSpace = 1
+	ColorSpaceAdobeRGB     ColorSpace = 2
+	ColorSpaceWideGamutRGB ColorSpace = 0xfffd
+	ColorSpaceICCProfile   ColorSpace = 0xfffe
+	ColorSpaceUncalibrated ColorSpace = 0xffff
+)
+
+// String returns a ColorSpace as a string
+func (cs ColorSpace) String() string {
+	switch cs {
+	case ColorSpaceSRGB:
+		return "sRGB"
+	case ColorSpaceAdobeRGB:
+		return "Adobe RGB"
+	case ColorSpaceWideGamutRGB:
+		return "Wide Gamut RGB"
+	case ColorSpaceICCProfile:
+		return "ICC Profile"
+	case ColorSpaceUncalibrated:
+		return "Uncalibrated"
+	}
+	return fmt.Sprintf("Unknown (%d)", cs)
+}
+
+// EffectiveColorSpace returns the ColorSpace of the image. Cameras that
+// record Adobe RGB images set ColorSpace to Uncalibrated and the
+// InteropIndex to "R03".
+func (e Exif) EffectiveColorSpace() ColorSpace {
+	if e.ColorSpace == ColorSpaceUncalibrated && e.Interop.Index == iopifd.IndexR03 {
+		return ColorSpaceAdobeRGB
+	}
+	return e.ColorSpace
+}
+
+// ThumbnailInfo is the thumbnail image of IFD1
+type ThumbnailInfo struct {
+	Offset         uint32           // IFD1 / 0x0201 ThumbnailOffset
+	Length         uint32           // IFD1 / 0x0202 ThumbnailLength
+	Width          uint32           // IFD1 / 0x0100
+	Height         uint32           // IFD1 / 0x0101
+	XResolution    meta.Rational    // IFD1 / 0x011a
+	YResolution    meta.Rational    // IFD1 / 0x011b
+	ResolutionUnit uint16           // IFD1 / 0x0128
+	Compression    meta.Compression // IFD1 / 0x0103
+	Orientation    meta.Orientation // IFD1 / 0x0112
+}
+
+// InteropInfo is the Interoperability Ifd
+type InteropInfo struct {
+	Index                  string // InteropIFD / 0x0001 ex: "R98" or "R03"
+	Version                string // InteropIFD / 0x0002 ex: "0100"
+	RelatedImageFileFormat string // InteropIFD / 0x1000
+	RelatedImageWidth      uint32 // InteropIFD / 0x1001
+	RelatedImageHeight     uint32 // InteropIFD / 0x1002
+}
+
 // SubjectArea coordinates
 type SubjectArea []uint16
 
 // GPSInfo data sctructure
 type GPSInfo struct {
-	latitude     float64 // Combination of GPSLatitudeRef and GPSLatitude
-	longitude    float64 // Combination of GPSLongitudeRef and GPSLongitude
-	date         time.Time
-	time         uint32  // time in seconds
-	altitude     float32 // Combination of GPSAltitudeRef and GPSAltitude
-	latitudeRef  bool
-	longitudeRef bool
-	altitudeRef  bool
+	Satellites              string // GPS / 0x0008
+	MapDatum                string // GPS / 0x0012
+	ProcessingMethod        string // GPS / 0x001b (without the character code prefix)
+	Rationals               GPSRationals
+	latitude                float64 // Combination of GPSLatitudeRef and GPSLatitude
+	longitude               float64 // Combination of GPSLongitudeRef and GPSLongitude
+	date                    time.Time
+	time                    uint32               // time in seconds
+	altitude                float32              // Combination of GPSAltitudeRef and GPSAltitude
+	DOP                     float32              // GPS / 0x000b
+	Speed                   float32              // GPS / 0x000d in the unit of SpeedRef
+	Track                   float32              // GPS / 0x000f in degrees
+	ImgDirection            float32              // GPS / 0x0011 in degrees
+	DestBearing             float32              // GPS / 0x0018 in degrees
+	DestDistance            float32              // GPS / 0x001a in the unit of DestDistanceRef
+	HPositioningError       float32              // GPS / 0x001f in metres
+	VersionID               [4]byte              // GPS / 0x0000
+	Status                  meta.GPSStatus       // GPS / 0x0009
+	MeasureMode             meta.GPSMeasureMode  // GPS / 0x000a
+	SpeedRef                meta.GPSSpeedRef     // GPS / 0x000c
+	TrackRef                meta.GPSDirectionRef // GPS / 0x000e
+	ImgDirectionRef         meta.GPSDirectionRef // GPS / 0x0010
+	DestBearingRef          meta.GPSDirectionRef // GPS / 0x0017
+	DestDistanceRef         meta.GPSDistanceRef  // GPS / 0x0019
+	ProcessingMethodCharset meta.CharacterCode   // GPS / 0x001b character code prefix
+	Differential            bool                 // GPS / 0x001e differential correction applied
+	latitudeRef             bool
+	longitudeRef            bool
+	altitudeRef             bool
+	hasLatitude             bool
+	hasLongitude            bool
+}
+
+// GPSRationals are the original rational values of GPSInfo
+type GPSRationals struct {
+	Latitude          [3]meta.Rational // GPS / 0x0002 degrees, minutes and seconds
+	Longitude         [3]meta.Rational // GPS / 0x0004 degrees, minutes and seconds
+	Altitude          meta.Rational    // GPS / 0x0006
+	TimeStamp         [3]meta.Rational // GPS / 0x0007 hours, minutes and seconds
+	DOP               meta.Rational    // GPS / 0x000b
+	Speed             meta.Rational    // GPS / 0x000d
+	Track             meta.Rational    // GPS / 0x000f
+	ImgDirection      meta.Rational    // GPS / 0x0011
+	DestBearing       meta.Rational    // GPS / 0x0018
+	DestDistance      meta.Rational    // GPS / 0x001a
+	HPositioningError meta.Rational    // GPS / 0x001f
+}
+
+// HasFix returns true if the GPSInfo has a latitude and longitude and the
+// measurement was not void.
+func (g GPSInfo) HasFix() bool {
+	return g.hasLatitude && g.hasLongitude && g.Status != meta.GPSStatusVoid
+}
+
+// SpeedMetresPerSecond returns the GPSSpeed in metres per second
+func (g GPSInfo) SpeedMetresPerSecond() float64 {
+	return g.SpeedRef.MetresPerSecond(float64(g.Speed))
+}
+
+// DestDistanceMetres returns the GPSDestDistance in metres
+func (g GPSInfo) DestDistanceMetres() float64 {
+	return g.DestDistanceRef.Metres(float64(g.DestDistance))
 }
 
 // Date returns the GPSDatesamp and GPSTimestamp tags as a composite
@@ -205,18 +371,19 @@ type TimeTags struct {
 	modifyDate          time.Time      // IFD0 / 0x0132
 	dateTimeOriginal    time.Time      // ExifIFD / 0x9003
 	createDate          time.Time      // ExifIFD / 0x9004
-	offsetTime          *time.Location // ExifIFD / 0x9010 (time zone for ModifyDate)
-	offsetTimeOriginal  *time.Location // ExifIFD / 0x9011 (time zone for DateTimeOriginal)
+	offsetTime          *time.Location // ExifIFD / 0x9010 (time zone for ModifyDate) or ExifIFD / 0x882a TimeZoneOffset
+	offsetTimeOriginal  *time.Location // ExifIFD / 0x9011 (time zone for DateTimeOriginal) or ExifIFD / 0x882a TimeZoneOffset
 	offsetTimeDigitized *time.Location // ExifIFD / 0x9012 (time zone for CreateDate)
 	subSecTime          uint16         // ExifIFD / 0x9290 (fractional seconds for ModifyDate)
 	subSecTimeOriginal  uint16         // ExifIFD / 0x9291 (fractional seconds for DateTimeOriginal)
 	subSecTimeDigitized uint16         // ExifIFD / 0x9292 (fractional seconds for CreateDate)
-	//timeZoneOffset      [2]int8        // ExifIFD / 0x882a // FixMe (1 or 2 values: 1. The time zone offset of DateTimeOriginal from GMT in hours, 2. If present, the time zone offset of ModifyDate)
 }
 
 // ApplicationNotes data are stil work in process
 type ApplicationNotes []byte
 
-// MakerNotes are still work in process
+// MakerNotes is a decoded Makernote. The concrete type is returned by the
+// MakerNoteDecoder registered for the CameraMake or Makernote header,
+// e.g. fujifilm.MakerNote or olympus.MakerNote.
 type MakerNotes interface {
 }
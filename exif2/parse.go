@@ -1,20 +1,28 @@
 package exif2
 
 import (
-	"math"
+	"fmt"
 	"time"
 
 	"github.com/evanoberholster/imagemeta/exif2/ifds"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/gpsifd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/iopifd"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/apple"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/canon"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/rw2ifd"
 	"github.com/evanoberholster/imagemeta/exif2/tag"
 	"github.com/evanoberholster/imagemeta/imagetype"
 	"github.com/evanoberholster/imagemeta/meta"
 )
 
 func (ir *ifdReader) parseTag(t Tag) {
+	if ir.retainTags {
+		ir.retainTag(t)
+	}
+	if ir.visitor != nil && !ir.stopped() {
+		ir.visitTag(t)
+	}
 	if ir.customTagParser != nil {
 		if err := ir.customTagParser(ir, t); err != nil {
 			ir.logError(err).Send()
@@ -23,6 +31,17 @@ func (ir *ifdReader) parseTag(t Tag) {
 	}
 	switch ifds.IfdType(t.Ifd) {
 	case ifds.IFD0:
+		if t.IfdIndex > 0 {
+			if t.IfdIndex == 1 {
+				ir.parseThumbnailTag(t)
+			}
+			return
+		}
+		ir.parseRawTag(t)
+		if ir.Exif.ImageType == imagetype.ImagePanaRAW && rw2ifd.IsPanasonicRawTag(t.ID) {
+			ir.parsePanasonicRawTag(t)
+			return
+		}
 		switch t.ID {
 		case ifds.Make:
 			ir.Exif.CameraMake, ir.Exif.Make = ir.ParseCameraMake(t)
@@ -33,9 +52,15 @@ func (ir *ifdReader) parseTag(t Tag) {
 		case ifds.Copyright:
 			ir.Exif.Copyright = ir.ParseString(t)
 		case ifds.ImageWidth:
-			ir.Exif.ImageWidth = uint16(ir.ParseUint32(t))
+			ir.Exif.ImageWidth = ir.ParseUint32(t)
 		case ifds.ImageLength:
-			ir.Exif.ImageHeight = uint16(ir.ParseUint32(t))
+			ir.Exif.ImageHeight = ir.ParseUint32(t)
+		case ifds.XResolution:
+			ir.Exif.XResolution = ir.ParseRational(t)
+		case ifds.YResolution:
+			ir.Exif.YResolution = ir.ParseRational(t)
+		case ifds.ResolutionUnit:
+			ir.Exif.ResolutionUnit = ir.ParseUint16(t)
 		case ifds.StripOffsets:
 			ir.Exif.StripOffsets = ir.ParseUint32(t)
 		case ifds.StripByteCounts:
@@ -48,11 +73,22 @@ func (ir *ifdReader) parseTag(t Tag) {
 			ir.Exif.ImageDescription = ir.ParseString(t)
 		case ifds.DateTime:
 			ir.Exif.Time.modifyDate = ir.ParseDate(t)
+		case ifds.XPTitle:
+			ir.Exif.XPTitle = ir.parseXPString(t)
+		case ifds.XPComment:
+			ir.Exif.XPComment = ir.parseXPString(t)
+		case ifds.XPAuthor:
+			ir.Exif.XPAuthor = ir.parseXPString(t)
+		case ifds.XPKeywords:
+			ir.Exif.XPKeywords = splitXPKeywords(ir.parseXPString(t))
+		case ifds.XPSubject:
+			ir.Exif.XPSubject = ir.parseXPString(t)
 		case ifds.DNGVersion:
 			// TODO: If DNG version > 0 imagetype is DNG
 			if ir.Exif.ImageType == imagetype.ImageTiff {
 				ir.Exif.ImageType = imagetype.ImageDNG
 			}
+			ir.parseDNGTag(t)
 
 		case ifds.CameraSerialNumber:
 			if ir.Exif.CameraSerial == "" {
@@ -62,8 +98,11 @@ func (ir *ifdReader) parseTag(t Tag) {
 		//fmt.Println(ir.parseApplicationNotes(t))
 		//ir.Exif.ApplicationNotes =
 		default:
-			//t.logTag(ir.logWarn()).Send()
+			ir.parseDNGTag(t)
 		}
+	case ifds.SubIfd0, ifds.SubIfd1, ifds.SubIfd2, ifds.SubIfd3, ifds.SubIfd4, ifds.SubIfd5, ifds.SubIfd6, ifds.SubIfd7:
+		ir.parseRawTag(t)
+		ir.parseDNGTag(t)
 	case ifds.ExifIFD:
 		switch t.ID {
 		case exififd.LensMake:
@@ -72,6 +111,22 @@ func (ir *ifdReader) parseTag(t Tag) {
 			ir.Exif.LensModel = ir.ParseString(t)
 		case exififd.LensSerialNumber:
 			ir.Exif.LensSerial = ir.ParseString(t)
+		case exififd.UserComment:
+			ir.Exif.UserCommentCharset, ir.Exif.UserComment = ir.parseCharacterCodeString(t)
+		case exififd.ImageTitle:
+			ir.Exif.ImageTitle = ir.ParseString(t)
+		case exififd.Photographer:
+			ir.Exif.Photographer = ir.ParseString(t)
+		case exififd.ImageEditor:
+			ir.Exif.ImageEditor = ir.ParseString(t)
+		case exififd.CameraFirmware:
+			ir.Exif.CameraFirmware = ir.ParseString(t)
+		case exififd.RAWDevelopingSoftware:
+			ir.Exif.RAWDevelopingSoftware = ir.ParseString(t)
+		case exififd.ImageEditingSoftware:
+			ir.Exif.ImageEditingSoftware = ir.ParseString(t)
+		case exififd.MetadataEditingSoftware:
+			ir.Exif.MetadataEditingSoftware = ir.ParseString(t)
 		case exififd.CameraOwnerName:
 			if ir.Exif.Artist == "" {
 				ir.Exif.Artist = ir.ParseString(t)
@@ -82,39 +137,94 @@ func (ir *ifdReader) parseTag(t Tag) {
 			}
 		case exififd.PixelXDimension:
 			if ir.Exif.ImageWidth == 0 {
-				ir.Exif.ImageWidth = uint16(ir.ParseUint32(t))
+				ir.Exif.ImageWidth = ir.ParseUint32(t)
 			}
 		case exififd.PixelYDimension:
 			if ir.Exif.ImageHeight == 0 {
-				ir.Exif.ImageHeight = uint16(ir.ParseUint32(t))
+				ir.Exif.ImageHeight = ir.ParseUint32(t)
 			}
 		case exififd.ExposureTime:
-			ir.Exif.ExposureTime = ir.parseExposureTime(t)
+			ir.Exif.Rationals.ExposureTime = ir.ParseRational(t)
+			ir.Exif.ExposureTime = meta.ExposureTime(ir.Exif.Rationals.ExposureTime.Float())
 		case exififd.ApertureValue:
+			ir.Exif.Rationals.ApertureValue = ir.ParseRational(t)
 			if ir.Exif.FNumber == 0.0 {
-				r := ir.ParseRationalU(t)
-				f := float64(r[0]) / float64(r[1])
-				ir.Exif.FNumber = meta.Aperture(math.Round(math.Pow(math.Sqrt2, float64(f))*100) / 100)
+				ir.Exif.FNumber = meta.NewApertureFromAPEX(ir.Exif.Rationals.ApertureValue.Float())
 			}
+		case exififd.MaxApertureValue:
+			ir.Exif.Rationals.MaxApertureValue = ir.ParseRational(t)
+			ir.Exif.MaxApertureValue = meta.NewApertureFromAPEX(ir.Exif.Rationals.MaxApertureValue.Float())
+		case exififd.ShutterSpeedValue:
+			ir.Exif.Rationals.ShutterSpeedValue = ir.ParseSRational(t)
+			ir.Exif.ShutterSpeedValue = meta.NewExposureTimeFromAPEX(ir.Exif.Rationals.ShutterSpeedValue.Float())
+		case exififd.BrightnessValue:
+			ir.Exif.BrightnessValue = ir.parseRationalFloat(t)
 		case exififd.FNumber:
-			ir.Exif.FNumber = ir.parseAperture(t)
+			ir.Exif.Rationals.FNumber = ir.ParseRational(t)
+			ir.Exif.FNumber = meta.Aperture(ir.Exif.Rationals.FNumber.Float())
 		case exififd.ExposureProgram:
 			ir.Exif.ExposureProgram = meta.ExposureProgram(ir.ParseUint16(t))
 		case exififd.ExposureBiasValue:
-			ir.Exif.ExposureBias = ir.parseExposureBias(t)
+			ir.Exif.Rationals.ExposureBias = ir.ParseSRational(t)
+			ir.Exif.ExposureBias = meta.NewExposureBiasFromSRational(ir.Exif.Rationals.ExposureBias)
 		case exififd.ExposureMode:
 			ir.Exif.ExposureMode = meta.ExposureMode(ir.ParseUint16(t))
 		case exififd.MeteringMode:
 			ir.Exif.MeteringMode = meta.MeteringMode(ir.ParseUint16(t))
 		case exififd.ISOSpeedRatings:
 			ir.Exif.ISOSpeed = ir.ParseUint32(t)
+		case exififd.SensitivityType:
+			ir.Exif.SensitivityType = meta.NewSensitivityType(ir.ParseUint16(t))
+		case exififd.StandardOutputSensitivity:
+			ir.Exif.StandardOutputSensitivity = ir.ParseUint32(t)
+		case exififd.RecommendedExposureIndex:
+			ir.Exif.RecommendedExposureIndex = ir.ParseUint32(t)
+		case exififd.WhiteBalance:
+			ir.Exif.WhiteBalance = meta.NewWhiteBalance(ir.ParseUint16(t))
+		case exififd.LightSource:
+			ir.Exif.LightSource = meta.LightSource(ir.ParseUint16(t))
+		case exififd.SceneCaptureType:
+			ir.Exif.SceneCaptureType = meta.NewSceneCaptureType(ir.ParseUint16(t))
+		case exififd.SceneType:
+			ir.Exif.SceneType = meta.SceneType(ir.parseUint8(t))
+		case exififd.Contrast:
+			ir.Exif.Contrast = meta.NewContrast(ir.ParseUint16(t))
+		case exififd.Saturation:
+			ir.Exif.Saturation = meta.NewSaturation(ir.ParseUint16(t))
+		case exififd.Sharpness:
+			ir.Exif.Sharpness = meta.NewSharpness(ir.ParseUint16(t))
+		case exififd.SubjectDistanceRange:
+			ir.Exif.SubjectDistanceRange = meta.NewSubjectDistanceRange(ir.ParseUint16(t))
+		case exififd.DigitalZoomRatio:
+			ir.Exif.DigitalZoomRatio = ir.parseRationalFloat(t)
+		case exififd.FocalPlaneXResolution:
+			ir.Exif.FocalPlaneXResolution = ir.parseRationalFloat(t)
+		case exififd.FocalPlaneYResolution:
+			ir.Exif.FocalPlaneYResolution = ir.parseRationalFloat(t)
+		case exififd.FocalPlaneResolutionUnit:
+			ir.Exif.FocalPlaneResolutionUnit = ir.ParseUint16(t)
+		case exififd.CompositeImage:
+			ir.Exif.CompositeImage = meta.NewCompositeImage(ir.ParseUint16(t))
+		case exififd.Gamma:
+			ir.Exif.Gamma = ir.parseRationalFloat(t)
+		case exififd.ColorSpace:
+			ir.Exif.ColorSpace = ColorSpace(ir.ParseUint16(t))
+		case exififd.TimeZoneOffset:
+			original, modify := ir.parseTimeZoneOffset(t)
+			if ir.Exif.Time.offsetTimeOriginal == nil {
+				ir.Exif.Time.offsetTimeOriginal = original
+			}
+			if ir.Exif.Time.offsetTime == nil {
+				ir.Exif.Time.offsetTime = modify
+			}
 
 		case ifds.Flash:
 			ir.Exif.Flash = meta.Flash(ir.ParseUint16(t))
 		case ifds.FocalLength:
-			ir.Exif.FocalLength = ir.parseFocalLength(t)
+			ir.Exif.Rationals.FocalLength = ir.parseFocalLength(t)
+			ir.Exif.FocalLength = meta.FocalLength(ir.Exif.Rationals.FocalLength.Float())
 		case exififd.FocalLengthIn35mmFilm:
-			ir.Exif.FocalLengthIn35mmFormat = ir.parseFocalLength(t)
+			ir.Exif.FocalLengthIn35mmFormat = meta.FocalLength(ir.parseFocalLength(t).Float())
 		case exififd.LensSpecification:
 			ir.Exif.LensInfo = ir.parseLensInfo(t)
 		case ifds.DateTimeOriginal:
@@ -136,6 +246,19 @@ func (ir *ifdReader) parseTag(t Tag) {
 		default:
 			//t.logTag(ir.logWarn()).Send()
 		}
+	case ifds.IopIFD:
+		switch t.ID {
+		case iopifd.InteropIndex:
+			ir.Exif.Interop.Index = ir.ParseString(t)
+		case iopifd.InteropVersion:
+			ir.Exif.Interop.Version = ir.ParseString(t)
+		case iopifd.RelatedImageFileFormat:
+			ir.Exif.Interop.RelatedImageFileFormat = ir.ParseString(t)
+		case iopifd.RelatedImageWidth:
+			ir.Exif.Interop.RelatedImageWidth = ir.ParseUint32(t)
+		case iopifd.RelatedImageHeight:
+			ir.Exif.Interop.RelatedImageHeight = ir.ParseUint32(t)
+		}
 	case ifds.GPSIFD:
 		switch t.ID {
 		case gpsifd.GPSAltitudeRef:
@@ -145,15 +268,71 @@ func (ir *ifdReader) parseTag(t Tag) {
 		case gpsifd.GPSLongitudeRef:
 			ir.Exif.GPS.longitudeRef = ir.ParseGPSRef(t)
 		case gpsifd.GPSAltitude:
-			ir.Exif.GPS.altitude = ir.ParseGPSAltitude(t)
+			ir.Exif.GPS.Rationals.Altitude = ir.ParseRational(t)
+			ir.Exif.GPS.altitude = float32(ir.Exif.GPS.Rationals.Altitude.Float())
 		case gpsifd.GPSLatitude:
-			ir.Exif.GPS.latitude = ir.ParseGPSCoord(t)
+			if ir.parseRationals(t, ir.Exif.GPS.Rationals.Latitude[:]) == 3 {
+				ir.Exif.GPS.latitude = gpsCoord(ir.Exif.GPS.Rationals.Latitude)
+				ir.Exif.GPS.hasLatitude = true
+			}
 		case gpsifd.GPSLongitude:
-			ir.Exif.GPS.longitude = ir.ParseGPSCoord(t)
+			if ir.parseRationals(t, ir.Exif.GPS.Rationals.Longitude[:]) == 3 {
+				ir.Exif.GPS.longitude = gpsCoord(ir.Exif.GPS.Rationals.Longitude)
+				ir.Exif.GPS.hasLongitude = true
+			}
 		case gpsifd.GPSTimeStamp:
-			ir.Exif.GPS.time = ir.parseGPSTimeStamp(t)
+			if ir.parseRationals(t, ir.Exif.GPS.Rationals.TimeStamp[:]) == 3 {
+				ir.Exif.GPS.time = gpsTimeStamp(ir.Exif.GPS.Rationals.TimeStamp)
+			}
 		case gpsifd.GPSDateStamp:
 			ir.Exif.GPS.date = ir.parseGPSDateStamp(t)
+		case gpsifd.GPSVersionID:
+			if t.IsEmbedded() && t.IsType(tag.TypeByte) {
+				t.EmbeddedValue(ir.Exif.GPS.VersionID[:])
+			}
+		case gpsifd.GPSSatellites:
+			ir.Exif.GPS.Satellites = ir.ParseString(t)
+		case gpsifd.GPSStatus:
+			ir.Exif.GPS.Status = meta.GPSStatus(ir.parseGPSRefChar(t))
+		case gpsifd.GPSMeasureMode:
+			ir.Exif.GPS.MeasureMode = meta.GPSMeasureMode(ir.parseGPSRefChar(t))
+		case gpsifd.GPSDOP:
+			ir.Exif.GPS.Rationals.DOP = ir.ParseRational(t)
+			ir.Exif.GPS.DOP = float32(ir.Exif.GPS.Rationals.DOP.Float())
+		case gpsifd.GPSSpeedRef:
+			ir.Exif.GPS.SpeedRef = meta.GPSSpeedRef(ir.parseGPSRefChar(t))
+		case gpsifd.GPSSpeed:
+			ir.Exif.GPS.Rationals.Speed = ir.ParseRational(t)
+			ir.Exif.GPS.Speed = float32(ir.Exif.GPS.Rationals.Speed.Float())
+		case gpsifd.GPSTrackRef:
+			ir.Exif.GPS.TrackRef = meta.GPSDirectionRef(ir.parseGPSRefChar(t))
+		case gpsifd.GPSTrack:
+			ir.Exif.GPS.Rationals.Track = ir.ParseRational(t)
+			ir.Exif.GPS.Track = float32(ir.Exif.GPS.Rationals.Track.Float())
+		case gpsifd.GPSImgDirectionRef:
+			ir.Exif.GPS.ImgDirectionRef = meta.GPSDirectionRef(ir.parseGPSRefChar(t))
+		case gpsifd.GPSImgDirection:
+			ir.Exif.GPS.Rationals.ImgDirection = ir.ParseRational(t)
+			ir.Exif.GPS.ImgDirection = float32(ir.Exif.GPS.Rationals.ImgDirection.Float())
+		case gpsifd.GPSMapDatum:
+			ir.Exif.GPS.MapDatum = ir.ParseString(t)
+		case gpsifd.GPSDestBearingRef:
+			ir.Exif.GPS.DestBearingRef = meta.GPSDirectionRef(ir.parseGPSRefChar(t))
+		case gpsifd.GPSDestBearing:
+			ir.Exif.GPS.Rationals.DestBearing = ir.ParseRational(t)
+			ir.Exif.GPS.DestBearing = float32(ir.Exif.GPS.Rationals.DestBearing.Float())
+		case gpsifd.GPSDestDistanceRef:
+			ir.Exif.GPS.DestDistanceRef = meta.GPSDistanceRef(ir.parseGPSRefChar(t))
+		case gpsifd.GPSDestDistance:
+			ir.Exif.GPS.Rationals.DestDistance = ir.ParseRational(t)
+			ir.Exif.GPS.DestDistance = float32(ir.Exif.GPS.Rationals.DestDistance.Float())
+		case gpsifd.GPSProcessingMethod:
+			ir.Exif.GPS.ProcessingMethodCharset, ir.Exif.GPS.ProcessingMethod = ir.parseCharacterCodeString(t)
+		case gpsifd.GPSDifferential:
+			ir.Exif.GPS.Differential = ir.ParseUint16(t) == 1
+		case gpsifd.GPSHPositioningError:
+			ir.Exif.GPS.Rationals.HPositioningError = ir.ParseRational(t)
+			ir.Exif.GPS.HPositioningError = float32(ir.Exif.GPS.Rationals.HPositioningError.Float())
 		default:
 			//t.logTag(ir.logWarn()).Send()
 		}
@@ -202,58 +381,26 @@ func (ir *ifdReader) ParseCameraModel(t Tag) (ifds.CameraModel, string) {
 //	return res2
 //}
 
-func (ir *ifdReader) parseAperture(t Tag) meta.Aperture {
-	if t.IsType(tag.TypeRational) || t.IsType(tag.TypeSignedRational) {
-		r := ir.ParseRationalU(t)
-		return meta.Aperture(float32(r[0]) / float32(r[1]))
-	}
-	if ir.logLevelWarn() {
-		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
-	}
-	return 0.0
-}
-func (ir *ifdReader) parseExposureTime(t Tag) meta.ExposureTime {
-	if t.IsType(tag.TypeRational) || t.IsType(tag.TypeSignedRational) {
-		r := ir.ParseRationalU(t)
-		return meta.ExposureTime(float32(r[0]) / float32(r[1]))
-	}
-	if ir.logLevelWarn() {
-		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
-	}
-	return 0.0
-}
-
-func (ir *ifdReader) parseExposureBias(t Tag) meta.ExposureBias {
-	if !t.IsEmbedded() {
-		r := ir.ParseRationalU(t)
-		return meta.NewExposureBias(int16(r[0]), int16(r[1]))
-	}
-	if ir.logLevelWarn() {
-		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
-	}
-	return meta.NewExposureBias(0, 0)
-}
-
-// parseFocalLength supports tag type Rational, SRational, Short, and Long
-func (ir *ifdReader) parseFocalLength(t Tag) meta.FocalLength {
+// parseFocalLength parses a FocalLength as a Rational.
+// Supports tag type Rational, SRational, Short, and Long
+func (ir *ifdReader) parseFocalLength(t Tag) meta.Rational {
 	switch t.Type {
 	case tag.TypeShort, tag.TypeLong:
-		return meta.NewFocalLength(ir.ParseUint32(t), 1)
+		return meta.NewRational(ir.ParseUint32(t), 1)
 	case tag.TypeRational, tag.TypeSignedRational:
-		val := ir.ParseRationalU(t)
-		return meta.FocalLength(float32(val[0]) / float32(val[1]))
+		return ir.ParseRational(t)
 	}
 	if ir.logLevelWarn() {
 		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
 	}
-	return meta.FocalLength(0)
+	return meta.Rational{}
 }
 
 // ParseSubSecTime parses an ASCII or ASCII no Nul.
 // Embedded tag with value length 4 bytes.
 // Value is in milliseconds.
 func (ir *ifdReader) ParseSubSecTime(t Tag) uint16 {
-	if t.IsType(tag.TypeASCII) || t.IsType(tag.TypeASCIINoNul) {
+	if t.Type.IsString() {
 		if t.IsEmbedded() {
 			t.EmbeddedValue(ir.buffer.buf[:4])
 			return uint16(parseStrUint(ir.buffer.buf[:4]))
@@ -282,6 +429,82 @@ func (ir *ifdReader) parseLensInfo(t Tag) LensInfo {
 	return LensInfo{}
 }
 
+// parseThumbnailTag parses the tags of IFD1, the thumbnail Ifd.
+func (ir *ifdReader) parseThumbnailTag(t Tag) {
+	switch t.ID {
+	case ifds.Compression:
+		ir.Exif.Thumbnail.Compression = meta.Compression(ir.ParseUint16(t))
+	case ifds.ImageWidth:
+		ir.Exif.Thumbnail.Width = ir.ParseUint32(t)
+	case ifds.ImageLength:
+		ir.Exif.Thumbnail.Height = ir.ParseUint32(t)
+	case ifds.XResolution:
+		ir.Exif.Thumbnail.XResolution = ir.ParseRational(t)
+	case ifds.YResolution:
+		ir.Exif.Thumbnail.YResolution = ir.ParseRational(t)
+	case ifds.ResolutionUnit:
+		ir.Exif.Thumbnail.ResolutionUnit = ir.ParseUint16(t)
+	case ifds.Orientation:
+		ir.Exif.Thumbnail.Orientation = meta.Orientation(ir.ParseUint16(t))
+	case ifds.JPEGInterchangeFormat:
+		ir.Exif.Thumbnail.Offset = ir.ParseUint32(t)
+		ir.Exif.ThumbnailOffset = ir.Exif.Thumbnail.Offset
+	case ifds.JPEGInterchangeFormatLength:
+		ir.Exif.Thumbnail.Length = ir.ParseUint32(t)
+		ir.Exif.ThumbnailLength = ir.Exif.Thumbnail.Length
+	}
+}
+
+// parseRationalFloat parses a Rational or SRational value as a float32.
+// Returns 0 if the denominator is 0.
+func (ir *ifdReader) parseRationalFloat(t Tag) float32 {
+	r := ir.ParseRationalU(t)
+	if r[1] == 0 {
+		return 0
+	}
+	if t.IsType(tag.TypeSignedRational) {
+		return float32(int32(r[0])) / float32(int32(r[1]))
+	}
+	return float32(r[0]) / float32(r[1])
+}
+
+// parseUint8 parses a BYTE or UNDEFINED value.
+// Embedded tag with value length 1 byte.
+func (ir *ifdReader) parseUint8(t Tag) uint8 {
+	if t.IsEmbedded() && (t.IsType(tag.TypeByte) || t.IsType(tag.TypeUndefined)) {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		return ir.buffer.buf[0]
+	}
+	if ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+	}
+	return 0
+}
+
+// parseTimeZoneOffset parses the TimeZoneOffset (0x882a). The first value
+// is the time zone offset of DateTimeOriginal in hours and the optional
+// second value is the time zone offset of ModifyDate.
+// Embedded tag with 1 or 2 SShort values.
+func (ir *ifdReader) parseTimeZoneOffset(t Tag) (original *time.Location, modify *time.Location) {
+	if t.IsEmbedded() && t.IsType(tag.TypeSignedShort) {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		for i := 0; i < int(t.UnitCount); i++ {
+			hours := int16(t.ByteOrder.Uint16(ir.buffer.buf[2*i:]))
+			l := getLocation(int32(hours)*hoursToSeconds, []byte(fmt.Sprintf("%+03d:00", hours)))
+			if i == 0 {
+				original = l
+			} else {
+				modify = l
+			}
+		}
+		return original, modify
+	}
+	if ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+	}
+	return nil, nil
+}
+
 // ParseRationalU parses an Unsigned Rational value.
 // Non-embedded tag with value length 8 bytes.
 func (ir *ifdReader) ParseRationalU(t Tag) [2]uint32 {
@@ -300,6 +523,44 @@ func (ir *ifdReader) ParseRationalU(t Tag) [2]uint32 {
 	return [2]uint32{}
 }
 
+// ParseRational parses a Rational value.
+// Non-embedded tag with value length 8 bytes.
+func (ir *ifdReader) ParseRational(t Tag) meta.Rational {
+	r := ir.ParseRationalU(t)
+	return meta.NewRational(r[0], r[1])
+}
+
+// ParseSRational parses a Signed Rational value.
+// Non-embedded tag with value length 8 bytes.
+func (ir *ifdReader) ParseSRational(t Tag) meta.SRational {
+	r := ir.ParseRationalU(t)
+	return meta.NewSRational(int32(r[0]), int32(r[1]))
+}
+
+// parseRationals parses Rational or SRational values into r and returns the
+// number of values parsed.
+// Non-embedded tag with value length 8 bytes per value.
+func (ir *ifdReader) parseRationals(t Tag, r []meta.Rational) int {
+	if !(t.IsType(tag.TypeRational) || t.IsType(tag.TypeSignedRational)) { // Some cameras write tag out of spec using signed rational. We accept that too.
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+		}
+		return 0
+	}
+	buf, err := ir.readTagValue()
+	if err != nil {
+		return 0
+	}
+	n := int(t.UnitCount)
+	if n > len(r) {
+		n = len(r)
+	}
+	for i := 0; i < n; i++ {
+		r[i] = meta.NewRational(t.ByteOrder.Uint32(buf[8*i:]), t.ByteOrder.Uint32(buf[8*i+4:]))
+	}
+	return n
+}
+
 // ParseUint32 parses a Uint32 value.
 // Embedded tag with value length 4 bytes.
 func (ir *ifdReader) ParseUint32(t Tag) uint32 {
@@ -317,6 +578,48 @@ func (ir *ifdReader) ParseUint32(t Tag) uint32 {
 	return 0
 }
 
+// parsePanasonicRawTag parses the Panasonic specific tags of a RW2 IFD0.
+// The exposure information is found in the Exif of the JpgFromRaw.
+func (ir *ifdReader) parsePanasonicRawTag(t Tag) {
+	rw2 := &ir.Exif.RW2
+	switch t.ID {
+	case rw2ifd.ISO:
+		if ir.Exif.ISOSpeed == 0 {
+			ir.Exif.ISOSpeed = ir.ParseUint32(t)
+		}
+	case rw2ifd.SensorWidth:
+		rw2.SensorWidth = ir.ParseUint16(t)
+	case rw2ifd.SensorHeight:
+		rw2.SensorHeight = ir.ParseUint16(t)
+	case rw2ifd.SensorTopBorder:
+		rw2.SensorTopBorder = ir.ParseUint16(t)
+	case rw2ifd.SensorLeftBorder:
+		rw2.SensorLeftBorder = ir.ParseUint16(t)
+	case rw2ifd.SensorBottomBorder:
+		rw2.SensorBottomBorder = ir.ParseUint16(t)
+	case rw2ifd.SensorRightBorder:
+		rw2.SensorRightBorder = ir.ParseUint16(t)
+	case rw2ifd.CFAPattern:
+		if v := ir.ParseUint16(t); int(v) < len(rw2CFAPatterns) {
+			rw2.CFAPattern = rw2CFAPatterns[v]
+		}
+	case rw2ifd.BitsPerSample:
+		rw2.BitsPerSample = ir.ParseUint16(t)
+	case rw2ifd.Compression:
+		rw2.Compression = ir.ParseUint16(t)
+	case rw2ifd.BlackLevelRed, rw2ifd.BlackLevelGreen, rw2ifd.BlackLevelBlue:
+		rw2.BlackLevel[t.ID-rw2ifd.BlackLevelRed] = ir.ParseUint16(t)
+	case rw2ifd.WBRedLevel, rw2ifd.WBGreenLevel, rw2ifd.WBBlueLevel:
+		rw2.WBLevels[t.ID-rw2ifd.WBRedLevel] = ir.ParseUint16(t)
+	case rw2ifd.RawDataOffset:
+		rw2.RawDataOffset = ir.ParseUint32(t)
+	case rw2ifd.CameraIFD:
+		ir.readCameraIFD(t)
+	case rw2ifd.JpgFromRaw:
+		ir.readJpgFromRaw(t)
+	}
+}
+
 // ParseUint16 parses a uint16 value.
 // Embedded tag with value length 2 bytes.
 func (ir *ifdReader) ParseUint16(t Tag) uint16 {
@@ -330,17 +633,17 @@ func (ir *ifdReader) ParseUint16(t Tag) uint16 {
 	return 0
 }
 
-// ParseString parses an ASCII value.
+// ParseString parses an ASCII or UTF8 value.
 // Non-embedded or embedded tag with variable byte length.
 // This function allocates.
 func (ir *ifdReader) ParseString(t Tag) string {
 	if t.IsEmbedded() {
 		t.EmbeddedValue(ir.buffer.buf[:4])
-		return string(trimNULBuffer(ir.buffer.buf[:t.Size()]))
+		return decodeString(trimNULBuffer(ir.buffer.buf[:t.Size()]))
 	}
-	if t.IsType(tag.TypeASCII) || t.IsType(tag.TypeASCIINoNul) {
+	if t.Type.IsString() {
 		buf, _ := ir.readTagValue()
-		return string(trimNULBuffer(buf)) // Trim function
+		return decodeString(trimNULBuffer(buf)) // Trim function
 	}
 	if ir.logLevelWarn() {
 		t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
@@ -356,7 +659,7 @@ func (ir *ifdReader) ParseBuffer(t Tag) []byte {
 		t.EmbeddedValue(ir.buffer.buf[:4])
 		return trimNULBuffer(ir.buffer.buf[:t.Size()])
 	}
-	if t.IsType(tag.TypeASCII) || t.IsType(tag.TypeASCIINoNul) {
+	if t.Type.IsString() {
 		buf, err := ir.readTagValue()
 		if err != nil {
 			return nil
@@ -431,18 +734,9 @@ func (ir *ifdReader) ParseOffsetTime(t Tag) *time.Location {
 
 // ParseGPSCoord parses the GPS Coordinate (Lat or Lng) from the corresponding Tag.
 func (ir *ifdReader) ParseGPSCoord(t Tag) float64 {
-	if t.UnitCount == 3 {
-		switch t.Type {
-		case tag.TypeRational, tag.TypeSignedRational: // Some cameras write tag out of spec using signed rational. We accept that too.
-			buf, err := ir.readTagValue()
-			if err != nil {
-				return 0.0
-			}
-			coord := (float64(t.ByteOrder.Uint32(buf[:4])) / float64(t.ByteOrder.Uint32(buf[4:8])))
-			coord += (float64(t.ByteOrder.Uint32(buf[8:12])) / float64(t.ByteOrder.Uint32(buf[12:16])) / 60.0)
-			coord += (float64(t.ByteOrder.Uint32(buf[16:20])) / float64(t.ByteOrder.Uint32(buf[20:24])) / 3600.0)
-			return coord
-		}
+	var r [3]meta.Rational
+	if t.UnitCount == 3 && ir.parseRationals(t, r[:]) == 3 {
+		return gpsCoord(r)
 	}
 	if ir.logLevelWarn() {
 		t.logTag(ir.logWarn()).Msg("error reading GPS Coord. Tag is not Rational or SRational")
@@ -450,6 +744,12 @@ func (ir *ifdReader) ParseGPSCoord(t Tag) float64 {
 	return 0.0
 }
 
+// gpsCoord returns the GPS Coordinate in degrees from degrees, minutes and
+// seconds.
+func gpsCoord(r [3]meta.Rational) float64 {
+	return r[0].Float() + r[1].Float()/60.0 + r[2].Float()/3600.0
+}
+
 // ParseGPSAltitude parses the GPS Altitude from the corresponding Tag.
 func (ir *ifdReader) ParseGPSAltitude(t Tag) float32 {
 	if t.UnitCount == 1 {
@@ -468,36 +768,19 @@ func (ir *ifdReader) ParseGPSAltitude(t Tag) float32 {
 	return 0.0
 }
 
-// parseGPSTimeStamp parses the GPSTimeStamp tag in UTC.
-func (ir *ifdReader) parseGPSTimeStamp(t Tag) uint32 {
-	if t.UnitCount == 3 && t.Type == tag.TypeRational {
-		buf, err := ir.readTagValue()
-		if err != nil {
-			return 0
-		}
-		var result uint32
-		value := [6]uint32{
-			t.ByteOrder.Uint32(buf[:4]),
-			t.ByteOrder.Uint32(buf[4:8]),
-			t.ByteOrder.Uint32(buf[8:12]),
-			t.ByteOrder.Uint32(buf[12:16]),
-			t.ByteOrder.Uint32(buf[16:20]),
-			t.ByteOrder.Uint32(buf[20:24])}
-		if value[1] > 0 {
-			result += (value[0] / value[1]) * hoursToSeconds
-		}
-		if value[3] > 0 {
-			result += (value[2] / value[3]) * minutesToSeconds
-		}
-		if value[5] > 0 {
-			result += (value[4] / value[5])
-		}
-		return result
+// gpsTimeStamp returns the GPSTimeStamp in seconds from hours, minutes and
+// seconds in UTC.
+func gpsTimeStamp(r [3]meta.Rational) (result uint32) {
+	if r[0].Denominator > 0 {
+		result += (r[0].Numerator / r[0].Denominator) * hoursToSeconds
 	}
-	if ir.logLevelWarn() {
-		t.logTag(ir.logWarn()).Msg("error reading GPS Time Stamp")
+	if r[1].Denominator > 0 {
+		result += (r[1].Numerator / r[1].Denominator) * minutesToSeconds
 	}
-	return 0
+	if r[2].Denominator > 0 {
+		result += (r[2].Numerator / r[2].Denominator)
+	}
+	return result
 }
 
 // parseGPSDateStamp parses a GPSDateStamp from the tag
@@ -549,6 +832,57 @@ func (ir *ifdReader) ParseGPSRef(t Tag) bool {
 	return false
 }
 
+// parseGPSRefChar parses a single character GPS reference such as
+// GPSSpeedRef or GPSMeasureMode. Embedded ASCII tag.
+func (ir *ifdReader) parseGPSRefChar(t Tag) uint8 {
+	if t.IsEmbedded() && t.IsType(tag.TypeASCII) {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		return ir.buffer.buf[0]
+	}
+	if ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Msg("error reading GPS Reference")
+	}
+	return 0
+}
+
+// parseCharacterCodeString parses an UNDEFINED value with an 8 byte
+// character code prefix, ex: GPSProcessingMethod.
+// This function allocates.
+func (ir *ifdReader) parseCharacterCodeString(t Tag) (meta.CharacterCode, string) {
+	var buf []byte
+	if t.IsEmbedded() {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		buf = ir.buffer.buf[:t.Size()]
+	} else {
+		var err error
+		if buf, err = ir.readTagValue(); err != nil {
+			return meta.CharacterCodeUndefined, ""
+		}
+	}
+	cc, val := meta.NewCharacterCode(buf)
+	return cc, decodeCharacterCode(cc, val, t.ByteOrder)
+}
+
+// parseXPString parses the BYTE value of a Windows XP tag, ex: XPTitle.
+// This function allocates.
+func (ir *ifdReader) parseXPString(t Tag) string {
+	if !t.IsType(tag.TypeByte) && !t.IsType(tag.TypeUndefined) {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+		}
+		return ""
+	}
+	if t.IsEmbedded() {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		return decodeXPString(ir.buffer.buf[:t.Size()])
+	}
+	buf, err := ir.readTagValue()
+	if err != nil {
+		return ""
+	}
+	return decodeXPString(buf)
+}
+
 // TagParser interface is used for Custom Tag Parsers.
 type TagParser interface {
 	ParseCameraMake(t Tag) (ifds.CameraMake, string)
@@ -557,6 +891,8 @@ type TagParser interface {
 	ParseGPSAltitude(t Tag) float32
 	ParseGPSCoord(t Tag) float64
 	ParseRationalU(t Tag) [2]uint32
+	ParseRational(t Tag) meta.Rational
+	ParseSRational(t Tag) meta.SRational
 	ParseString(t Tag) string
 	ParseSubSecTime(t Tag) uint16
 	ParseUint32(t Tag) uint32
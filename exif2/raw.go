@@ -0,0 +1,312 @@
+package exif2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/meta"
+)
+
+// rawIfdCount is the number of Ifds that can hold the raw image: IFD0 and
+// SubIfd0-7.
+const rawIfdCount = 9
+
+// RawInfo is the raw image of a raw file. It is the IFD0 or SubIfd with
+// NewSubfileType 0 that holds the sensor data. The zero value is returned
+// when the raw image Ifd is not found.
+type RawInfo struct {
+	StripOffsets        []uint32                  // 0x0111
+	StripByteCounts     []uint32                  // 0x0117
+	TileOffsets         []uint32                  // 0x0144
+	TileByteCounts      []uint32                  // 0x0145
+	BlackLevel          []float32                 // 0xc61a
+	WhiteLevel          []uint32                  // 0xc61d
+	CFAPattern          string                    // 0x828e as colours ex: "RGGB"
+	Width               uint32                    // 0x0100
+	Height              uint32                    // 0x0101
+	RowsPerStrip        uint32                    // 0x0116
+	TileWidth           uint32                    // 0x0142
+	TileLength          uint32                    // 0x0143
+	DataOffset          uint32                    // Offset of the raw image data from the Tiff header
+	DataLength          uint32                    // Length of the raw image data
+	SubfileType         uint32                    // 0x00fe
+	Ifd                 ifds.IfdType              // IFD0 or SubIfd0-7
+	BitsPerSample       uint16                    // 0x0102
+	SamplesPerPixel     uint16                    // 0x0115
+	CFARepeatPatternDim [2]uint16                 // 0x828d
+	Compression         meta.Compression          // 0x0103
+	Photometric         PhotometricInterpretation // 0x0106
+}
+
+// IsTiled returns true if the raw image data is stored in tiles
+func (ri RawInfo) IsTiled() bool {
+	return len(ri.TileOffsets) > 0
+}
+
+// RW2Info is the sensor information of the Panasonic specific IFD0 tags of
+// a RW2 image. Black levels and white balance levels are Red, Green, Blue.
+type RW2Info struct {
+	CameraIFD          []RawTag  // 0x0120 tags of the CameraIFD
+	BlackLevel         [3]uint16 // 0x001c-0x001e
+	WBLevels           [3]uint16 // 0x0024-0x0026
+	CFAPattern         string    // 0x0009 as colours ex: "RGGB"
+	RawDataOffset      uint32    // 0x0118
+	SensorWidth        uint16    // 0x0002
+	SensorHeight       uint16    // 0x0003
+	SensorTopBorder    uint16    // 0x0004
+	SensorLeftBorder   uint16    // 0x0005
+	SensorBottomBorder uint16    // 0x0006
+	SensorRightBorder  uint16    // 0x0007
+	BitsPerSample      uint16    // 0x000a
+	Compression        uint16    // 0x000b
+}
+
+// Width returns the width of the sensor area within the borders
+func (ri RW2Info) Width() uint16 {
+	if ri.SensorRightBorder < ri.SensorLeftBorder {
+		return 0
+	}
+	return ri.SensorRightBorder - ri.SensorLeftBorder
+}
+
+// Height returns the height of the sensor area within the borders
+func (ri RW2Info) Height() uint16 {
+	if ri.SensorBottomBorder < ri.SensorTopBorder {
+		return 0
+	}
+	return ri.SensorBottomBorder - ri.SensorTopBorder
+}
+
+// rw2CFAPatterns are the colours of the RW2 CFAPattern values 1-4
+var rw2CFAPatterns = [...]string{"", "RGGB", "GRBG", "GBRG", "BGGR"}
+
+// PhotometricInterpretation is the colour space of image data
+type PhotometricInterpretation uint16
+
+// PhotometricInterpretation values
+const (
+	PhotometricWhiteIsZero      PhotometricInterpretation = 0
+	PhotometricBlackIsZero      PhotometricInterpretation = 1
+	PhotometricRGB              PhotometricInterpretation = 2
+	PhotometricPalette          PhotometricInterpretation = 3
+	PhotometricTransparencyMask PhotometricInterpretation = 4
+	PhotometricCMYK             PhotometricInterpretation = 5
+	PhotometricYCbCr            PhotometricInterpretation = 6
+	PhotometricCIELab           PhotometricInterpretation = 8
+	PhotometricCFA              PhotometricInterpretation = 32803
+	PhotometricLinearRaw        PhotometricInterpretation = 34892
+)
+
+// String returns a PhotometricInterpretation as a string
+func (pi PhotometricInterpretation) String() string {
+	switch pi {
+	case PhotometricWhiteIsZero:
+		return "WhiteIsZero"
+	case PhotometricBlackIsZero:
+		return "BlackIsZero"
+	case PhotometricRGB:
+		return "RGB"
+	case PhotometricPalette:
+		return "RGB Palette"
+	case PhotometricTransparencyMask:
+		return "Transparency Mask"
+	case PhotometricCMYK:
+		return "CMYK"
+	case PhotometricYCbCr:
+		return "YCbCr"
+	case PhotometricCIELab:
+		return "CIELab"
+	case PhotometricCFA:
+		return "Color Filter Array"
+	case PhotometricLinearRaw:
+		return "Linear Raw"
+	}
+	return fmt.Sprintf("Unknown (%d)", pi)
+}
+
+// IsRaw returns true for the Color Filter Array and Linear Raw
+// PhotometricInterpretations of raw image data
+func (pi PhotometricInterpretation) IsRaw() bool {
+	return pi == PhotometricCFA || pi == PhotometricLinearRaw
+}
+
+// cfaColors are the colours of CFAPattern values
+const cfaColors = "RGBCMYW"
+
+// cfaPatternString returns the CFAPattern as colours. Unknown colours are "?".
+func cfaPatternString(buf []byte) string {
+	var sb strings.Builder
+	for _, c := range buf {
+		if int(c) < len(cfaColors) {
+			sb.WriteByte(cfaColors[c])
+		} else {
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// rawIfdIndex returns the index of the RawInfo candidate of the Ifd of t,
+// or -1 if the Ifd can not hold the raw image.
+func rawIfdIndex(t Tag) int {
+	switch ifds.IfdType(t.Ifd) {
+	case ifds.IFD0:
+		if t.IfdIndex == 0 {
+			return 0
+		}
+	case ifds.SubIfd0, ifds.SubIfd1, ifds.SubIfd2, ifds.SubIfd3, ifds.SubIfd4, ifds.SubIfd5, ifds.SubIfd6, ifds.SubIfd7:
+		return 1 + int(t.Ifd-ifds.SubIfd0)
+	}
+	return -1
+}
+
+// parseRawTag parses the image data tags of IFD0 and the SubIfds into the
+// RawInfo candidate of the Ifd.
+func (ir *ifdReader) parseRawTag(t Tag) {
+	i := rawIfdIndex(t)
+	if i < 0 {
+		return
+	}
+	ri := &ir.raw[i]
+	ri.Ifd = ifds.IfdType(t.Ifd)
+	switch t.ID {
+	case ifds.NewSubfileType:
+		ri.SubfileType = ir.ParseUint32(t)
+	case ifds.ImageWidth:
+		ri.Width = ir.ParseUint32(t)
+	case ifds.ImageLength:
+		ri.Height = ir.ParseUint32(t)
+	case ifds.BitsPerSample:
+		if v := ir.parseUint32s(t); len(v) > 0 {
+			ri.BitsPerSample = uint16(v[0])
+		}
+	case ifds.Compression:
+		ri.Compression = meta.Compression(ir.ParseUint16(t))
+	case ifds.PhotometricInterpretation:
+		ri.Photometric = PhotometricInterpretation(ir.ParseUint16(t))
+	case ifds.SamplesPerPixel:
+		ri.SamplesPerPixel = ir.ParseUint16(t)
+	case ifds.RowsPerStrip:
+		ri.RowsPerStrip = ir.ParseUint32(t)
+	case ifds.StripOffsets:
+		ri.StripOffsets = ir.parseUint32s(t)
+	case ifds.StripByteCounts:
+		ri.StripByteCounts = ir.parseUint32s(t)
+	case ifds.TileWidth:
+		ri.TileWidth = ir.ParseUint32(t)
+	case ifds.TileLength:
+		ri.TileLength = ir.ParseUint32(t)
+	case ifds.TileOffsets:
+		ri.TileOffsets = ir.parseUint32s(t)
+	case ifds.TileByteCounts:
+		ri.TileByteCounts = ir.parseUint32s(t)
+	case ifds.CFARepeatPatternDim:
+		if v := ir.parseUint32s(t); len(v) == 2 {
+			ri.CFARepeatPatternDim = [2]uint16{uint16(v[0]), uint16(v[1])}
+		}
+	case ifds.CFAPattern:
+		ri.CFAPattern = ir.parseCFAPattern(t)
+	case ifds.BlackLevel:
+		ri.BlackLevel = ir.parseFloats(t)
+	case ifds.WhiteLevel:
+		ri.WhiteLevel = ir.parseUint32s(t)
+	}
+}
+
+// rawInfo returns the RawInfo of the raw image Ifd. Candidates must have
+// NewSubfileType 0, image dimensions and image data. A Color Filter Array or
+// Linear Raw candidate is preferred, other candidates that are not JPEG
+// compressed previews are accepted. The largest candidate is returned.
+func (ir *ifdReader) rawInfo() (raw RawInfo) {
+	var rawArea uint64
+	for _, ri := range ir.raw {
+		if ri.SubfileType != 0 || ri.Width == 0 || ri.Height == 0 || (len(ri.StripOffsets) == 0 && len(ri.TileOffsets) == 0) {
+			continue
+		}
+		if !ri.Photometric.IsRaw() {
+			if raw.Photometric.IsRaw() {
+				continue
+			}
+			switch ri.Compression {
+			case 6, 7, 99: // JPEG
+				continue
+			}
+		}
+		area := uint64(ri.Width) * uint64(ri.Height)
+		if (ri.Photometric.IsRaw() && !raw.Photometric.IsRaw()) || area > rawArea {
+			raw, rawArea = ri, area
+		}
+	}
+	offsets, counts := raw.StripOffsets, raw.StripByteCounts
+	if raw.IsTiled() {
+		offsets, counts = raw.TileOffsets, raw.TileByteCounts
+	}
+	if len(offsets) == 0 || len(offsets) != len(counts) {
+		return raw
+	}
+	start, end := offsets[0], offsets[0]
+	for i, o := range offsets {
+		if o < start {
+			start = o
+		}
+		if o+counts[i] > end {
+			end = o + counts[i]
+		}
+	}
+	raw.DataOffset, raw.DataLength = start, end-start
+	return raw
+}
+
+// parseUint32s parses SHORT or LONG values as uint32.
+// This function allocates.
+func (ir *ifdReader) parseUint32s(t Tag) []uint32 {
+	if !(t.IsType(tag.TypeShort) || t.IsType(tag.TypeLong)) || t.Size() > rawTagMaxLength {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+		}
+		return nil
+	}
+	var buf []byte
+	if t.IsEmbedded() {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		buf = ir.buffer.buf[:t.Size()]
+	} else {
+		var err error
+		if buf, err = ir.readValue(t); err != nil {
+			t.logTag(ir.logError(err)).Send()
+			return nil
+		}
+	}
+	v := make([]uint32, t.UnitCount)
+	for i := range v {
+		if t.IsType(tag.TypeShort) {
+			v[i] = uint32(t.ByteOrder.Uint16(buf[2*i:]))
+		} else {
+			v[i] = t.ByteOrder.Uint32(buf[4*i:])
+		}
+	}
+	return v
+}
+
+// parseCFAPattern parses a CFAPattern as colours.
+// Embedded or non-embedded BYTE values.
+func (ir *ifdReader) parseCFAPattern(t Tag) string {
+	if !(t.IsType(tag.TypeByte) || t.IsType(tag.TypeUndefined)) || t.Size() > 64 {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+		}
+		return ""
+	}
+	if t.IsEmbedded() {
+		t.EmbeddedValue(ir.buffer.buf[:4])
+		return cfaPatternString(ir.buffer.buf[:t.Size()])
+	}
+	buf, err := ir.readTagValue()
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return ""
+	}
+	return cfaPatternString(buf)
+}
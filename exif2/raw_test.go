@@ -0,0 +1,60 @@
+package exif2
+
+import (
+	"os"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+)
+
+func TestParseRawInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifd         ifds.IfdType
+		width       uint32
+		height      uint32
+		bits        uint16
+		cfa         string
+		dataOffset  uint32
+		dataLength  uint32
+		tiled       bool
+		photometric PhotometricInterpretation
+	}{
+		{"ARW.exif", ifds.SubIfd0, 4928, 3280, 12, "RGGB", 1310720, 16163840, false, PhotometricCFA},
+		{"NEF.exif", ifds.SubIfd1, 6036, 4020, 14, "RGGB", 3477504, 25365397, false, PhotometricCFA},
+		{"Hero8.GPR", ifds.IFD0, 4000, 3000, 16, "RGGB", 14792, 4058584, true, PhotometricCFA},
+		{"CR2.exif", ifds.NullIFD, 0, 0, 0, "", 0, 0, false, 0},
+	}
+	for _, wr := range tests {
+		t.Run(wr.name, func(t *testing.T) {
+			f, err := os.Open("../testImages/" + wr.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			e, err := Parse(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := e.Raw
+			if r.Ifd != wr.ifd || r.Width != wr.width || r.Height != wr.height || r.BitsPerSample != wr.bits {
+				t.Errorf("Incorrect RawInfo wanted %s %dx%d %d got %s %dx%d %d", wr.ifd, wr.width, wr.height, wr.bits, r.Ifd, r.Width, r.Height, r.BitsPerSample)
+			}
+			if r.Photometric != wr.photometric || r.CFAPattern != wr.cfa {
+				t.Errorf("Incorrect Photometric and CFAPattern wanted %s %s got %s %s", wr.photometric, wr.cfa, r.Photometric, r.CFAPattern)
+			}
+			if r.DataOffset != wr.dataOffset || r.DataLength != wr.dataLength || r.IsTiled() != wr.tiled {
+				t.Errorf("Incorrect raw data wanted %d %d got %d %d", wr.dataOffset, wr.dataLength, r.DataOffset, r.DataLength)
+			}
+		})
+	}
+}
+
+func TestCFAPatternString(t *testing.T) {
+	if s := cfaPatternString([]byte{1, 0, 2, 1}); s != "GRBG" {
+		t.Errorf("Incorrect CFAPattern wanted %s got %s", "GRBG", s)
+	}
+	if s := cfaPatternString([]byte{3, 5, 4, 9}); s != "CYM?" {
+		t.Errorf("Incorrect CFAPattern wanted %s got %s", "CYM?", s)
+	}
+}
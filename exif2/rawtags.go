@@ -0,0 +1,457 @@
+package exif2
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
+
+// rawTagMaxLength is the maximum length of a tag value that is retained.
+// Larger values are retained without a value.
+const rawTagMaxLength = 64 * 1024
+
+// ParseAllTags parses Exif from r like Parse and retains every tag that is
+// read in Exif.Ifds.
+func ParseAllTags(r io.ReadSeeker) (Exif, error) {
+	return parse(r, true)
+}
+
+// SetRetainTags sets the ifdReader to retain every tag that is read in
+// Exif.Ifds, in addition to the parsed Exif fields.
+func (ir *ifdReader) SetRetainTags(retain bool) {
+	ir.retainTags = retain
+}
+
+// retainTag adds the tag and its value to ir.Exif.Ifds. Values that are not
+// embedded are read and cached so that they remain available to parseTag.
+func (ir *ifdReader) retainTag(t Tag) {
+	rt := RawTag{ID: t.ID, Name: t.Name(), Type: t.Type, Count: t.UnitCount, ByteOrder: t.ByteOrder}
+	if t.IsEmbedded() {
+		var buf [4]byte
+		t.EmbeddedValue(buf[:])
+		rt.Raw = append([]byte(nil), buf[:t.Size()]...)
+	} else if t.Size() <= rawTagMaxLength && t.ValueOffset >= ir.po {
+		buf, err := ir.readValue(t)
+		if err != nil {
+			if ir.logLevelWarn() {
+				t.logTag(ir.logWarn()).Err(err).Msg("retain tag")
+			}
+			return
+		}
+		rt.Raw = buf
+	}
+	rt.Val = decodeRawValue(rt.Type, rt.ByteOrder, rt.Raw)
+
+	ifdType, index := t.Ifd, t.IfdIndex
+	if ifdType >= ifds.SubIfd0 && ifdType <= ifds.SubIfd7 {
+		ifdType, index = ifds.SubIFD, int8(ifdType-ifds.SubIfd0)
+	}
+	ir.Exif.Ifds.add(ifdType, index, rt)
+}
+
+// RawIfds are the retained tags grouped by IfdType and Ifd index.
+// SubIfds are retained as ifds.SubIFD with the SubIfd index.
+type RawIfds map[ifds.IfdType]map[int8]RawIfd
+
+// RawIfd is the retained tags of an Ifd
+type RawIfd struct {
+	Tags []RawTag
+}
+
+func (ri *RawIfds) add(ifdType ifds.IfdType, index int8, rt RawTag) {
+	if *ri == nil {
+		*ri = RawIfds{}
+	}
+	m := (*ri)[ifdType]
+	if m == nil {
+		m = map[int8]RawIfd{}
+		(*ri)[ifdType] = m
+	}
+	ifd := m[index]
+	ifd.Tags = append(ifd.Tags, rt)
+	m[index] = ifd
+}
+
+// Tag returns the retained tag with the given IfdType, Ifd index and tag.ID
+func (ri RawIfds) Tag(ifdType ifds.IfdType, index int8, id tag.ID) (RawTag, bool) {
+	for _, rt := range ri[ifdType][index].Tags {
+		if rt.ID == id {
+			return rt, true
+		}
+	}
+	return RawTag{}, false
+}
+
+// RawTag is a retained tag with its raw and decoded value.
+//
+// Val is the decoded value by Type:
+//
+//	BYTE, UNDEFINED: []byte
+//	ASCII: string
+//	SHORT: uint16 or []uint16
+//	LONG: uint32 or []uint32
+//	SSHORT: int16 or []int16
+//	SLONG: int32 or []int32
+//	RATIONAL: []Rational
+//	SRATIONAL: []SRational
+//	FLOAT: float32 or []float32
+//	DOUBLE: float64 or []float64
+//
+// Single values are used when Count is 1. Val and Raw are nil when
+// the value was not read.
+type RawTag struct {
+	Val       interface{}
+	Raw       []byte
+	Name      string
+	Count     uint32
+	ID        tag.ID
+	Type      tag.Type
+	ByteOrder utils.ByteOrder
+}
+
+// Rational is an unsigned rational value of a RawTag. It is marshalled to
+// JSON as an object, meta.Rational(r) has the text representation.
+type Rational meta.Rational
+
+// SRational is a signed rational value of a RawTag. It is marshalled to
+// JSON as an object, meta.SRational(r) has the text representation.
+type SRational meta.SRational
+
+// String returns the value of an ASCII RawTag
+func (rt RawTag) String() string {
+	s, _ := rt.Val.(string)
+	return s
+}
+
+// Bytes returns the value of a BYTE or UNDEFINED RawTag
+func (rt RawTag) Bytes() []byte {
+	b, _ := rt.Val.([]byte)
+	return b
+}
+
+// Ints returns the values of a BYTE, SHORT, LONG, SSHORT or SLONG RawTag
+// as a []int64. This function allocates.
+func (rt RawTag) Ints() (ints []int64) {
+	switch v := rt.Val.(type) {
+	case []byte:
+		if rt.Type != tag.TypeByte {
+			return nil
+		}
+		ints = make([]int64, len(v))
+		for i := range v {
+			ints[i] = int64(v[i])
+		}
+	case uint16:
+		ints = []int64{int64(v)}
+	case []uint16:
+		ints = make([]int64, len(v))
+		for i := range v {
+			ints[i] = int64(v[i])
+		}
+	case uint32:
+		ints = []int64{int64(v)}
+	case []uint32:
+		ints = make([]int64, len(v))
+		for i := range v {
+			ints[i] = int64(v[i])
+		}
+	case int16:
+		ints = []int64{int64(v)}
+	case []int16:
+		ints = make([]int64, len(v))
+		for i := range v {
+			ints[i] = int64(v[i])
+		}
+	case int32:
+		ints = []int64{int64(v)}
+	case []int32:
+		ints = make([]int64, len(v))
+		for i := range v {
+			ints[i] = int64(v[i])
+		}
+	}
+	return ints
+}
+
+// Int returns the first value of an integer RawTag
+func (rt RawTag) Int() int64 {
+	if v := rt.Ints(); len(v) > 0 {
+		return v[0]
+	}
+	return 0
+}
+
+// Rationals returns the values of a RATIONAL RawTag
+func (rt RawTag) Rationals() []Rational {
+	r, _ := rt.Val.([]Rational)
+	return r
+}
+
+// SRationals returns the values of a SRATIONAL RawTag
+func (rt RawTag) SRationals() []SRational {
+	r, _ := rt.Val.([]SRational)
+	return r
+}
+
+// Floats returns the values of a RATIONAL, SRATIONAL, FLOAT or DOUBLE RawTag,
+// or an integer RawTag, as a []float64. This function allocates.
+func (rt RawTag) Floats() []float64 {
+	switch v := rt.Val.(type) {
+	case []Rational:
+		f := make([]float64, len(v))
+		for i := range v {
+			f[i] = meta.Rational(v[i]).Float()
+		}
+		return f
+	case []SRational:
+		f := make([]float64, len(v))
+		for i := range v {
+			f[i] = meta.SRational(v[i]).Float()
+		}
+		return f
+	case float32:
+		return []float64{float64(v)}
+	case []float32:
+		f := make([]float64, len(v))
+		for i := range v {
+			f[i] = float64(v[i])
+		}
+		return f
+	case float64:
+		return []float64{v}
+	case []float64:
+		return v
+	}
+	ints := rt.Ints()
+	if ints == nil {
+		return nil
+	}
+	f := make([]float64, len(ints))
+	for i := range ints {
+		f[i] = float64(ints[i])
+	}
+	return f
+}
+
+// decodeRawValue decodes raw as tagType with byteOrder. Returns nil if raw is nil.
+func decodeRawValue(tagType tag.Type, byteOrder utils.ByteOrder, raw []byte) interface{} {
+	if raw == nil {
+		return nil
+	}
+	switch tagType {
+	case tag.TypeASCII, tag.TypeASCIINoNul, tag.TypeUTF8:
+		return string(mknote.TrimNUL(raw))
+	case tag.TypeShort:
+		v := make([]uint16, len(raw)/2)
+		for i := range v {
+			v[i] = byteOrder.Uint16(raw[2*i:])
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	case tag.TypeLong:
+		v := make([]uint32, len(raw)/4)
+		for i := range v {
+			v[i] = byteOrder.Uint32(raw[4*i:])
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	case tag.TypeSignedShort:
+		v := make([]int16, len(raw)/2)
+		for i := range v {
+			v[i] = int16(byteOrder.Uint16(raw[2*i:]))
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	case tag.TypeSignedLong:
+		v := make([]int32, len(raw)/4)
+		for i := range v {
+			v[i] = int32(byteOrder.Uint32(raw[4*i:]))
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	case tag.TypeRational:
+		v := make([]Rational, len(raw)/8)
+		for i := range v {
+			v[i] = Rational{Numerator: byteOrder.Uint32(raw[8*i:]), Denominator: byteOrder.Uint32(raw[8*i+4:])}
+		}
+		return v
+	case tag.TypeSignedRational:
+		v := make([]SRational, len(raw)/8)
+		for i := range v {
+			v[i] = SRational{Numerator: int32(byteOrder.Uint32(raw[8*i:])), Denominator: int32(byteOrder.Uint32(raw[8*i+4:]))}
+		}
+		return v
+	case tag.TypeFloat:
+		v := make([]float32, len(raw)/4)
+		for i := range v {
+			v[i] = math.Float32frombits(byteOrder.Uint32(raw[4*i:]))
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	case tag.TypeDouble:
+		v := make([]float64, len(raw)/8)
+		for i := range v {
+			v[i] = math.Float64frombits(byteOrder.Uint64(raw[8*i:]))
+		}
+		if len(v) == 1 {
+			return v[0]
+		}
+		return v
+	}
+	// BYTE, UNDEFINED
+	return raw
+}
+
+// rawTagJSON is the JSON representation of a RawTag
+type rawTagJSON struct {
+	ID    string
+	Name  string
+	Count uint32
+	Type  string
+	Val   json.RawMessage
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (rt RawTag) MarshalJSON() ([]byte, error) {
+	val, err := json.Marshal(rt.Val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rawTagJSON{ID: rt.ID.String(), Name: rt.Name, Count: rt.Count, Type: rt.Type.String(), Val: val})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Val is decoded
+// to the Go type of the tag Type. Raw is not available.
+func (rt *RawTag) UnmarshalJSON(buf []byte) (err error) {
+	var j rawTagJSON
+	if err = json.Unmarshal(buf, &j); err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(j.ID, 0, 16)
+	if err != nil {
+		return fmt.Errorf("error tag ID %q: %w", j.ID, err)
+	}
+	*rt = RawTag{ID: tag.ID(id), Name: j.Name, Count: j.Count, Type: tagTypeFromString(j.Type)}
+	rt.Val, err = unmarshalRawValue(rt.Type, j.Val)
+	return err
+}
+
+// unmarshalRawValue unmarshals the JSON value of a RawTag to the Go type of tagType
+func unmarshalRawValue(tagType tag.Type, buf json.RawMessage) (interface{}, error) {
+	if len(buf) == 0 || string(buf) == "null" {
+		return nil, nil
+	}
+	var err error
+	multiple := buf[0] == '['
+	switch tagType {
+	case tag.TypeByte, tag.TypeUndefined:
+		var v []byte
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeASCII, tag.TypeASCIINoNul, tag.TypeUTF8:
+		var v string
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeShort:
+		if multiple {
+			var v []uint16
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v uint16
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeLong:
+		if multiple {
+			var v []uint32
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v uint32
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeSignedShort:
+		if multiple {
+			var v []int16
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v int16
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeSignedLong:
+		if multiple {
+			var v []int32
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v int32
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeRational:
+		var v []Rational
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeSignedRational:
+		var v []SRational
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeFloat:
+		if multiple {
+			var v []float32
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v float32
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	case tag.TypeDouble:
+		if multiple {
+			var v []float64
+			err = json.Unmarshal(buf, &v)
+			return v, err
+		}
+		var v float64
+		err = json.Unmarshal(buf, &v)
+		return v, err
+	}
+	var v interface{}
+	err = json.Unmarshal(buf, &v)
+	return v, err
+}
+
+// tagTypeFromString returns the tag.Type for the string representation of a tag.Type
+func tagTypeFromString(s string) tag.Type {
+	for tt := tag.TypeByte; tt <= tag.TypeDouble; tt++ {
+		if tt.IsValid() && tt.String() == s {
+			return tt
+		}
+	}
+	switch s {
+	case tag.TypeASCIINoNul.String():
+		return tag.TypeASCIINoNul
+	case tag.TypeUTF8.String():
+		return tag.TypeUTF8
+	case tag.TypeIfd.String():
+		return tag.TypeIfd
+	}
+	return tag.TypeUnknown
+}
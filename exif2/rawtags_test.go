@@ -0,0 +1,121 @@
+package exif2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+)
+
+// readRawIfdsJSON reads the Ifds of a testImages json file
+func readRawIfdsJSON(t *testing.T, name string) (ri RawIfds, buf []byte) {
+	buf, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v struct {
+		Ifds RawIfds
+	}
+	if err = json.Unmarshal(buf, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v.Ifds, buf
+}
+
+func TestRawIfdsJSON(t *testing.T) {
+	for _, name := range []string{"ARW.exif.json", "CR2.exif.json", "Heic.exif.json", "JPEG.jpg.json", "NEF.exif.json"} {
+		t.Run(name, func(t *testing.T) {
+			ri, buf := readRawIfdsJSON(t, "../testImages/"+name)
+			b, err := json.Marshal(ri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var wanted struct {
+				Ifds interface{}
+			}
+			var got interface{}
+			if err = json.Unmarshal(buf, &wanted); err != nil {
+				t.Fatal(err)
+			}
+			if err = json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(wanted.Ifds, got) {
+				t.Errorf("Incorrect Ifds round trip for %s", name)
+			}
+		})
+	}
+}
+
+func TestParseAllTags(t *testing.T) {
+	f, err := os.Open("../testImages/CR2.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	e, err := ParseAllTags(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, _ := readRawIfdsJSON(t, "../testImages/CR2.exif.json")
+
+	for _, ifdType := range []ifds.IfdType{ifds.IFD0, ifds.ExifIFD, ifds.GPSIFD} {
+		for index, wi := range wanted[ifdType] {
+			for _, wt := range wi.Tags {
+				if ifdType == ifds.ExifIFD && wt.ID == exififd.InteroperabilityTag {
+					continue // read as the Interop Ifd
+				}
+				rt, ok := e.Ifds.Tag(ifdType, index, wt.ID)
+				if !ok {
+					t.Errorf("%s%d: tag %s not retained", ifdType, index, wt.ID)
+					continue
+				}
+				if rt.Type != wt.Type || rt.Count != wt.Count {
+					t.Errorf("%s%d: tag %s wanted %s[%d] got %s[%d]", ifdType, index, wt.ID, wt.Type, wt.Count, rt.Type, rt.Count)
+				}
+				switch wt.Type {
+				case tag.TypeShort, tag.TypeLong, tag.TypeASCII, tag.TypeRational, tag.TypeSignedRational:
+					if !reflect.DeepEqual(rt.Val, wt.Val) {
+						t.Errorf("%s%d: tag %s wanted %v got %v", ifdType, index, wt.ID, wt.Val, rt.Val)
+					}
+				}
+			}
+		}
+	}
+
+	// Typed accessors
+	if rt, _ := e.Ifds.Tag(ifds.IFD0, 0, ifds.Make); rt.String() != e.Make {
+		t.Errorf("Incorrect Make wanted %s got %s", e.Make, rt.String())
+	}
+	if rt, _ := e.Ifds.Tag(ifds.ExifIFD, 0, exififd.ExposureTime); len(rt.Floats()) != 1 || float32(rt.Floats()[0]) != float32(e.ExposureTime) {
+		t.Errorf("Incorrect ExposureTime wanted %v got %v", e.ExposureTime, rt.Floats())
+	}
+	if rt, _ := e.Ifds.Tag(ifds.ExifIFD, 0, exififd.ISOSpeedRatings); rt.Int() != int64(e.ISOSpeed) {
+		t.Errorf("Incorrect ISOSpeedRatings wanted %d got %d", e.ISOSpeed, rt.Int())
+	}
+}
+
+func TestParseAllTagsLargeIfd(t *testing.T) {
+	le := binary.LittleEndian
+	entries := []tifftest.Entry{tifftest.ASCII(ifds.Make, "Canon")}
+	for i := 0; i < 100; i++ {
+		entries = append(entries, tifftest.Shorts(le, tag.ID(0xc000+i), uint16(i)))
+	}
+	e, err := ParseAllTags(bytes.NewReader(tifftest.Tiff(le, 0x2a, entries)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(e.Ifds[ifds.IFD0][0].Tags); n != 101 {
+		t.Errorf("Incorrect number of retained tags wanted %d got %d", 101, n)
+	}
+	if rt, ok := e.Ifds.Tag(ifds.IFD0, 0, 0xc063); !ok || rt.Int() != 99 {
+		t.Errorf("Incorrect tag 0xc063 got %v", rt.Val)
+	}
+}
@@ -1,13 +1,16 @@
 package exif2
 
 import (
+	"bufio"
+	"bytes"
 	"io"
 
 	"github.com/evanoberholster/imagemeta/exif2/ifds"
 	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
-	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/nikon"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
 	"github.com/evanoberholster/imagemeta/exif2/tag"
 	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/jpeg"
 	"github.com/evanoberholster/imagemeta/meta"
 	"github.com/evanoberholster/imagemeta/meta/utils"
 	"github.com/evanoberholster/imagemeta/tiff"
@@ -15,6 +18,12 @@ import (
 )
 
 func Parse(r io.ReadSeeker) (Exif, error) {
+	return parse(r, false)
+}
+
+// parse parses Exif from r. If retainTags is true every tag that is read
+// is retained in Exif.Ifds.
+func parse(r io.ReadSeeker, retainTags bool) (Exif, error) {
 	h, err := tiff.ScanTiffHeader(r, imagetype.ImageUnknown)
 	if err != nil {
 		return Exif{}, err
@@ -22,11 +31,12 @@ func Parse(r io.ReadSeeker) (Exif, error) {
 
 	ir := NewIfdReader(Logger)
 	defer ir.Close()
+	ir.SetRetainTags(retainTags)
 
 	if _, err = r.Seek(int64(h.TiffHeaderOffset), 0); err != nil {
 		return ir.Exif, err
 	}
-	if err := ir.DecodeTiff(r, h); err != nil {
+	if err := ir.DecodeTiff(bufio.NewReader(r), h); err != nil {
 		return ir.Exif, err
 	}
 	return ir.Exif, nil
@@ -46,6 +56,7 @@ func (ir *ifdReader) DecodeTiff(r io.Reader, h meta.ExifHeader) error {
 		return err
 	}
 	err := ir.readIfd(ifds.NewIFD(h.ByteOrder, ifds.IfdType(h.FirstIfd), 0, ir.tiffHeaderOffset, 0))
+	ir.Exif.Raw = ir.rawInfo()
 	return err
 }
 
@@ -81,6 +92,7 @@ func (ir *ifdReader) DecodeIfd(r io.Reader, h meta.ExifHeader) (err error) {
 	ir.firstIfdOffset = h.FirstIfdOffset
 	ir.po = h.FirstIfdOffset
 	err = ir.readIfd(ifds.NewIFD(h.ByteOrder, ifds.IfdType(h.FirstIfd), 0, ir.tiffHeaderOffset, 0))
+	ir.Exif.Raw = ir.rawInfo()
 	return err
 }
 
@@ -99,6 +111,7 @@ func NewIfdReader(l zerolog.Logger) ifdReader {
 func (ir *ifdReader) ResetReader(r io.Reader) {
 	ir.buffer.clear()
 	ir.reader = r
+	ir.tagValue = nil
 }
 
 // SetCustomTagParser sets a custom tag parser
@@ -123,6 +136,18 @@ type ifdReader struct {
 	tiffHeaderOffset uint32
 	firstIfdOffset   uint32
 	exifLength       uint32
+
+	// retained tags
+	retainTags     bool
+	tagValue       []byte // value of the last retained tag
+	tagValueOffset uint32 // ValueOffset of tagValue
+
+	// tag visitor
+	visitor  TagVisitor
+	visitErr error
+
+	// raw image Ifd candidates, see rawInfo
+	raw [rawIfdCount]RawInfo
 }
 
 func (ir *ifdReader) readIfdHeader(ifd ifds.Ifd) (err error) {
@@ -158,7 +183,14 @@ func (ir *ifdReader) readIfdHeader(ifd ifds.Ifd) (err error) {
 			t.logTag(ir.logDebug()).Send()
 		}
 		if t.IsEmbedded() {
+			if t.ID == ifds.SubIFDs && t.Ifd == ifds.IFD0 { // a single SubIfd
+				ir.readSubIfds(t)
+				continue
+			}
 			ir.parseTag(t)
+			if ir.stopped() {
+				return nil
+			}
 		} else {
 			ir.addTagBuffer(t)
 		}
@@ -177,7 +209,7 @@ type BufferedReader interface {
 
 func (ir *ifdReader) readNextIfdTag(ifd ifds.Ifd) error {
 	var err error
-	if uint32(ir.buffer.nextTag().ValueOffset) <= ir.po {
+	if !ir.overlapsTagValue(4) {
 		var nextIfd uint32
 		if nextIfd, err = ir.readUint32(ifd); err != nil {
 			if ir.logLevelError() {
@@ -197,11 +229,22 @@ func (ir *ifdReader) readNextIfdTag(ifd ifds.Ifd) error {
 }
 
 func (ir *ifdReader) readIfd(ifd ifds.Ifd) (err error) {
+	if ir.stopped() {
+		return nil
+	}
 	if err = ir.readIfdHeader(ifd); err != nil {
 		return err
 	}
+	return ir.readTags()
+}
 
+// readTags reads the tags in the tag buffer in order of their value offsets
+// and the Ifds that they point to.
+func (ir *ifdReader) readTags() (err error) {
 	for t := ir.buffer.currentTag(); ir.buffer.validTag(); t = ir.buffer.advanceBuffer() {
+		if ir.stopped() {
+			return nil
+		}
 
 		if t.IsType(tag.TypeIfd) {
 			if err = ir.seekToTag(t); err != nil { // seek to next tag value
@@ -215,12 +258,25 @@ func (ir *ifdReader) readIfd(ifd ifds.Ifd) (err error) {
 					if err = ir.readIfdHeader(t.childIfd()); err != nil { // ignore errors from GPSIfd and ExifIfd
 						ir.logError(err).Send()
 					}
+				case ifds.SubIFDs: // IFD1 and following Ifds from readNextIfdTag
+					if err = ir.readIfdHeader(t.childIfd()); err != nil { // ignore errors from IFD1
+						ir.logError(err).Send()
+					}
 				}
 			case ifds.SubIfd0, ifds.SubIfd1, ifds.SubIfd2, ifds.SubIfd3, ifds.SubIfd4, ifds.SubIfd5:
 				if err = ir.readIfdHeader(t.childIfd()); err != nil { // ignore errors from SubIfd0
 					ir.logError(err).Send()
 				}
 			case ifds.ExifIFD:
+				switch t.ID {
+				case exififd.MakerNote:
+					ir.readMakerNotes(t)
+				case exififd.InteroperabilityTag:
+					if err = ir.readIfdHeader(t.childIfd()); err != nil { // ignore errors from InteropIfd
+						ir.logError(err).Send()
+					}
+				}
+			case ifds.DNGAdobeDataIFD:
 				if t.ID == exififd.MakerNote {
 					ir.readMakerNotes(t)
 				}
@@ -242,44 +298,201 @@ func (ir *ifdReader) readIfd(ifd ifds.Ifd) (err error) {
 // Limited to total 6 SubIfds, can be increased.
 func (ir *ifdReader) readSubIfds(t Tag) {
 	if t.IsType(tag.TypeLong) {
-		buf, err := ir.readTagValue()
-		if err != nil {
-			if ir.logLevelError() {
-				t.logTag(ir.logError(err)).Send()
+		var buf []byte
+		if t.IsEmbedded() { // a single SubIfd
+			t.EmbeddedValue(ir.buffer.buf[:4])
+			buf = ir.buffer.buf[:4]
+		} else {
+			var err error
+			if buf, err = ir.readTagValue(); err != nil {
+				if ir.logLevelError() {
+					t.logTag(ir.logError(err)).Send()
+				}
+				return
 			}
-			return
 		}
-		for i := 0; i < int(t.UnitCount); i++ {
+		for i := 0; i < int(t.UnitCount) && i < 6; i++ {
 			ir.addTagBuffer(NewTag(t.ID, tag.TypeIfd, tag.TypeIfdSize, t.ByteOrder.Uint32(buf[4*i:]), ifds.SubIfd0+ifds.IfdType(i), 0, t.ByteOrder))
 		}
 	}
 }
 
+// readMakerNotes reads the Makernote of t with the makerNoteReader registered
+// for the CameraMake. Makernotes that begin with a header registered with
+// RegisterMakerNoteHeader are decoded with the MakerNoteDecoder of the header.
+// The reader must be positioned at t.ValueOffset.
 func (ir *ifdReader) readMakerNotes(t Tag) {
-	switch ir.Exif.CameraMake {
-	case ifds.Canon:
-		if err := ir.readIfdHeader(t.childIfd()); err != nil {
-			ir.logError(err).Send()
+	read, headerLength := makerNoteReaderFor(ir.Exif.CameraMake)
+	if headerLength > 0 && (read == nil || ir.peekMakerNoteHeader(t, headerLength)) {
+		ir.decodeMakerNote(t, nil)
+		return
+	}
+	if read != nil {
+		read(ir, t)
+	}
+}
+
+// peekMakerNoteHeader returns true if the Makernote of t begins with a
+// registered header. It returns false if the reader can not Peek.
+func (ir *ifdReader) peekMakerNoteHeader(t Tag, n int) bool {
+	br, ok := ir.reader.(BufferedReader)
+	if !ok {
+		return false
+	}
+	if n > int(t.Size()) {
+		n = int(t.Size())
+	}
+	buf, _ := br.Peek(n)
+	return makerNoteHeaderDecoderFor(buf) != nil
+}
+
+// decodeMakerNote reads the Makernote of t and decodes it with the
+// MakerNoteDecoder registered for its header or else with fn. It returns true
+// if the Makernote was decoded. The reader must be positioned at t.ValueOffset.
+func (ir *ifdReader) decodeMakerNote(t Tag, fn MakerNoteDecoder) bool {
+	buf, err := ir.readMakerNoteBuffer(t)
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return false
+	}
+	if hfn := makerNoteHeaderDecoderFor(buf); hfn != nil {
+		fn = hfn
+	}
+	if fn == nil {
+		return false
+	}
+	mn, err := fn(MakerNote{
+		Buf:        buf,
+		Offset:     t.ValueOffset,
+		ByteOrder:  t.ByteOrder,
+		CameraMake: ir.Exif.CameraMake,
+		Make:       ir.Exif.Make,
+		Model:      ir.Exif.Model,
+		Visitor:    ir.makerNoteVisitor(t),
+	})
+	if ir.stopped() {
+		return false
+	}
+	if err != nil {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Err(err).Str("make", ir.Exif.CameraMake.String()).Msg("makernote")
 		}
-	case ifds.Nikon:
-		if t.Size() > 18 { // read Nikon Makernotes header 18 bytes
-			buf, err := ir.fastRead(18)
-			if err != nil {
-				t.logTag(ir.logError(err)).Send()
-			}
-			if nikon.IsNikonMkNoteHeaderBytes(buf[:5]) {
-				ir.Exif.ImageType = imagetype.ImageNEF
-				if byteOrder := utils.BinaryOrder(buf[10:14]); byteOrder != utils.UnknownEndian {
-					err = ir.readIfdHeader(ifds.NewIFD(byteOrder, ifds.MknoteIFD, t.IfdIndex, t.ValueOffset, t.ValueOffset+byteOrder.Uint32(buf[14:18])))
-					if err != nil {
-						ir.logError(err).Send()
-					}
-				}
-			}
+		return false
+	}
+	if mn == nil {
+		return false
+	}
+	ir.Exif.Makernotes = mn
+	return true
+}
+
+// readJpgFromRaw decodes the Exif of the JPEG embedded in the JpgFromRaw tag
+// of a Panasonic RW2 image. The Exif of the JPEG is merged with ir.Exif.
+// The reader must be at or before t.ValueOffset.
+func (ir *ifdReader) readJpgFromRaw(t Tag) {
+	var r io.Reader
+	var lr *io.LimitedReader
+	var n int64
+	if buf, ok := ir.cachedValue(t); ok { // value was read by a TagVisitor
+		r = bytes.NewReader(buf)
+	} else {
+		if err := ir.seekToTag(t); err != nil {
+			return
+		}
+		n = int64(t.Size())
+		if ir.exifLength != 0 && int64(ir.po)+n > int64(ir.exifLength) {
+			n = int64(ir.exifLength) - int64(ir.po)
+		}
+		lr = &io.LimitedReader{R: ir.reader, N: n}
+		r = lr
+	}
+	jr := NewIfdReader(ir.logger)
+	defer jr.Close()
+	jr.Exif = ir.Exif
+	jr.customTagParser = ir.customTagParser
+	jr.retainTags = ir.retainTags
+	jr.visitor = ir.visitor
+
+	err := jpeg.ScanJPEG(r, jr.DecodeJPEGIfd, nil)
+	if err != nil && err != jpeg.ErrEndOfImage && ir.logLevelWarn() {
+		t.logTag(ir.logWarn()).Err(err).Msg("JpgFromRaw")
+	}
+	ir.Exif = jr.Exif
+	ir.Exif.ImageType = imagetype.ImagePanaRAW
+	ir.visitErr = jr.visitErr
+	if lr == nil {
+		return
+	}
+
+	// Discard the remainder of the JPEG
+	ir.po += uint32(n - lr.N)
+	if err = ir.discard(int(lr.N)); err != nil && ir.logLevelError() {
+		t.logTag(ir.logError(err)).Send()
+	}
+}
+
+// readCameraIFD reads the Panasonic CameraIFD of a RW2 image. The CameraIFD
+// is a Tiff Header and Ifd with offsets relative to the CameraIFD. Its tags
+// are retained in ir.Exif.RW2.CameraIFD and passed to the TagVisitor with
+// the path "Ifd/CameraIFD".
+func (ir *ifdReader) readCameraIFD(t Tag) {
+	if t.IsEmbedded() || t.Size() > makerNoteMaxLength {
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("Unrecognized tag type")
+		}
+		return
+	}
+	buf, err := ir.readValue(t)
+	if err != nil {
+		t.logTag(ir.logError(err)).Send()
+		return
+	}
+	var byteOrder utils.ByteOrder
+	switch {
+	case len(buf) < 8:
+		return
+	case buf[0] == 'I' && buf[1] == 'I':
+		byteOrder = utils.LittleEndian
+	case buf[0] == 'M' && buf[1] == 'M':
+		byteOrder = utils.BigEndian
+	default:
+		if ir.logLevelWarn() {
+			t.logTag(ir.logWarn()).Msg("CameraIFD header")
 		}
+		return
+	}
+	r := mknote.NewReader(buf, byteOrder, t.ValueOffset, t.ValueOffset)
+	r.Visitor = ir.entryVisitor(IfdPath(t)+"/CameraIFD", ifds.IfdType(t.Ifd), t.IfdIndex)
+	entries, _, err := r.ReadIfd(byteOrder.Uint32(buf[4:]))
+	if err != nil && !ir.stopped() {
+		t.logTag(ir.logError(err)).Msg("CameraIFD")
+	}
+	for _, e := range entries {
+		ir.Exif.RW2.CameraIFD = append(ir.Exif.RW2.CameraIFD, RawTag{
+			ID:        e.ID,
+			Name:      e.ID.String(),
+			Type:      e.Type,
+			Count:     e.UnitCount,
+			ByteOrder: e.ByteOrder,
+			Raw:       e.Bytes(),
+			Val:       decodeRawValue(e.Type, e.ByteOrder, e.Bytes()),
+		})
 	}
 }
 
+// readMakerNoteBuffer reads the full Makernote of the given tag into a newly
+// allocated buffer. The reader must be positioned at t.ValueOffset.
+func (ir *ifdReader) readMakerNoteBuffer(t Tag) (buf []byte, err error) {
+	n := int(t.UnitCount)
+	if n > makerNoteMaxLength || (ir.exifLength != 0 && int(ir.po)+n > int(ir.exifLength)) {
+		return nil, imagetype.ErrDataLength
+	}
+	buf = make([]byte, n)
+	n, err = io.ReadFull(ir.reader, buf)
+	ir.po += uint32(n)
+	return buf[:n], err
+}
+
 func (ir *ifdReader) fastRead(n int) (buf []byte, err error) {
 	if ir.exifLength != 0 && int(ir.po)+n > int(ir.exifLength) {
 		return nil, imagetype.ErrDataLength
@@ -300,14 +513,19 @@ func (ir *ifdReader) fastRead(n int) (buf []byte, err error) {
 		ir.po += uint32(n)
 		return
 	}
-	if n, err = ir.reader.Read(ir.buffer.buf[:n]); err != nil {
+	buf = ir.buffer.buf[:]
+	if n > len(buf) { // ex: an Ifd with more than 85 tags
+		buf = make([]byte, n)
+	}
+	n, err = io.ReadFull(ir.reader, buf[:n])
+	ir.po += uint32(n)
+	if err != nil {
 		if ir.logLevelError() {
 			ir.logError(err).Msg("Read error")
 		}
-		return
+		return nil, err
 	}
-	ir.po += uint32(n)
-	return ir.buffer.buf[:n], err
+	return buf[:n], nil
 }
 
 // ReadUint16 reads a uint16 from an ifdReader.
@@ -347,7 +565,7 @@ func tagIsIfd(ifdType ifds.IfdType, tagID tag.ID, tagType tag.Type) tag.Type {
 			}
 		case ifds.ExifIFD: // ExifIfd Children
 			switch tagID {
-			case exififd.MakerNote:
+			case exififd.MakerNote, exififd.InteroperabilityTag:
 				return tag.TypeIfd
 			}
 		}
@@ -90,12 +90,22 @@ func (t Tag) childIfd() ifds.Ifd {
 			return ifds.NewIFD(t.ByteOrder, ifds.ExifIFD, t.IfdIndex, t.ValueOffset, 0)
 		case ifds.GPSTag:
 			return ifds.NewIFD(t.ByteOrder, ifds.GPSIFD, t.IfdIndex, t.ValueOffset, 0)
+		case ifds.SubIFDs:
+			if t.IfdIndex > 0 { // Next Ifd (IFD1, IFD2...) from readNextIfdTag
+				return ifds.NewIFD(t.ByteOrder, ifds.IFD0, t.IfdIndex, t.ValueOffset, 0)
+			}
 		}
 
 	case ifds.ExifIFD: // ExifIfd Children
 		switch t.ID {
 		case exififd.MakerNote:
 			return ifds.NewIFD(t.ByteOrder, ifds.MknoteIFD, t.IfdIndex, t.ValueOffset, 0)
+		case exififd.InteroperabilityTag:
+			return ifds.NewIFD(t.ByteOrder, ifds.IopIFD, t.IfdIndex, t.ValueOffset, 0)
+		}
+	case ifds.DNGAdobeDataIFD: // DNGPrivateData Makernote
+		if t.ID == exififd.MakerNote {
+			return ifds.NewIFD(t.ByteOrder, ifds.MknoteIFD, t.IfdIndex, t.ValueOffset, 0)
 		}
 	case ifds.SubIfd0, ifds.SubIfd1, ifds.SubIfd2, ifds.SubIfd3, ifds.SubIfd4, ifds.SubIfd5:
 		return ifds.NewIFD(t.ByteOrder, t.Ifd, t.IfdIndex, t.ValueOffset, 0)
@@ -69,6 +69,10 @@ const (
 	// TypeDouble describes an emcoded double (uint64).
 	TypeDouble Type = 12
 
+	// TypeUTF8 describes an encoded list of UTF-8 characters that is
+	// terminated with a NUL in its encoded form. (Exif 3.0)
+	TypeUTF8 Type = 129
+
 	// PseudoTypes
 
 	// TypeASCIINoNul is just a pseudo-type, for our own purposes.
@@ -91,6 +95,7 @@ const (
 	TypeFloatSize          = 4
 	TypeDoubleSize         = 8
 	TypeIfdSize            = 4
+	TypeUTF8Size           = 1
 
 	// TagType Stringer String
 	_TagTypeStringerString = "UnknownBYTEASCIISHORTLONGRATIONALUnknownUNDEFINEDSSHORTSLONGSRATIONALFLOATDOUBLE"
@@ -111,7 +116,7 @@ var (
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, TypeUTF8Size,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 		0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
@@ -141,6 +146,9 @@ func (tt Type) String() string {
 	if int(tt) < len(_TagTypeStringerIndex)-1 {
 		return _TagTypeStringerString[_TagTypeStringerIndex[tt]:_TagTypeStringerIndex[tt+1]]
 	}
+	if tt == TypeUTF8 {
+		return "UTF8"
+	}
 	if tt == TypeIfd {
 		return "IFD"
 	}
@@ -164,5 +172,11 @@ func (tt Type) IsValid() bool {
 		tt == TypeFloat ||
 		tt == TypeDouble ||
 		tt == TypeUndefined ||
+		tt == TypeUTF8 ||
 		tt == TypeIfd
 }
+
+// IsString returns true if tagType is a string type: ASCII or UTF8.
+func (tt Type) IsString() bool {
+	return tt == TypeASCII || tt == TypeASCIINoNul || tt == TypeUTF8
+}
@@ -21,6 +21,7 @@ var tagTypeTests = []struct {
 	{10, TypeSignedRational, TypeSignedRationalSize, "SRATIONAL", nil},
 	{11, TypeFloat, TypeFloatSize, "FLOAT", nil},
 	{12, TypeDouble, TypeDoubleSize, "DOUBLE", nil},
+	{129, TypeUTF8, TypeUTF8Size, "UTF8", nil},
 	{0xf0, TypeASCIINoNul, TypeASCIINoNulSize, "_ASCII_NO_NUL", nil},
 	{0xf1, TypeIfd, TypeIfdSize, "IFD", nil},
 	{0, TypeUnknown, 0, "Unknown", ErrTagTypeNotValid},
@@ -75,3 +76,14 @@ func TestType(t *testing.T) {
 		t.Errorf("Incorrect Type should be equal")
 	}
 }
+
+func TestTypeIsString(t *testing.T) {
+	for _, tt := range []Type{TypeASCII, TypeASCIINoNul, TypeUTF8} {
+		if !tt.IsString() {
+			t.Errorf("Incorrect Type %s should be a string", tt)
+		}
+	}
+	if TypeUndefined.IsString() || TypeByte.IsString() {
+		t.Errorf("Incorrect Type should not be a string")
+	}
+}
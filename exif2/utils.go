@@ -1,5 +1,16 @@
 package exif2
 
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+	"golang.org/x/text/encoding/japanese"
+)
+
 // static values
 const (
 	hoursToSeconds   = 60 * minutesToSeconds
@@ -19,7 +30,7 @@ func parseStrUint(buf []byte) (u uint) {
 
 // trimNULBuffer removes trailing bytes from Buffer
 func trimNULBuffer(buf []byte) []byte {
-	for i := len(buf) - 1; i > 0; i-- {
+	for i := len(buf) - 1; i >= 0; i-- {
 		if buf[i] == 0 || buf[i] == ' ' || buf[i] == '\n' {
 			continue
 		}
@@ -37,3 +48,103 @@ func trimNULBuffer(buf []byte) []byte {
 //	}
 //	return buf
 //}
+
+// decodeUTF16 decodes a UTF-16 string with byteOrder. A byte order mark
+// takes precedence over byteOrder. Trailing NUL characters are trimmed.
+func decodeUTF16(buf []byte, byteOrder utils.ByteOrder) string {
+	if len(buf) >= 2 {
+		switch {
+		case buf[0] == 0xfe && buf[1] == 0xff:
+			byteOrder, buf = utils.BigEndian, buf[2:]
+		case buf[0] == 0xff && buf[1] == 0xfe:
+			byteOrder, buf = utils.LittleEndian, buf[2:]
+		}
+	}
+	u := make([]uint16, 0, len(buf)/2)
+	for i := 0; i+1 < len(buf); i += 2 {
+		u = append(u, byteOrder.Uint16(buf[i:]))
+	}
+	for len(u) > 0 && u[len(u)-1] == 0 {
+		u = u[:len(u)-1]
+	}
+	return string(utf16.Decode(u))
+}
+
+// utf16ByteOrder returns the byte order of a UTF-16 string without a byte
+// order mark. Cameras do not always write UNICODE strings in the byte order
+// of the Tiff, mostly Latin text has a NUL high byte in each character.
+func utf16ByteOrder(buf []byte, byteOrder utils.ByteOrder) utils.ByteOrder {
+	var even, odd int
+	for i := 0; i+1 < len(buf); i += 2 {
+		if buf[i] == 0 && buf[i+1] != 0 {
+			even++
+		} else if buf[i] != 0 && buf[i+1] == 0 {
+			odd++
+		}
+	}
+	switch {
+	case even > odd:
+		return utils.BigEndian
+	case odd > even:
+		return utils.LittleEndian
+	}
+	return byteOrder
+}
+
+// decodeCharacterCode decodes the value of a string with an 8 byte character
+// code prefix, ex: UserComment. Trailing NUL characters and spaces are
+// trimmed. This function allocates.
+func decodeCharacterCode(cc meta.CharacterCode, buf []byte, byteOrder utils.ByteOrder) string {
+	switch cc {
+	case meta.CharacterCodeUnicode:
+		if len(buf) >= 2 && !(buf[0] == 0xfe && buf[1] == 0xff) && !(buf[0] == 0xff && buf[1] == 0xfe) {
+			byteOrder = utf16ByteOrder(buf, byteOrder)
+		}
+		return strings.TrimRight(decodeUTF16(buf, byteOrder), " \x00")
+	case meta.CharacterCodeJIS:
+		return decodeJIS(trimNULBuffer(buf))
+	}
+	return decodeString(trimNULBuffer(buf))
+}
+
+// decodeJIS decodes a Shift-JIS string, or an ISO-2022-JP string when it
+// contains escape sequences.
+func decodeJIS(buf []byte) string {
+	dec := japanese.ShiftJIS.NewDecoder()
+	if bytes.IndexByte(buf, 0x1b) >= 0 {
+		dec = japanese.ISO2022JP.NewDecoder()
+	}
+	if b, err := dec.Bytes(buf); err == nil {
+		return string(b)
+	}
+	return decodeString(buf)
+}
+
+// decodeString returns buf as a string. Strings that are not valid UTF-8
+// are decoded as Latin-1 (ISO 8859-1).
+func decodeString(buf []byte) string {
+	if utf8.Valid(buf) {
+		return string(buf)
+	}
+	r := make([]rune, len(buf))
+	for i, b := range buf {
+		r[i] = rune(b)
+	}
+	return string(r)
+}
+
+// decodeXPString decodes the UCS-2 little endian value of the Windows
+// XPTitle, XPComment, XPAuthor, XPKeywords and XPSubject tags.
+func decodeXPString(buf []byte) string {
+	return strings.TrimSpace(decodeUTF16(buf, utils.LittleEndian))
+}
+
+// splitXPKeywords splits the semicolon separated XPKeywords
+func splitXPKeywords(s string) (keywords []string) {
+	for _, k := range strings.Split(s, ";") {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
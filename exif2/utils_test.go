@@ -1,6 +1,12 @@
 package exif2
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/meta/utils"
+)
 
 func TestParseStrUint(t *testing.T) {
 	tests := []struct {
@@ -29,6 +35,7 @@ func TestTrim(t *testing.T) {
 	}{
 		{"abcdefgh\000\000\000", "abcdefgh"},
 		{"\n\n\n\n\000\000\000", ""},
+		{"8\000", "8"},
 	}
 	for _, test := range tests {
 		result := trimNULBuffer([]byte(test.raw))
@@ -37,3 +44,42 @@ func TestTrim(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeCharacterCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		cc        meta.CharacterCode
+		raw       []byte
+		byteOrder utils.ByteOrder
+		result    string
+	}{
+		{"ASCII", meta.CharacterCodeASCII, []byte("Caption   \x00"), utils.LittleEndian, "Caption"},
+		{"Unicode", meta.CharacterCodeUnicode, []byte{'H', 0, 'i', 0, 0, 0}, utils.LittleEndian, "Hi"},
+		{"UnicodeBigEndian", meta.CharacterCodeUnicode, []byte{0, 'H', 0, 'i', 0, ' '}, utils.BigEndian, "Hi"},
+		{"UnicodeByteOrderQuirk", meta.CharacterCodeUnicode, []byte{'H', 0, 'i', 0}, utils.BigEndian, "Hi"},
+		{"UnicodeBOM", meta.CharacterCodeUnicode, []byte{0xff, 0xfe, 0xe5, 0x65, 0x2c, 0x67}, utils.BigEndian, "日本"},
+		{"JIS", meta.CharacterCodeJIS, []byte{0x93, 0xfa, 0x96, 0x7b, 0x00}, utils.LittleEndian, "日本"},
+		{"ISO2022JP", meta.CharacterCodeJIS, []byte{0x1b, '$', 'B', 0x46, 0x7c, 0x4b, 0x5c, 0x1b, '(', 'B'}, utils.LittleEndian, "日本"},
+		{"UndefinedLatin1", meta.CharacterCodeUndefined, []byte{'C', 'a', 'f', 0xe9}, utils.LittleEndian, "Café"},
+		{"UndefinedUTF8", meta.CharacterCodeUndefined, []byte("Café"), utils.LittleEndian, "Café"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := decodeCharacterCode(test.cc, test.raw, test.byteOrder); result != test.result {
+				t.Errorf("Incorrect decodeCharacterCode wanted %s got %s", test.result, result)
+			}
+		})
+	}
+}
+
+func TestDecodeXPString(t *testing.T) {
+	if s := decodeXPString([]byte{'T', 0, 'i', 0, 't', 0, 'r', 0, 0xe9, 0, 0, 0}); s != "Titré" {
+		t.Errorf("Incorrect decodeXPString wanted %s got %s", "Titré", s)
+	}
+	if k := splitXPKeywords("beach; sunset;;  family "); !reflect.DeepEqual(k, []string{"beach", "sunset", "family"}) {
+		t.Errorf("Incorrect splitXPKeywords got %v", k)
+	}
+	if k := splitXPKeywords(""); k != nil {
+		t.Errorf("Incorrect splitXPKeywords wanted nil got %v", k)
+	}
+}
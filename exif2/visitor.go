@@ -0,0 +1,197 @@
+package exif2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/imagetype"
+)
+
+// Errors
+var (
+	// ErrStopVisit is returned by a TagVisitor to stop reading tags.
+	// It is not returned by the decoder.
+	ErrStopVisit = errors.New("stop visiting tags")
+
+	// ErrTagValueUnavailable is returned by TagValue when the reader has
+	// already passed the tag value.
+	ErrTagValueUnavailable = errors.New("error tag value is no longer available")
+)
+
+// TagVisitor is called with every tag that is parsed. path is the path of
+// the Ifd of the tag, ex: "Ifd/Exif" or "Ifd/SubIfd0". Tags from IFD1 and
+// later Ifds have their index appended, ex: "Ifd1".
+//
+// The tag value can be read with v, it is read lazily and is only valid
+// during the call to VisitTag. Return ErrStopVisit to stop reading tags.
+// Any other error also stops reading tags and is returned by the decoder.
+type TagVisitor interface {
+	VisitTag(path string, t Tag, v TagValue) error
+}
+
+// TagVisitorFn is a function that implements TagVisitor.
+type TagVisitorFn func(path string, t Tag, v TagValue) error
+
+// VisitTag implements the TagVisitor interface
+func (fn TagVisitorFn) VisitTag(path string, t Tag, v TagValue) error {
+	return fn(path, t, v)
+}
+
+// SetTagVisitor sets a TagVisitor that is called with every tag that is parsed.
+func (ir *ifdReader) SetTagVisitor(v TagVisitor) {
+	ir.visitor = v
+}
+
+// VisitErr returns the error returned by the TagVisitor, if any.
+// ErrStopVisit is not returned.
+func (ir *ifdReader) VisitErr() error {
+	if ir.visitErr == ErrStopVisit {
+		return nil
+	}
+	return ir.visitErr
+}
+
+// stopped returns true if the TagVisitor stopped reading tags
+func (ir *ifdReader) stopped() bool {
+	return ir.visitErr != nil
+}
+
+// visitTag calls the TagVisitor with t
+func (ir *ifdReader) visitTag(t Tag) {
+	if err := ir.visitor.VisitTag(IfdPath(t), t, TagValue{ir: ir, t: t}); err != nil {
+		ir.visitErr = err
+	}
+}
+
+// makerNoteVisitor returns a mknote.Visitor that passes the entries of the
+// Makernote of t to the TagVisitor. Entries of the Makernote Ifd have the
+// path of the Makernote, ex: "Ifd/Exif/Makernote", and entries of sub-Ifds
+// have the name of the sub-Ifd appended, ex: "Ifd/Exif/Makernote/Equipment".
+func (ir *ifdReader) makerNoteVisitor(t Tag) mknote.Visitor {
+	return ir.entryVisitor(IfdPath(Tag{Ifd: ifds.MknoteIFD, IfdIndex: t.IfdIndex}), ifds.MknoteIFD, t.IfdIndex)
+}
+
+// entryVisitor returns a mknote.Visitor that passes the entries of an Ifd
+// that is read from a buffer to the TagVisitor as tags of ifd with path.
+// Entries of sub-Ifds have the name of the sub-Ifd appended to path.
+func (ir *ifdReader) entryVisitor(path string, ifd ifds.IfdType, ifdIndex int8) mknote.Visitor {
+	if ir.visitor == nil {
+		return nil
+	}
+	return func(sub string, e mknote.Entry) error {
+		mt := NewTag(e.ID, e.Type, e.UnitCount, e.ValueOffset, ifd, ifdIndex, e.ByteOrder)
+		p := path
+		if sub != "" {
+			p = path + "/" + sub
+		}
+		if err := ir.visitor.VisitTag(p, mt, TagValue{t: mt, buf: e.Bytes(), buffered: true}); err != nil {
+			ir.visitErr = err
+			return err
+		}
+		return nil
+	}
+}
+
+// IfdPath returns the path of the Ifd of the tag, ex: "Ifd/Exif" or "Ifd1"
+func IfdPath(t Tag) string {
+	if t.IfdIndex > 0 {
+		return fmt.Sprintf("%s%d", t.Ifd, t.IfdIndex)
+	}
+	return t.Ifd.String()
+}
+
+// TagValue lazily reads the value of a Tag. The value is read from the
+// underlying reader the first time it is requested.
+type TagValue struct {
+	ir  *ifdReader
+	t   Tag
+	buf []byte // value of a buffered tag, ex: a Makernote entry
+
+	// buffered is true if the value is buf and not read from ir
+	buffered bool
+}
+
+// Bytes returns the raw value of the tag. The returned buffer must not be
+// modified.
+func (v TagValue) Bytes() ([]byte, error) {
+	if v.buffered {
+		if v.buf == nil {
+			return nil, ErrTagValueUnavailable
+		}
+		return v.buf, nil
+	}
+	if v.t.IsEmbedded() {
+		buf := make([]byte, 4)
+		v.t.EmbeddedValue(buf)
+		return buf[:v.t.Size()], nil
+	}
+	return v.ir.readValue(v.t)
+}
+
+// Value returns the decoded value of the tag. See RawTag for the types of
+// values.
+func (v TagValue) Value() (interface{}, error) {
+	buf, err := v.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return decodeRawValue(v.t.Type, v.t.ByteOrder, buf), nil
+}
+
+// RawTag returns the tag and its decoded value as a RawTag.
+func (v TagValue) RawTag() (RawTag, error) {
+	rt := RawTag{ID: v.t.ID, Name: v.t.Name(), Type: v.t.Type, Count: v.t.UnitCount, ByteOrder: v.t.ByteOrder}
+	buf, err := v.Bytes()
+	if err != nil {
+		return rt, err
+	}
+	rt.Raw = buf
+	rt.Val = decodeRawValue(rt.Type, rt.ByteOrder, rt.Raw)
+	return rt, nil
+}
+
+// String returns the value of an ASCII or UTF8 tag.
+func (v TagValue) String() (string, error) {
+	if !v.t.Type.IsString() {
+		return "", tag.ErrTagTypeNotValid
+	}
+	buf, err := v.Bytes()
+	return string(trimNULBuffer(buf)), err
+}
+
+// cachedValue returns the value of t if it was read by readValue
+func (ir *ifdReader) cachedValue(t Tag) ([]byte, bool) {
+	if ir.tagValue != nil && ir.tagValueOffset == t.ValueOffset && len(ir.tagValue) == int(t.Size()) {
+		return ir.tagValue, true
+	}
+	return nil, false
+}
+
+// readValue reads the value of t from the underlying reader and caches it
+// so that it remains available to readTagValue.
+func (ir *ifdReader) readValue(t Tag) ([]byte, error) {
+	if buf, ok := ir.cachedValue(t); ok {
+		return buf, nil
+	}
+	if t.ValueOffset < ir.po {
+		return nil, ErrTagValueUnavailable
+	}
+	if ir.exifLength != 0 && t.ValueOffset+t.Size() > ir.exifLength {
+		return nil, imagetype.ErrDataLength
+	}
+	if err := ir.seekToTag(t); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, t.Size())
+	n, err := io.ReadFull(ir.reader, buf)
+	ir.po += uint32(n)
+	if err != nil {
+		return nil, err
+	}
+	ir.tagValue, ir.tagValueOffset = buf, t.ValueOffset
+	return buf, nil
+}
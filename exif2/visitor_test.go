@@ -0,0 +1,148 @@
+package exif2
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/olympus"
+	"github.com/evanoberholster/imagemeta/tiff"
+)
+
+// decodeWithVisitor decodes the Tiff in name with the TagVisitor v
+func decodeWithVisitor(t *testing.T, name string, v TagVisitor) (Exif, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return decodeReaderWithVisitor(t, f, v)
+}
+
+// decodeReaderWithVisitor decodes the Tiff in r with the TagVisitor v
+func decodeReaderWithVisitor(t *testing.T, r io.ReadSeeker, v TagVisitor) (Exif, error) {
+	h, err := tiff.ScanTiffHeader(r, imagetype.ImageUnknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Seek(int64(h.TiffHeaderOffset), 0); err != nil {
+		t.Fatal(err)
+	}
+	ir := NewIfdReader(Logger)
+	defer ir.Close()
+	ir.SetTagVisitor(v)
+	err = ir.DecodeTiff(bufio.NewReader(r), h)
+	if err == nil {
+		err = ir.VisitErr()
+	}
+	return ir.Exif, err
+}
+
+func TestTagVisitor(t *testing.T) {
+	var count int
+	var model, dateTime string
+	paths := map[string]bool{}
+	e, err := decodeWithVisitor(t, "../testImages/CR2.exif", TagVisitorFn(func(path string, tg Tag, v TagValue) (err error) {
+		count++
+		paths[path] = true
+		switch {
+		case tg.Ifd == ifds.IFD0 && tg.ID == ifds.Model:
+			model, err = v.String()
+		case tg.Ifd == ifds.ExifIFD && tg.ID == exififd.DateTimeOriginal:
+			dateTime, err = v.String()
+		}
+		return err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model == "" || model != e.Model {
+		t.Errorf("Incorrect Model wanted %s got %s", e.Model, model)
+	}
+	// Values read by the visitor remain available to the parser
+	if dateTime == "" || e.DateTimeOriginal().IsZero() {
+		t.Errorf("Incorrect DateTimeOriginal got %s and %v", dateTime, e.DateTimeOriginal())
+	}
+	for _, path := range []string{"Ifd", "Ifd/Exif", "Ifd/Exif/Makernote"} {
+		if !paths[path] {
+			t.Errorf("Wanted tags from %s", path)
+		}
+	}
+
+	// Stop
+	var stopCount int
+	e, err = decodeWithVisitor(t, "../testImages/CR2.exif", TagVisitorFn(func(path string, tg Tag, v TagValue) error {
+		stopCount++
+		if tg.Ifd == ifds.IFD0 && tg.ID == ifds.Model {
+			return ErrStopVisit
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stopCount >= count || e.Model == "" || e.LensModel != "" {
+		t.Errorf("Incorrect stop, visited %d of %d tags", stopCount, count)
+	}
+
+	// Error
+	_, err = decodeWithVisitor(t, "../testImages/CR2.exif", TagVisitorFn(func(path string, tg Tag, v TagValue) error {
+		return ErrTagValueUnavailable
+	}))
+	if err != ErrTagValueUnavailable {
+		t.Errorf("Wanted error %v got %v", ErrTagValueUnavailable, err)
+	}
+
+	if path := IfdPath(NewTag(0, 0, 0, 0, ifds.IFD0, 1, 0)); path != "Ifd1" {
+		t.Errorf("Incorrect IfdPath wanted %s got %s", "Ifd1", path)
+	}
+}
+
+func TestMakerNoteVisitor(t *testing.T) {
+	le := binary.LittleEndian
+	buf := tifftest.MakerNote(le, "OM Digital Solutions", func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("OLYMPUS\x00II\x03\x00"), le, 0, []tifftest.Entry{
+			tifftest.ASCII(0x0207, "OM-1"),                         // CameraType
+			tifftest.SubIfd(0x2010, tifftest.ASCII(0x0100, "OM1")), // Equipment CameraType2
+		})
+	})
+
+	values := map[string]string{}
+	e, err := decodeReaderWithVisitor(t, bytes.NewReader(buf), TagVisitorFn(func(path string, tg Tag, v TagValue) (err error) {
+		if tg.Ifd == ifds.MknoteIFD && tg.Type == tag.TypeASCII {
+			values[path], err = v.String()
+		}
+		return err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["Ifd/Exif/Makernote"] != "OM-1" || values["Ifd/Exif/Makernote/Equipment"] != "OM1" {
+		t.Errorf("Incorrect Makernote tags got %v", values)
+	}
+	if mn, ok := e.Makernotes.(olympus.MakerNote); !ok || mn.CameraType != "OM1" {
+		t.Errorf("Incorrect Makernotes got %v", e.Makernotes)
+	}
+
+	// Stop in a Makernote sub-Ifd
+	e, err = decodeReaderWithVisitor(t, bytes.NewReader(buf), TagVisitorFn(func(path string, tg Tag, v TagValue) error {
+		if path == "Ifd/Exif/Makernote/Equipment" {
+			return ErrStopVisit
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Makernotes != nil {
+		t.Errorf("Incorrect Makernotes wanted nil got %T", e.Makernotes)
+	}
+}
@@ -11,6 +11,7 @@ require (
 	github.com/stretchr/testify v1.8.1
 	github.com/tidwall/pretty v1.2.1
 	github.com/tinylib/msgp v1.1.8
+	golang.org/x/text v0.13.0
 )
 
 require (
@@ -15,6 +15,7 @@ import (
 	"github.com/evanoberholster/imagemeta/meta"
 	"github.com/evanoberholster/imagemeta/png"
 	"github.com/evanoberholster/imagemeta/preview"
+	"github.com/evanoberholster/imagemeta/raf"
 	"github.com/evanoberholster/imagemeta/tiff"
 	"github.com/pkg/errors"
 )
@@ -33,13 +34,41 @@ var readerPool = sync.Pool{
 	New: func() interface{} { return bufio.NewReaderSize(nil, 4*1024) },
 }
 
+// DecodeOptions are the options of DecodeWithOptions.
+type DecodeOptions struct {
+	// Visitor is called with every tag that is parsed, see DecodeWithVisitor.
+	Visitor exif2.TagVisitor
+
+	// RetainTags retains every tag that is read in Exif.Ifds, in addition
+	// to the parsed Exif fields. See exif2.ParseAllTags.
+	RetainTags bool
+}
+
+// Decode decodes the Exif of an image from an io.ReadSeeker returning Exif or an error.
 func Decode(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeWithOptions(r, DecodeOptions{})
+}
+
+// DecodeWithVisitor decodes the Exif of an image like Decode and calls v
+// with every tag that is parsed. If v returns an error other than
+// exif2.ErrStopVisit, the error is returned.
+func DecodeWithVisitor(r io.ReadSeeker, v exif2.TagVisitor) (exif2.Exif, error) {
+	return DecodeWithOptions(r, DecodeOptions{Visitor: v})
+}
+
+// DecodeWithOptions decodes the Exif of an image like Decode with the given
+// DecodeOptions.
+func DecodeWithOptions(r io.ReadSeeker, opts DecodeOptions) (exif2.Exif, error) {
 	rr := readerPool.Get().(*bufio.Reader)
 	rr.Reset(r)
 	defer readerPool.Put(rr)
 
 	ir := exif2.NewIfdReader(exif2.Logger)
 	defer ir.Close()
+	if opts.Visitor != nil {
+		ir.SetTagVisitor(opts.Visitor)
+	}
+	ir.SetRetainTags(opts.RetainTags)
 
 	it, err := imagetype.ScanBuf(rr)
 	if err != nil {
@@ -51,7 +80,7 @@ func Decode(r io.ReadSeeker) (exif2.Exif, error) {
 		if err = jpeg.ScanJPEG(rr, ir.DecodeJPEGIfd, nil); err != nil {
 			return exif2.Exif{}, err
 		}
-	case imagetype.ImageCR2, imagetype.ImageTiff, imagetype.ImagePanaRAW, imagetype.ImageDNG:
+	case imagetype.ImageCR2, imagetype.ImageTiff, imagetype.ImagePanaRAW, imagetype.ImageDNG, imagetype.ImageORF, imagetype.ImagePEF:
 		header, err := tiff.ScanTiffHeader(rr, it)
 		if err != nil {
 			return exif2.Exif{}, err
@@ -70,6 +99,12 @@ func Decode(r io.ReadSeeker) (exif2.Exif, error) {
 			return ir.Exif, err
 		}
 
+	case imagetype.ImageRAF:
+		ir.Exif.RAF, err = raf.ScanRAF(r, ir.DecodeJPEGIfd, nil)
+		ir.Exif.ImageType = it
+		if err != nil {
+			return ir.Exif, err
+		}
 	case imagetype.ImageHEIF:
 		header, err := tiff.ScanTiffHeader(rr, it)
 		if err != nil {
@@ -82,17 +117,26 @@ func Decode(r io.ReadSeeker) (exif2.Exif, error) {
 		return exif2.Exif{}, ErrMetadataNotSupported
 	}
 
-	return ir.Exif, nil
+	return ir.Exif, ir.VisitErr()
 }
 
 // DecodeCR3 decodes a CR3 file from an io.Reader returning Exif or an error.
 func DecodeCR3(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeCR3WithVisitor(r, nil)
+}
+
+// DecodeCR3WithVisitor decodes a CR3 file like DecodeCR3 and calls v with
+// every tag that is parsed.
+func DecodeCR3WithVisitor(r io.ReadSeeker, v exif2.TagVisitor) (exif2.Exif, error) {
 	rr := readerPool.Get().(*bufio.Reader)
 	defer readerPool.Put(rr)
 	rr.Reset(r)
 
 	ir := exif2.NewIfdReader(exif2.Logger)
 	defer ir.Close()
+	if v != nil {
+		ir.SetTagVisitor(v)
+	}
 
 	bmr := isobmff.NewReader(rr)
 	defer bmr.Close()
@@ -106,11 +150,17 @@ func DecodeCR3(r io.ReadSeeker) (exif2.Exif, error) {
 	if err := bmr.ReadMetadata(); err != nil {
 		return ir.Exif, err
 	}
-	return ir.Exif, nil
+	return ir.Exif, ir.VisitErr()
 }
 
 // DecodeTiff decodes a Tiff/DNG file from an io.Reader returning Exif or an error.
 func DecodeTiff(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeTiffWithVisitor(r, nil)
+}
+
+// DecodeTiffWithVisitor decodes a Tiff/DNG file like DecodeTiff and calls v
+// with every tag that is parsed.
+func DecodeTiffWithVisitor(r io.ReadSeeker, v exif2.TagVisitor) (exif2.Exif, error) {
 	rr := readerPool.Get().(*bufio.Reader)
 	rr.Reset(r)
 	defer readerPool.Put(rr)
@@ -125,11 +175,14 @@ func DecodeTiff(r io.ReadSeeker) (exif2.Exif, error) {
 	}
 	ir := exif2.NewIfdReader(exif2.Logger)
 	defer ir.Close()
+	if v != nil {
+		ir.SetTagVisitor(v)
+	}
 
 	if err := ir.DecodeTiff(rr, header); err != nil {
 		return ir.Exif, err
 	}
-	return ir.Exif, nil
+	return ir.Exif, ir.VisitErr()
 	//return exif2.DecodeHeader(r, moov.Meta.Exif[0], moov.Meta.Exif[1], moov.Meta.Exif[3])
 }
 
@@ -138,6 +191,44 @@ func DecodeCR2(r io.ReadSeeker) (exif2.Exif, error) {
 	return DecodeTiff(r)
 }
 
+// DecodeRAF decodes a Fujifilm RAF file from an io.ReadSeeker returning Exif or an error.
+func DecodeRAF(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeRAFWithVisitor(r, nil)
+}
+
+// DecodeRAFWithVisitor decodes a Fujifilm RAF file like DecodeRAF and calls
+// v with every tag that is parsed.
+func DecodeRAFWithVisitor(r io.ReadSeeker, v exif2.TagVisitor) (exif2.Exif, error) {
+	ir := exif2.NewIfdReader(exif2.Logger)
+	defer ir.Close()
+	if v != nil {
+		ir.SetTagVisitor(v)
+	}
+
+	rafInfo, err := raf.ScanRAF(r, ir.DecodeJPEGIfd, nil)
+	ir.Exif.RAF = rafInfo
+	ir.Exif.ImageType = imagetype.ImageRAF
+	if err != nil {
+		return ir.Exif, err
+	}
+	return ir.Exif, ir.VisitErr()
+}
+
+// DecodeORF decodes an Olympus ORF file from an io.Reader returning Exif or an error.
+func DecodeORF(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeTiff(r)
+}
+
+// DecodePEF decodes a Pentax PEF file from an io.Reader returning Exif or an error.
+func DecodePEF(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeTiff(r)
+}
+
+// DecodeRW2 decodes a Panasonic RW2 file from an io.Reader returning Exif or an error.
+func DecodeRW2(r io.ReadSeeker) (exif2.Exif, error) {
+	return DecodeTiff(r)
+}
+
 // DecodeHeif decodes a Heif file from an io.Reader returning Exif or an error.
 // Needs improvement
 func DecodeHeif(r io.ReadSeeker) (exif2.Exif, error) {
@@ -0,0 +1,147 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/exif2"
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/imagetype"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/meta/fujifilm"
+)
+
+func TestDecodeWithVisitor(t *testing.T) {
+	for _, name := range []string{"JPEG.jpg", "CR2.exif"} {
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open("testImages/" + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			var count int
+			var make string
+			e, err := DecodeWithVisitor(f, exif2.TagVisitorFn(func(path string, tg exif2.Tag, v exif2.TagValue) (err error) {
+				count++
+				if path == "Ifd" && tg.ID == ifds.Make {
+					make, err = v.String()
+				}
+				return err
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count == 0 {
+				t.Errorf("No tags visited")
+			}
+			if make != e.Make {
+				t.Errorf("Incorrect Make wanted %s got %s", e.Make, make)
+			}
+		})
+	}
+}
+
+func TestDecodeTiffWithVisitor(t *testing.T) {
+	f, err := os.Open("testImages/CR2.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var mknoteCount int
+	e, err := DecodeTiffWithVisitor(f, exif2.TagVisitorFn(func(path string, tg exif2.Tag, v exif2.TagValue) error {
+		if path == "Ifd/Exif/Makernote" {
+			mknoteCount++
+		}
+		if path == "Ifd" && tg.ID == ifds.Model {
+			return exif2.ErrStopVisit
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Model == "" || mknoteCount != 0 {
+		t.Errorf("Incorrect stop got Model %s and %d Makernote tags", e.Model, mknoteCount)
+	}
+}
+
+// testJPEG returns a JPEG with an Exif that has an IFD0 Make tag and an
+// ExifIFD ExposureTime tag.
+func testJPEG() []byte {
+	le := binary.LittleEndian
+	return tifftest.JPEG(tifftest.Tiff(le, 0x2a, []tifftest.Entry{
+		tifftest.ASCII(ifds.Make, "Leo"),
+		tifftest.SubIfd(ifds.ExifTag, tifftest.Rationals(le, exififd.ExposureTime, 1, 250)),
+	}))
+}
+
+func TestDecodeWithOptions(t *testing.T) {
+	cr2, err := os.ReadFile("testImages/CR2.exif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, buf := range map[string][]byte{"JPEG": testJPEG(), "CR2": cr2} {
+		t.Run(name, func(t *testing.T) {
+			e, err := DecodeWithOptions(bytes.NewReader(buf), DecodeOptions{RetainTags: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			rt, ok := e.Ifds.Tag(ifds.IFD0, 0, ifds.Make)
+			if !ok || e.Make == "" || rt.String() != e.Make {
+				t.Errorf("Incorrect retained Make wanted %s got %s", e.Make, rt.String())
+			}
+			if rt, ok = e.Ifds.Tag(ifds.ExifIFD, 0, exififd.ExposureTime); !ok || len(rt.Rationals()) != 1 {
+				t.Errorf("Incorrect retained ExposureTime got %v", rt.Val)
+			}
+
+			if e, err = Decode(bytes.NewReader(buf)); err != nil || e.Ifds != nil {
+				t.Errorf("Incorrect Decode wanted no retained tags got %d Ifds, %v", len(e.Ifds), err)
+			}
+		})
+	}
+}
+
+func TestDecodeRAF(t *testing.T) {
+	f, err := os.Open("testImages/RAF.raf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, decode := range []func(r io.ReadSeeker) (exif2.Exif, error){Decode, DecodeRAF} {
+		if _, err = f.Seek(0, 0); err != nil {
+			t.Fatal(err)
+		}
+		e, err := decode(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.ImageType != imagetype.ImageRAF || e.CameraMake != ifds.FujiFilm || e.Model != "X-T3" {
+			t.Errorf("Incorrect RAF got %s %s %s", e.ImageType, e.Make, e.Model)
+		}
+		if e.ISOSpeed != 640 {
+			t.Errorf("Incorrect ISOSpeed wanted 640 got %d", e.ISOSpeed)
+		}
+		mn, ok := e.Makernotes.(fujifilm.MakerNote)
+		if !ok {
+			t.Fatalf("Incorrect Makernotes type %T", e.Makernotes)
+		}
+		if mn.FilmSimulation != fujifilm.FilmSimulationEterna || mn.ImageCount != 4242 {
+			t.Errorf("Incorrect Makernotes got %s and %d", mn.FilmSimulation, mn.ImageCount)
+		}
+
+		// RAF Header and Directory
+		if e.RAF.Model != "X-T3" || e.RAF.RawImageFullWidth != 6240 || e.RAF.RawImageFullHeight != 4032 {
+			t.Errorf("Incorrect RAF got %s %dx%d", e.RAF.Model, e.RAF.RawImageFullWidth, e.RAF.RawImageFullHeight)
+		}
+		if !e.RAF.IsXTrans() || e.RAF.CFAPattern[:6] != "BGGRGG" {
+			t.Errorf("Incorrect CFAPattern got %s", e.RAF.CFAPattern)
+		}
+	}
+}
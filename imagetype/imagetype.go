@@ -916,6 +916,9 @@ var (
 	djvuTypeDJVM         = []byte("DJVM")
 	djvuTypeDJVI         = []byte("DJVI")
 	rw2TiffSignature     = []byte{0x49, 0x49, 0x55, 0x00}
+	orfLittleSignature   = []byte("IIRO")
+	orfLittleSignature2  = []byte("IIRS")
+	orfBigSignature      = []byte("MMOR")
 	rw2RawSignature      = []byte{0x88, 0xE7, 0x74, 0xD8}
 	jpegSignature        = []byte{0xFF, 0xD8}
 	pngSignature         = []byte{0x89, 0x50, 0x4E, 0x47}
@@ -1187,6 +1190,12 @@ func isRW2(buf []byte) bool {
 	return hasPrefix(buf, rw2TiffSignature) && hasAt(buf, 8, rw2RawSignature)
 }
 
+// isORF returns true if the first 4 bytes match one of the Olympus ORF
+// alternate Tiff headers "IIRO", "IIRS" or "MMOR"
+func isORF(buf []byte) bool {
+	return hasPrefix(buf, orfLittleSignature) || hasPrefix(buf, orfLittleSignature2) || hasPrefix(buf, orfBigSignature)
+}
+
 func tiffReadU16(buf []byte, offset int, littleEndian bool) (uint16, bool) {
 	if offset < 0 || len(buf) < offset+2 {
 		return 0, false
@@ -1220,6 +1229,11 @@ func tiffSecondarySubtype(buf []byte) FileType {
 		return ImagePanaRAW
 	}
 
+	// ORF has its own TIFF-like signature.
+	if isORF(buf) {
+		return ImageORF
+	}
+
 	if !isTiff(buf) {
 		return ImageUnknown
 	}
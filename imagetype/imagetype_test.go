@@ -305,6 +305,9 @@ func TestBufDetectsAdditionalMagicNumbers(t *testing.T) {
 		{name: "JNG", header: []byte{0x8B, 0x4A, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}, expected: ImageJNG},
 		{name: "FITS", header: []byte("SIMPLE  ="), expected: ImageFITS},
 		{name: "RAF", header: []byte("FUJIFILMCCD-RAW "), expected: ImageRAF},
+		{name: "ORF/LittleEndian", header: []byte("IIRO\x08\x00\x00\x00"), expected: ImageORF},
+		{name: "ORF/LittleEndian2", header: []byte("IIRS\x08\x00\x00\x00"), expected: ImageORF},
+		{name: "ORF/BigEndian", header: []byte("MMOR\x00\x00\x00\x08"), expected: ImageORF},
 		{name: "XCF", header: []byte("gimp xcf "), expected: ImageXCF},
 		{name: "FLIF", header: []byte("FLIF"), expected: ImageFLIF},
 		{name: "BPG", header: []byte{0x42, 0x50, 0x47, 0xFB}, expected: ImageBPG},
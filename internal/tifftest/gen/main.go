@@ -0,0 +1,251 @@
+// Command gen generates the vendor sample images in testImages that are
+// built with tifftest. The images have the layout of the vendor files with
+// small Exif and Makernote Ifds and no image data.
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/fujifilm"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/olympus"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/panasonic"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/mknote/pentax"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/rw2ifd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+	"github.com/evanoberholster/imagemeta/internal/tifftest"
+	"github.com/evanoberholster/imagemeta/raf"
+)
+
+type fixture struct {
+	name string
+	buf  []byte
+}
+
+func main() {
+	out := flag.String("out", defaultOutputPath(), "output directory for generated images")
+	flag.Parse()
+
+	for _, f := range fixtures() {
+		name := filepath.Join(*out, f.name)
+		if err := os.WriteFile(name, f.buf, 0o644); err != nil {
+			panic(err)
+		}
+		fmt.Printf("%-10s %6d bytes -> %s\n", f.name, len(f.buf), name)
+	}
+}
+
+func defaultOutputPath() string {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		return "testImages"
+	}
+	return filepath.Clean(filepath.Join(filepath.Dir(filename), "..", "..", "..", "testImages"))
+}
+
+func fixtures() []fixture {
+	return []fixture{
+		{name: "RAF.raf", buf: rafImage()},
+		{name: "ORF.orf", buf: orfImage()},
+		{name: "RW2.rw2", buf: rw2Image()},
+		{name: "PEF.pef", buf: pefImage()},
+	}
+}
+
+// rafImage returns a Fujifilm X-T3 RAF with an embedded JPEG with Exif and
+// a Fujifilm Makernote, and a RAF directory with an X-Trans layout.
+func rafImage() []byte {
+	le := binary.LittleEndian
+	mkNote := func(offset uint32) []byte {
+		buf := append([]byte("FUJIFILM"), tifftest.U32s(le, 12)...)
+		return tifftest.AppendIfd(buf, le, 0, []tifftest.Entry{
+			tifftest.Bytes(fujifilm.Version, tag.TypeUndefined, []byte("0130")),
+			tifftest.ASCII(fujifilm.InternalSerialNumber, "FF02B4459617 Y55555 2018:09:06 5F3A3A31010A"),
+			tifftest.Shorts(le, fujifilm.ShutterType, 1),
+			tifftest.Shorts(le, fujifilm.DynamicRange, 1),
+			tifftest.Shorts(le, fujifilm.FilmMode, 0x700),
+			tifftest.Bytes(fujifilm.WhiteBalanceFineTune, tag.TypeSignedLong, tifftest.U32s(le, 40, 0xffffffc4)),
+			tifftest.Longs(le, fujifilm.GrainEffectRoughness, 32),
+			tifftest.Longs(le, fujifilm.GrainEffectSize, 16),
+			tifftest.Shorts(le, fujifilm.ImageCount, 0x8000|4242),
+		})
+	}
+	exif := tifftest.Tiff(binary.BigEndian, 0x2a, []tifftest.Entry{
+		tifftest.ASCII(ifds.Make, "FUJIFILM"),
+		tifftest.ASCII(ifds.Model, "X-T3"),
+		tifftest.SubIfd(ifds.ExifTag,
+			tifftest.Rationals(binary.BigEndian, exififd.ExposureTime, 1, 250),
+			tifftest.Shorts(binary.BigEndian, exififd.ISOSpeedRatings, 640),
+			tifftest.Data(exififd.MakerNote, mkNote),
+		),
+	})
+	jpg := tifftest.JPEG(exif)
+
+	be := binary.BigEndian
+	dir := be.AppendUint32(nil, 5)
+	entry := func(id uint16, val []byte) {
+		dir = be.AppendUint16(dir, id)
+		dir = be.AppendUint16(dir, uint16(len(val)))
+		dir = append(dir, val...)
+	}
+	entry(raf.TagRawImageFullSize, tifftest.U16s(be, 4032, 6240))
+	entry(raf.TagCropTopLeft, tifftest.U16s(be, 8, 16))
+	entry(raf.TagCroppedSize, tifftest.U16s(be, 4000, 6000))
+	entry(raf.TagXTransLayout, []byte(strings.Repeat("\x01\x01\x00\x01\x01\x02", 6)))
+	entry(raf.TagWBGRGBLevels, tifftest.U16s(be, 302, 544, 302, 741))
+
+	buf := make([]byte, 0x100)
+	copy(buf, "FUJIFILMCCD-RAW 0201FF129502X-T3")
+	copy(buf[0x3c:], "0100")
+	be.PutUint32(buf[0x54:], uint32(len(buf)))
+	be.PutUint32(buf[0x58:], uint32(len(jpg)))
+	be.PutUint32(buf[0x5c:], uint32(len(buf)+len(jpg)))
+	be.PutUint32(buf[0x60:], uint32(len(dir)))
+	buf = append(buf, jpg...)
+	return append(buf, dir...)
+}
+
+// orfImage returns an Olympus E-M1X ORF with an Olympus Makernote with the
+// Equipment, CameraSettings, RawDevelopment2 and ImageProcessing Ifds.
+func orfImage() []byte {
+	le := binary.LittleEndian
+	subIfd := func(id tag.ID, entries ...tifftest.Entry) tifftest.Entry {
+		e := tifftest.SubIfd(id, entries...)
+		e.Type = 13 // Olympus sub-Ifds are of type Ifd
+		return e
+	}
+	mkNote := func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("OLYMPUS\x00II\x03\x00"), le, 0, []tifftest.Entry{
+			subIfd(olympus.Equipment,
+				tifftest.ASCII(olympus.EquipmentCameraType2, "E-M1X"),
+				tifftest.ASCII(olympus.EquipmentSerialNumber, "BHX209613"),
+				tifftest.Longs(le, olympus.EquipmentBodyFirmwareVersion, 0x1300),
+				tifftest.Bytes(olympus.EquipmentLensType, tag.TypeByte, []byte{0, 0, 0x26, 0x10, 0, 0}),
+				tifftest.ASCII(olympus.EquipmentLensModel, "OLYMPUS M.12-100mm F4.0"),
+			),
+			subIfd(olympus.CameraSettings,
+				tifftest.Shorts(le, olympus.CameraSettingsDriveMode, 1, 3),
+				tifftest.Longs(le, olympus.CameraSettingsImageStabilization, 1),
+			),
+			subIfd(olympus.RawDevelopment2,
+				tifftest.Bytes(olympus.RawDev2ExposureBiasValue, tag.TypeSignedRational, tifftest.U32s(le, 0, 10)),
+				tifftest.Shorts(le, olympus.RawDev2WhiteBalanceValue, 5300),
+				tifftest.Shorts(le, olympus.RawDev2ColorSpace, 0),
+				tifftest.Shorts(le, olympus.RawDev2Engine, 1),
+			),
+			subIfd(olympus.ImageProcessing,
+				tifftest.Shorts(le, olympus.ImageProcessingWB_RBLevels, 498, 420, 256, 256),
+				tifftest.Shorts(le, olympus.ImageProcessingBlackLevel2, 254, 256, 256, 255),
+				tifftest.Shorts(le, olympus.ImageProcessingValidBits, 12, 12),
+				tifftest.Shorts(le, olympus.ImageProcessingCropLeft, 8, 0),
+				tifftest.Shorts(le, olympus.ImageProcessingCropTop, 4, 0),
+				tifftest.Shorts(le, olympus.ImageProcessingCropWidth, 5184, 0),
+				tifftest.Shorts(le, olympus.ImageProcessingCropHeight, 3888, 0),
+			),
+		})
+	}
+	return tifftest.Tiff(le, 0x4f52, []tifftest.Entry{
+		tifftest.ASCII(ifds.Make, "OLYMPUS CORPORATION"),
+		tifftest.ASCII(ifds.Model, "E-M1X"),
+		tifftest.SubIfd(ifds.ExifTag,
+			tifftest.Rationals(le, exififd.ExposureTime, 1, 160),
+			tifftest.Shorts(le, exififd.ISOSpeedRatings, 200),
+			tifftest.Data(exififd.MakerNote, mkNote),
+		),
+	})
+}
+
+// rw2Image returns a Panasonic DC-G9 RW2 with the sensor tags, a CameraIFD
+// and a JpgFromRaw with Exif and a Panasonic Makernote.
+func rw2Image() []byte {
+	le := binary.LittleEndian
+	mkNote := func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("Panasonic\x00\x00\x00"), le, offset, []tifftest.Entry{
+			tifftest.Bytes(panasonic.InternalSerialNumber, tag.TypeUndefined, []byte("F541201130055\x00\x00\x00")),
+			tifftest.Shorts(le, panasonic.BurstMode, 3),
+			tifftest.Longs(le, panasonic.SequenceNumber, 7),
+			tifftest.ASCII(panasonic.LensType, "LUMIX G VARIO 12-60/F3.5-5.6"),
+			tifftest.Shorts(le, panasonic.ShutterType, 0),
+		})
+	}
+	jpg := tifftest.JPEG(tifftest.Tiff(le, 0x2a, []tifftest.Entry{
+		tifftest.ASCII(ifds.Make, "Panasonic"),
+		tifftest.ASCII(ifds.Model, "DC-G9"),
+		tifftest.SubIfd(ifds.ExifTag,
+			tifftest.Rationals(le, exififd.ExposureTime, 1, 200),
+			tifftest.Shorts(le, exififd.ISOSpeedRatings, 200),
+			tifftest.Data(exififd.MakerNote, mkNote),
+		),
+	}))
+	// The CameraIFD is a Tiff Header and Ifd with offsets relative to the
+	// CameraIFD. Its tags are not decoded.
+	cameraIFD := func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte{'I', 'I', 0x2a, 0x00, 0x08, 0x00, 0x00, 0x00}, le, 0, []tifftest.Entry{
+			tifftest.Shorts(le, 0x1101, 46),
+			tifftest.Longs(le, 0x3200, 1024, 2048),
+		})
+	}
+	return tifftest.RW2([]tifftest.Entry{
+		tifftest.Bytes(rw2ifd.PanasonicRawVersion, tag.TypeUndefined, []byte("0312")),
+		tifftest.Shorts(le, rw2ifd.SensorWidth, 5248),
+		tifftest.Shorts(le, rw2ifd.SensorHeight, 3920),
+		tifftest.Shorts(le, rw2ifd.SensorTopBorder, 4),
+		tifftest.Shorts(le, rw2ifd.SensorLeftBorder, 8),
+		tifftest.Shorts(le, rw2ifd.SensorBottomBorder, 3892),
+		tifftest.Shorts(le, rw2ifd.SensorRightBorder, 5192),
+		tifftest.Shorts(le, rw2ifd.CFAPattern, 4),
+		tifftest.Shorts(le, rw2ifd.BitsPerSample, 12),
+		tifftest.Shorts(le, rw2ifd.Compression, 34316),
+		tifftest.Shorts(le, rw2ifd.ISO, 200),
+		tifftest.Shorts(le, rw2ifd.BlackLevelRed, 143),
+		tifftest.Shorts(le, rw2ifd.BlackLevelGreen, 144),
+		tifftest.Shorts(le, rw2ifd.BlackLevelBlue, 145),
+		tifftest.Shorts(le, rw2ifd.WBRedLevel, 1960),
+		tifftest.Shorts(le, rw2ifd.WBGreenLevel, 1024),
+		tifftest.Shorts(le, rw2ifd.WBBlueLevel, 1698),
+		tifftest.Data(rw2ifd.JpgFromRaw, func(uint32) []byte { return jpg }),
+		tifftest.ASCII(rw2ifd.Make, "Panasonic"),
+		tifftest.ASCII(rw2ifd.Model, "DC-G9"),
+		tifftest.Shorts(le, rw2ifd.Orientation, 1),
+		tifftest.Longs(le, rw2ifd.RawDataOffset, 0x1000),
+		tifftest.Data(rw2ifd.CameraIFD, cameraIFD),
+	})
+}
+
+// pefImage returns a Pentax K-3 PEF with an "AOC\0" Pentax Makernote with
+// an encoded ShutterCount of 48213.
+func pefImage() []byte {
+	be := binary.BigEndian
+	date := []byte{0x07, 0xea, 0x0a, 0x13} // 2026:10:19
+	time := []byte{0x0c, 0x1e, 0x2d}       // 12:30:45
+	t := uint32(time[0])<<24 | uint32(time[1])<<16 | uint32(time[2])<<8
+	shutterCount := be.AppendUint32(nil, 48213^be.Uint32(date)^^t)
+	mkNote := func(offset uint32) []byte {
+		return tifftest.AppendIfd([]byte("AOC\x00MM"), be, offset, []tifftest.Entry{
+			tifftest.Bytes(pentax.Date, tag.TypeUndefined, date),
+			tifftest.Bytes(pentax.Time, tag.TypeUndefined, time),
+			tifftest.Shorts(be, pentax.AFPointSelected, 6),
+			tifftest.Bytes(pentax.LensRec, tag.TypeUndefined, []byte{4, 252, 0, 0}),
+			tifftest.Bytes(pentax.ShakeReductionInfo, tag.TypeUndefined, []byte{1, 1, 0, 0}),
+			tifftest.Bytes(pentax.ShutterCount, tag.TypeUndefined, shutterCount),
+			tifftest.ASCII(pentax.SerialNumber, "4250917"),
+			tifftest.Bytes(pentax.LevelInfo, tag.TypeUndefined, []byte{1, 0xfc, 0x02, 0}),
+		})
+	}
+	return tifftest.Tiff(be, 0x2a, []tifftest.Entry{
+		tifftest.ASCII(ifds.Make, "PENTAX"),
+		tifftest.ASCII(ifds.Model, "PENTAX K-3"),
+		tifftest.SubIfd(ifds.ExifTag,
+			tifftest.Rationals(be, exififd.ExposureTime, 1, 125),
+			tifftest.Shorts(be, exififd.ISOSpeedRatings, 100),
+			tifftest.Data(exififd.MakerNote, mkNote),
+		),
+	})
+}
@@ -0,0 +1,174 @@
+// Package tifftest builds Tiff Ifds, Makernotes and JPEG images for tests.
+package tifftest
+
+import (
+	"encoding/binary"
+
+	"github.com/evanoberholster/imagemeta/exif2/ifds"
+	"github.com/evanoberholster/imagemeta/exif2/ifds/exififd"
+	"github.com/evanoberholster/imagemeta/exif2/tag"
+)
+
+// padding is appended to Tiff and JPEG images so that header and marker
+// scans can peek past the end of the image.
+const padding = 64
+
+// ByteOrder is binary.LittleEndian or binary.BigEndian
+type ByteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+// Entry is an Ifd entry. Values of up to 4 bytes are embedded in the entry
+// and longer values are written following the Ifd.
+type Entry struct {
+	// Data returns the value of an Entry written at offset. It is used for
+	// values that depend on their offset, ex: Makernotes.
+	Data  func(offset uint32) []byte
+	Value []byte
+	Ifd   []Entry // sub-Ifd written following the Ifd values
+	Count uint32
+	ID    tag.ID
+	Type  tag.Type
+}
+
+// ASCII returns an ASCII Entry with a NUL terminated s
+func ASCII(id tag.ID, s string) Entry {
+	return Entry{ID: id, Type: tag.TypeASCII, Count: uint32(len(s) + 1), Value: append([]byte(s), 0)}
+}
+
+// Bytes returns an Entry of type t with the value buf
+func Bytes(id tag.ID, t tag.Type, buf []byte) Entry {
+	count := uint32(len(buf))
+	if s := t.Size(); s > 1 {
+		count /= uint32(s)
+	}
+	return Entry{ID: id, Type: t, Count: count, Value: buf}
+}
+
+// Shorts returns a Short Entry
+func Shorts(bo ByteOrder, id tag.ID, v ...uint16) Entry {
+	return Bytes(id, tag.TypeShort, U16s(bo, v...))
+}
+
+// Longs returns a Long Entry
+func Longs(bo ByteOrder, id tag.ID, v ...uint32) Entry {
+	return Bytes(id, tag.TypeLong, U32s(bo, v...))
+}
+
+// Rationals returns a Rational Entry from numerator and denominator pairs
+func Rationals(bo ByteOrder, id tag.ID, v ...uint32) Entry {
+	return Bytes(id, tag.TypeRational, U32s(bo, v...))
+}
+
+// SubIfd returns a Long Entry with the offset of the sub-Ifd entries
+func SubIfd(id tag.ID, entries ...Entry) Entry {
+	return Entry{ID: id, Type: tag.TypeLong, Count: 1, Ifd: entries}
+}
+
+// Data returns an Undefined Entry with the value returned by fn
+func Data(id tag.ID, fn func(offset uint32) []byte) Entry {
+	return Entry{ID: id, Type: tag.TypeUndefined, Data: fn}
+}
+
+// U16s returns v as bytes
+func U16s(bo ByteOrder, v ...uint16) (buf []byte) {
+	for _, u := range v {
+		buf = bo.AppendUint16(buf, u)
+	}
+	return buf
+}
+
+// U32s returns v as bytes
+func U32s(bo ByteOrder, v ...uint32) (buf []byte) {
+	for _, u := range v {
+		buf = bo.AppendUint32(buf, u)
+	}
+	return buf
+}
+
+// AppendIfd appends an Ifd with entries to buf, followed by the values of
+// the entries and their sub-Ifds. offset is the offset of buf[0] from the
+// offset that value offsets are relative to.
+func AppendIfd(buf []byte, bo ByteOrder, offset uint32, entries []Entry) []byte {
+	start := len(buf)
+	buf = bo.AppendUint16(buf, uint16(len(entries)))
+	buf = append(buf, make([]byte, 12*len(entries)+4)...)
+	var subIfds []int
+	for i, e := range entries {
+		header := buf[start+2+12*i:]
+		bo.PutUint16(header, uint16(e.ID))
+		bo.PutUint16(header[2:], uint16(e.Type))
+		switch {
+		case e.Ifd != nil:
+			subIfds = append(subIfds, i)
+		case e.Data != nil:
+			value := e.Data(offset + uint32(len(buf)))
+			e.Count = uint32(len(value))
+			bo.PutUint32(header[8:], offset+uint32(len(buf)))
+			buf = appendValue(buf, value)
+		case len(e.Value) <= 4:
+			copy(header[8:12], e.Value)
+		default:
+			bo.PutUint32(header[8:], offset+uint32(len(buf)))
+			buf = appendValue(buf, e.Value)
+		}
+		bo.PutUint32(buf[start+2+12*i+4:], e.Count)
+	}
+	for _, i := range subIfds {
+		bo.PutUint32(buf[start+2+12*i+8:], offset+uint32(len(buf)))
+		buf = AppendIfd(buf, bo, offset, entries[i].Ifd)
+	}
+	return buf
+}
+
+// appendValue appends value to buf aligned to an even offset
+func appendValue(buf []byte, value []byte) []byte {
+	buf = append(buf, value...)
+	if len(value)%2 == 1 {
+		buf = append(buf, 0)
+	}
+	return buf
+}
+
+// Tiff returns a Tiff with the magic number and IFD0 entries. ex: 0x2a for
+// Tiff and 0x4f52 ("RO") for Olympus ORF.
+func Tiff(bo ByteOrder, magic uint16, ifd0 []Entry) []byte {
+	buf := []byte("II")
+	if bo == binary.BigEndian {
+		buf = []byte("MM")
+	}
+	buf = bo.AppendUint16(buf, magic)
+	buf = bo.AppendUint32(buf, 8)
+	buf = AppendIfd(buf, bo, 0, ifd0)
+	return append(buf, make([]byte, padding)...)
+}
+
+// RW2 returns a little endian Panasonic RW2 with the IFD0 entries following
+// the 24 byte RW2 header.
+func RW2(ifd0 []Entry) []byte {
+	buf := []byte{'I', 'I', 'U', 0x00, 0x18, 0x00, 0x00, 0x00, 0x88, 0xe7, 0x74, 0xd8}
+	buf = append(buf, make([]byte, 12)...)
+	buf = AppendIfd(buf, binary.LittleEndian, 0, ifd0)
+	return append(buf, make([]byte, padding)...)
+}
+
+// MakerNote returns a Tiff with an IFD0 Make tag and an ExifIFD with the
+// exif entries and a MakerNote. mkNote is called with the offset of the
+// MakerNote from the beginning of the Tiff Header.
+func MakerNote(bo ByteOrder, cameraMake string, mkNote func(offset uint32) []byte, exif ...Entry) []byte {
+	return Tiff(bo, 0x2a, []Entry{
+		ASCII(ifds.Make, cameraMake),
+		SubIfd(ifds.ExifTag, append(exif, Data(exififd.MakerNote, mkNote))...),
+	})
+}
+
+// JPEG returns a JPEG with an APP1 Exif segment with the Tiff exif
+func JPEG(exif []byte) []byte {
+	buf := []byte{0xff, 0xd8, 0xff, 0xe1}
+	buf = binary.BigEndian.AppendUint16(buf, uint16(2+6+len(exif)))
+	buf = append(buf, "Exif\x00\x00"...)
+	buf = append(buf, exif...)
+	buf = append(buf, 0xff, 0xdb, 0x00, 0x04, 0x00, 0x00, 0xff, 0xd9)
+	return append(buf, make([]byte, padding)...)
+}
@@ -0,0 +1,594 @@
+package meta
+
+import (
+	"fmt"
+	"math"
+)
+
+// ExifIFD values
+// Derived from https://exiftool.org/TagNames/EXIF.html (19/10/2026)
+
+// LightSource is the kind of light source.
+//
+// ExifIFD / 0x9208
+type LightSource uint16
+
+// Light Sources
+const (
+	LightSourceUnknown              LightSource = 0
+	LightSourceDaylight             LightSource = 1
+	LightSourceFluorescent          LightSource = 2
+	LightSourceTungsten             LightSource = 3
+	LightSourceFlash                LightSource = 4
+	LightSourceFineWeather          LightSource = 9
+	LightSourceCloudy               LightSource = 10
+	LightSourceShade                LightSource = 11
+	LightSourceDaylightFluorescent  LightSource = 12
+	LightSourceDayWhiteFluorescent  LightSource = 13
+	LightSourceCoolWhiteFluorescent LightSource = 14
+	LightSourceWhiteFluorescent     LightSource = 15
+	LightSourceWarmWhiteFluorescent LightSource = 16
+	LightSourceStandardLightA       LightSource = 17
+	LightSourceStandardLightB       LightSource = 18
+	LightSourceStandardLightC       LightSource = 19
+	LightSourceD55                  LightSource = 20
+	LightSourceD65                  LightSource = 21
+	LightSourceD75                  LightSource = 22
+	LightSourceD50                  LightSource = 23
+	LightSourceISOStudioTungsten    LightSource = 24
+	LightSourceOther                LightSource = 255
+)
+
+var (
+	mapLightSourceString = map[LightSource]string{
+		LightSourceUnknown:              "Unknown",
+		LightSourceDaylight:             "Daylight",
+		LightSourceFluorescent:          "Fluorescent",
+		LightSourceTungsten:             "Tungsten (Incandescent)",
+		LightSourceFlash:                "Flash",
+		LightSourceFineWeather:          "Fine Weather",
+		LightSourceCloudy:               "Cloudy",
+		LightSourceShade:                "Shade",
+		LightSourceDaylightFluorescent:  "Daylight Fluorescent",
+		LightSourceDayWhiteFluorescent:  "Day White Fluorescent",
+		LightSourceCoolWhiteFluorescent: "Cool White Fluorescent",
+		LightSourceWhiteFluorescent:     "White Fluorescent",
+		LightSourceWarmWhiteFluorescent: "Warm White Fluorescent",
+		LightSourceStandardLightA:       "Standard Light A",
+		LightSourceStandardLightB:       "Standard Light B",
+		LightSourceStandardLightC:       "Standard Light C",
+		LightSourceD55:                  "D55",
+		LightSourceD65:                  "D65",
+		LightSourceD75:                  "D75",
+		LightSourceD50:                  "D50",
+		LightSourceISOStudioTungsten:    "ISO Studio Tungsten",
+		LightSourceOther:                "Other",
+	}
+	mapStringLightSource = map[string]LightSource{}
+)
+
+func init() {
+	for k, v := range mapLightSourceString {
+		mapStringLightSource[v] = k
+	}
+}
+
+// String returns a LightSource as a string
+func (ls LightSource) String() string {
+	if str, ok := mapLightSourceString[ls]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown (%d)", ls)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ls LightSource) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(ls.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (ls *LightSource) UnmarshalText(text []byte) (err error) {
+	*ls = mapStringLightSource[string(text)]
+	return nil
+}
+
+// SceneType is the type of scene.
+//
+// ExifIFD / 0xa301
+//
+//	1: "Directly photographed",
+type SceneType uint8
+
+// Scene Types
+const (
+	SceneTypeUnknown              SceneType = 0
+	SceneTypeDirectlyPhotographed SceneType = 1
+)
+
+// String returns a SceneType as a string
+func (st SceneType) String() string {
+	switch st {
+	case SceneTypeUnknown:
+		return "Unknown"
+	case SceneTypeDirectlyPhotographed:
+		return "Directly photographed"
+	}
+	return fmt.Sprintf("Unknown (%d)", st)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (st SceneType) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(st.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (st *SceneType) UnmarshalText(text []byte) (err error) {
+	*st = SceneTypeUnknown
+	if string(text) == SceneTypeDirectlyPhotographed.String() {
+		*st = SceneTypeDirectlyPhotographed
+	}
+	return nil
+}
+
+// NewApertureFromAPEX returns an Aperture from an APEX aperture value
+// ex: ApertureValue (0x9202) and MaxApertureValue (0x9205).
+// The Aperture is rounded to 2 decimal places.
+func NewApertureFromAPEX(v float64) Aperture {
+	return Aperture(math.Round(math.Pow(math.Sqrt2, v)*100) / 100)
+}
+
+// NewExposureTimeFromAPEX returns an ExposureTime from an APEX shutter
+// speed value ex: ShutterSpeedValue (0x9201).
+func NewExposureTimeFromAPEX(v float64) ExposureTime {
+	return ExposureTime(math.Pow(2, -v))
+}
+
+// WhiteBalance is the white balance mode of the image.
+//
+// ExifIFD / 0xa403
+//
+//	0: "Auto",
+//	1: "Manual",
+type WhiteBalance uint16
+
+// WhiteBalance values
+const (
+	WhiteBalanceAuto WhiteBalance = iota
+	WhiteBalanceManual
+
+	// WhiteBalance Stringer
+	_WhiteBalanceName = "AutoManual"
+)
+
+var (
+	_WhiteBalanceIndex    = [...]uint8{0, 4, 10}
+	mapStringWhiteBalance = map[string]WhiteBalance{
+		"Auto":   WhiteBalanceAuto,
+		"Manual": WhiteBalanceManual,
+	}
+)
+
+// NewWhiteBalance returns a WhiteBalance from the given uint16
+func NewWhiteBalance(wb uint16) WhiteBalance {
+	return WhiteBalance(wb)
+}
+
+// String returns a WhiteBalance as a string
+func (wb WhiteBalance) String() string {
+	if int(wb) < len(_WhiteBalanceIndex)-1 {
+		return _WhiteBalanceName[_WhiteBalanceIndex[wb]:_WhiteBalanceIndex[wb+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", wb)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (wb WhiteBalance) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(wb.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (wb *WhiteBalance) UnmarshalText(text []byte) (err error) {
+	*wb = mapStringWhiteBalance[string(text)]
+	return nil
+}
+
+// SceneCaptureType is the type of scene that was shot.
+//
+// ExifIFD / 0xa406
+//
+//	0: "Standard",
+//	1: "Landscape",
+//	2: "Portrait",
+//	3: "Night",
+//	4: "Other",
+type SceneCaptureType uint16
+
+// SceneCaptureType values
+const (
+	SceneCaptureTypeStandard SceneCaptureType = iota
+	SceneCaptureTypeLandscape
+	SceneCaptureTypePortrait
+	SceneCaptureTypeNight
+	SceneCaptureTypeOther
+
+	// SceneCaptureType Stringer
+	_SceneCaptureTypeName = "StandardLandscapePortraitNightOther"
+)
+
+var (
+	_SceneCaptureTypeIndex    = [...]uint8{0, 8, 17, 25, 30, 35}
+	mapStringSceneCaptureType = map[string]SceneCaptureType{
+		"Standard":  SceneCaptureTypeStandard,
+		"Landscape": SceneCaptureTypeLandscape,
+		"Portrait":  SceneCaptureTypePortrait,
+		"Night":     SceneCaptureTypeNight,
+		"Other":     SceneCaptureTypeOther,
+	}
+)
+
+// NewSceneCaptureType returns a SceneCaptureType from the given uint16
+func NewSceneCaptureType(sct uint16) SceneCaptureType {
+	return SceneCaptureType(sct)
+}
+
+// String returns a SceneCaptureType as a string
+func (sct SceneCaptureType) String() string {
+	if int(sct) < len(_SceneCaptureTypeIndex)-1 {
+		return _SceneCaptureTypeName[_SceneCaptureTypeIndex[sct]:_SceneCaptureTypeIndex[sct+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", sct)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (sct SceneCaptureType) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(sct.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (sct *SceneCaptureType) UnmarshalText(text []byte) (err error) {
+	*sct = mapStringSceneCaptureType[string(text)]
+	return nil
+}
+
+// Contrast is the direction of contrast processing applied by the camera.
+//
+// ExifIFD / 0xa408
+//
+//	0: "Normal",
+//	1: "Low",
+//	2: "High",
+type Contrast uint16
+
+// Contrast values
+const (
+	ContrastNormal Contrast = iota
+	ContrastLow
+	ContrastHigh
+
+	// Contrast Stringer
+	_ContrastName = "NormalLowHigh"
+)
+
+var (
+	_ContrastIndex    = [...]uint8{0, 6, 9, 13}
+	mapStringContrast = map[string]Contrast{
+		"Normal": ContrastNormal,
+		"Low":    ContrastLow,
+		"High":   ContrastHigh,
+	}
+)
+
+// NewContrast returns a Contrast from the given uint16
+func NewContrast(c uint16) Contrast {
+	return Contrast(c)
+}
+
+// String returns a Contrast as a string
+func (c Contrast) String() string {
+	if int(c) < len(_ContrastIndex)-1 {
+		return _ContrastName[_ContrastIndex[c]:_ContrastIndex[c+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", c)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (c Contrast) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(c.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (c *Contrast) UnmarshalText(text []byte) (err error) {
+	*c = mapStringContrast[string(text)]
+	return nil
+}
+
+// Saturation is the direction of saturation processing applied by the camera.
+//
+// ExifIFD / 0xa409
+//
+//	0: "Normal",
+//	1: "Low",
+//	2: "High",
+type Saturation uint16
+
+// Saturation values
+const (
+	SaturationNormal Saturation = iota
+	SaturationLow
+	SaturationHigh
+
+	// Saturation Stringer
+	_SaturationName = "NormalLowHigh"
+)
+
+var (
+	_SaturationIndex    = [...]uint8{0, 6, 9, 13}
+	mapStringSaturation = map[string]Saturation{
+		"Normal": SaturationNormal,
+		"Low":    SaturationLow,
+		"High":   SaturationHigh,
+	}
+)
+
+// NewSaturation returns a Saturation from the given uint16
+func NewSaturation(s uint16) Saturation {
+	return Saturation(s)
+}
+
+// String returns a Saturation as a string
+func (s Saturation) String() string {
+	if int(s) < len(_SaturationIndex)-1 {
+		return _SaturationName[_SaturationIndex[s]:_SaturationIndex[s+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", s)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (s Saturation) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(s.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (s *Saturation) UnmarshalText(text []byte) (err error) {
+	*s = mapStringSaturation[string(text)]
+	return nil
+}
+
+// Sharpness is the direction of sharpness processing applied by the camera.
+//
+// ExifIFD / 0xa40a
+//
+//	0: "Normal",
+//	1: "Soft",
+//	2: "Hard",
+type Sharpness uint16
+
+// Sharpness values
+const (
+	SharpnessNormal Sharpness = iota
+	SharpnessSoft
+	SharpnessHard
+
+	// Sharpness Stringer
+	_SharpnessName = "NormalSoftHard"
+)
+
+var (
+	_SharpnessIndex    = [...]uint8{0, 6, 10, 14}
+	mapStringSharpness = map[string]Sharpness{
+		"Normal": SharpnessNormal,
+		"Soft":   SharpnessSoft,
+		"Hard":   SharpnessHard,
+	}
+)
+
+// NewSharpness returns a Sharpness from the given uint16
+func NewSharpness(s uint16) Sharpness {
+	return Sharpness(s)
+}
+
+// String returns a Sharpness as a string
+func (s Sharpness) String() string {
+	if int(s) < len(_SharpnessIndex)-1 {
+		return _SharpnessName[_SharpnessIndex[s]:_SharpnessIndex[s+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", s)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (s Sharpness) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(s.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (s *Sharpness) UnmarshalText(text []byte) (err error) {
+	*s = mapStringSharpness[string(text)]
+	return nil
+}
+
+// SubjectDistanceRange is the distance range to the subject.
+//
+// ExifIFD / 0xa40c
+//
+//	0: "Unknown",
+//	1: "Macro",
+//	2: "Close",
+//	3: "Distant",
+type SubjectDistanceRange uint16
+
+// SubjectDistanceRange values
+const (
+	SubjectDistanceRangeUnknown SubjectDistanceRange = iota
+	SubjectDistanceRangeMacro
+	SubjectDistanceRangeClose
+	SubjectDistanceRangeDistant
+
+	// SubjectDistanceRange Stringer
+	_SubjectDistanceRangeName = "UnknownMacroCloseDistant"
+)
+
+var (
+	_SubjectDistanceRangeIndex    = [...]uint8{0, 7, 12, 17, 24}
+	mapStringSubjectDistanceRange = map[string]SubjectDistanceRange{
+		"Unknown": SubjectDistanceRangeUnknown,
+		"Macro":   SubjectDistanceRangeMacro,
+		"Close":   SubjectDistanceRangeClose,
+		"Distant": SubjectDistanceRangeDistant,
+	}
+)
+
+// NewSubjectDistanceRange returns a SubjectDistanceRange from the given uint16
+func NewSubjectDistanceRange(sdr uint16) SubjectDistanceRange {
+	return SubjectDistanceRange(sdr)
+}
+
+// String returns a SubjectDistanceRange as a string
+func (sdr SubjectDistanceRange) String() string {
+	if int(sdr) < len(_SubjectDistanceRangeIndex)-1 {
+		return _SubjectDistanceRangeName[_SubjectDistanceRangeIndex[sdr]:_SubjectDistanceRangeIndex[sdr+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", sdr)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (sdr SubjectDistanceRange) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(sdr.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (sdr *SubjectDistanceRange) UnmarshalText(text []byte) (err error) {
+	*sdr = mapStringSubjectDistanceRange[string(text)]
+	return nil
+}
+
+// SensitivityType is the parameter used for the ISOSpeedRatings (0x8827).
+//
+// ExifIFD / 0x8830
+//
+//	0: "Unknown",
+//	1: "Standard Output Sensitivity",
+//	2: "Recommended Exposure Index",
+//	3: "ISO Speed",
+//	4: "Standard Output Sensitivity and Recommended Exposure Index",
+//	5: "Standard Output Sensitivity and ISO Speed",
+//	6: "Recommended Exposure Index and ISO Speed",
+//	7: "Standard Output Sensitivity, Recommended Exposure Index and ISO Speed",
+type SensitivityType uint16
+
+// SensitivityType values
+const (
+	SensitivityTypeUnknown SensitivityType = iota
+	SensitivityTypeSOS
+	SensitivityTypeREI
+	SensitivityTypeISOSpeed
+	SensitivityTypeSOSAndREI
+	SensitivityTypeSOSAndISOSpeed
+	SensitivityTypeREIAndISOSpeed
+	SensitivityTypeSOSAndREIAndISOSpeed
+
+	// SensitivityType Stringer
+	_SensitivityTypeName = "UnknownStandard Output SensitivityRecommended Exposure IndexISO SpeedStandard Output Sensitivity and Recommended Exposure IndexStandard Output Sensitivity and ISO SpeedRecommended Exposure Index and ISO SpeedStandard Output Sensitivity, Recommended Exposure Index and ISO Speed"
+)
+
+var (
+	_SensitivityTypeIndex    = [...]uint16{0, 7, 34, 60, 69, 127, 168, 208, 277}
+	mapStringSensitivityType = map[string]SensitivityType{
+		"Unknown":                     SensitivityTypeUnknown,
+		"Standard Output Sensitivity": SensitivityTypeSOS,
+		"Recommended Exposure Index":  SensitivityTypeREI,
+		"ISO Speed":                   SensitivityTypeISOSpeed,
+		"Standard Output Sensitivity and Recommended Exposure Index":            SensitivityTypeSOSAndREI,
+		"Standard Output Sensitivity and ISO Speed":                             SensitivityTypeSOSAndISOSpeed,
+		"Recommended Exposure Index and ISO Speed":                              SensitivityTypeREIAndISOSpeed,
+		"Standard Output Sensitivity, Recommended Exposure Index and ISO Speed": SensitivityTypeSOSAndREIAndISOSpeed,
+	}
+)
+
+// NewSensitivityType returns a SensitivityType from the given uint16
+func NewSensitivityType(st uint16) SensitivityType {
+	return SensitivityType(st)
+}
+
+// String returns a SensitivityType as a string
+func (st SensitivityType) String() string {
+	if int(st) < len(_SensitivityTypeIndex)-1 {
+		return _SensitivityTypeName[_SensitivityTypeIndex[st]:_SensitivityTypeIndex[st+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", st)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (st SensitivityType) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(st.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (st *SensitivityType) UnmarshalText(text []byte) (err error) {
+	*st = mapStringSensitivityType[string(text)]
+	return nil
+}
+
+// CompositeImage is whether the image is a composite image.
+//
+// ExifIFD / 0xa460
+//
+//	0: "Unknown",
+//	1: "Not a Composite Image",
+//	2: "General Composite Image",
+//	3: "Composite Image Captured While Shooting",
+type CompositeImage uint16
+
+// CompositeImage values
+const (
+	CompositeImageUnknown CompositeImage = iota
+	CompositeImageNotComposite
+	CompositeImageGeneral
+	CompositeImageCapturedWhileShooting
+
+	// CompositeImage Stringer
+	_CompositeImageName = "UnknownNot a Composite ImageGeneral Composite ImageComposite Image Captured While Shooting"
+)
+
+var (
+	_CompositeImageIndex    = [...]uint8{0, 7, 28, 51, 90}
+	mapStringCompositeImage = map[string]CompositeImage{
+		"Unknown":                                 CompositeImageUnknown,
+		"Not a Composite Image":                   CompositeImageNotComposite,
+		"General Composite Image":                 CompositeImageGeneral,
+		"Composite Image Captured While Shooting": CompositeImageCapturedWhileShooting,
+	}
+)
+
+// NewCompositeImage returns a CompositeImage from the given uint16
+func NewCompositeImage(ci uint16) CompositeImage {
+	return CompositeImage(ci)
+}
+
+// String returns a CompositeImage as a string
+func (ci CompositeImage) String() string {
+	if int(ci) < len(_CompositeImageIndex)-1 {
+		return _CompositeImageName[_CompositeImageIndex[ci]:_CompositeImageIndex[ci+1]]
+	}
+	return fmt.Sprintf("Unknown (%d)", ci)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ci CompositeImage) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(ci.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (ci *CompositeImage) UnmarshalText(text []byte) (err error) {
+	*ci = mapStringCompositeImage[string(text)]
+	return nil
+}
@@ -0,0 +1,107 @@
+package meta
+
+import (
+	"encoding"
+	"fmt"
+	"testing"
+)
+
+func TestExifIFDTypes(t *testing.T) {
+	type textValue interface {
+		fmt.Stringer
+		encoding.TextMarshaler
+	}
+	tests := []struct {
+		val textValue
+		str string
+		new func(text []byte) (fmt.Stringer, error)
+	}{
+		{WhiteBalanceManual, "Manual", func(text []byte) (fmt.Stringer, error) {
+			var v WhiteBalance
+			return &v, v.UnmarshalText(text)
+		}},
+		{SceneCaptureTypeNight, "Night", func(text []byte) (fmt.Stringer, error) {
+			var v SceneCaptureType
+			return &v, v.UnmarshalText(text)
+		}},
+		{ContrastHigh, "High", func(text []byte) (fmt.Stringer, error) {
+			var v Contrast
+			return &v, v.UnmarshalText(text)
+		}},
+		{SaturationLow, "Low", func(text []byte) (fmt.Stringer, error) {
+			var v Saturation
+			return &v, v.UnmarshalText(text)
+		}},
+		{SharpnessHard, "Hard", func(text []byte) (fmt.Stringer, error) {
+			var v Sharpness
+			return &v, v.UnmarshalText(text)
+		}},
+		{SubjectDistanceRangeMacro, "Macro", func(text []byte) (fmt.Stringer, error) {
+			var v SubjectDistanceRange
+			return &v, v.UnmarshalText(text)
+		}},
+		{SensitivityTypeSOSAndREI, "Standard Output Sensitivity and Recommended Exposure Index", func(text []byte) (fmt.Stringer, error) {
+			var v SensitivityType
+			return &v, v.UnmarshalText(text)
+		}},
+		{CompositeImageCapturedWhileShooting, "Composite Image Captured While Shooting", func(text []byte) (fmt.Stringer, error) {
+			var v CompositeImage
+			return &v, v.UnmarshalText(text)
+		}},
+		{LightSourceD65, "D65", func(text []byte) (fmt.Stringer, error) {
+			var v LightSource
+			return &v, v.UnmarshalText(text)
+		}},
+		{SceneTypeDirectlyPhotographed, "Directly photographed", func(text []byte) (fmt.Stringer, error) {
+			var v SceneType
+			return &v, v.UnmarshalText(text)
+		}},
+	}
+	for _, v := range tests {
+		if v.val.String() != v.str {
+			t.Errorf("Incorrect String wanted %s got %s", v.str, v.val)
+		}
+		text, err := v.val.MarshalText()
+		if err != nil {
+			t.Error(err)
+		}
+		v2, err := v.new(text)
+		if err != nil {
+			t.Error(err)
+		}
+		if v2.String() != v.str {
+			t.Errorf("Incorrect UnmarshalText wanted %s got %s", v.str, v2)
+		}
+	}
+
+	// Unknown values
+	unknown := []struct {
+		val fmt.Stringer
+		str string
+	}{
+		{NewWhiteBalance(5), "Unknown (5)"},
+		{NewSceneCaptureType(5), "Unknown (5)"},
+		{NewContrast(9), "Unknown (9)"},
+		{NewSaturation(3), "Unknown (3)"},
+		{NewSharpness(300), "Unknown (300)"},
+		{NewSubjectDistanceRange(4), "Unknown (4)"},
+		{NewSensitivityType(8), "Unknown (8)"},
+		{NewCompositeImage(65535), "Unknown (65535)"},
+	}
+	for _, v := range unknown {
+		if v.val.String() != v.str {
+			t.Errorf("Incorrect unknown %T wanted %s got %s", v.val, v.str, v.val)
+		}
+	}
+	if LightSource(5).String() != "Unknown (5)" || SceneType(2).String() != "Unknown (2)" {
+		t.Errorf("Incorrect unknown LightSource %s or SceneType %s", LightSource(5), SceneType(2))
+	}
+
+	// APEX
+	if a := NewApertureFromAPEX(5); a != 5.66 {
+		t.Errorf("Incorrect Aperture from APEX wanted %f got %f", 5.66, a)
+	}
+	if et := NewExposureTimeFromAPEX(7); et.String() != "1/128" {
+		t.Errorf("Incorrect ExposureTime from APEX wanted %s got %s", "1/128", et)
+	}
+}
@@ -152,6 +152,38 @@ func NewExposureBias(n int16, d int16) ExposureBias {
 	return ExposureBias(n + d)
 }
 
+// exposureBiasMaxDenominator is the largest denominator of an approximated
+// Exposure Bias, Exposure Bias is recorded in halves or thirds of a stop.
+const exposureBiasMaxDenominator = 6
+
+// NewExposureBiasFromSRational creates a new Exposure Bias from r. The
+// fraction is reduced to its lowest terms. A fraction that does not fit is
+// approximated by the nearest fraction with a denominator of at most 6,
+// ex: 333333/1000000 is "+1/3".
+func NewExposureBiasFromSRational(r SRational) ExposureBias {
+	if r.Denominator == 0 {
+		return 0
+	}
+	r = r.Reduce()
+	n, d := int64(r.Numerator), int64(r.Denominator)
+	if n < math.MinInt8 || n > math.MaxInt8 || d > math.MaxInt8 {
+		f := r.Float()
+		n, d = int64(math.Round(f)), 1
+		for den := int64(2); den <= exposureBiasMaxDenominator; den++ {
+			num := int64(math.Round(f * float64(den)))
+			if math.Abs(f-float64(num)/float64(den)) < math.Abs(f-float64(n)/float64(d))-1e-9 {
+				n, d = num, den
+			}
+		}
+		if n < math.MinInt8 {
+			n, d = math.MinInt8, 1
+		} else if n > math.MaxInt8 {
+			n, d = math.MaxInt8, 1
+		}
+	}
+	return NewExposureBias(int16(n), int16(d))
+}
+
 // String returns the value of Exposure Bias as a string
 func (eb ExposureBias) String() string {
 	buf, _ := eb.MarshalText()
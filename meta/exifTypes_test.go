@@ -202,6 +202,33 @@ func TestExposureBias(t *testing.T) {
 	if eb2.String() != "+"+str {
 		t.Errorf("NewExposureBias #%s, wanted %s got %s", "Unsigned test", eb2.String(), "+"+str)
 	}
+
+	// From SRational
+	for _, tt := range []struct {
+		r   SRational
+		str string
+	}{
+		{NewSRational(-2, 3), "-2/3"},
+		{NewSRational(-20, 30), "-2/3"},
+		{NewSRational(1000000, 3000000), "+1/3"},
+		{NewSRational(333333, 1000000), "+1/3"},
+		{NewSRational(-1500, 1000), "-3/2"},
+		{NewSRational(1, 1000000), "+0/1"},
+		{NewSRational(0, 1), "+0/1"},
+		{NewSRational(0, 0), "0/0"},
+		{NewSRational(1000, 3), "+127/1"},
+		{NewSRational(7, 150), "+0/1"},
+		{NewSRational(-7, 150), "+0/1"},
+		{NewSRational(-1, 200), "+0/1"},
+		{NewSRational(1, 128), "+0/1"},
+		{NewSRational(67, 200), "+1/3"},
+		{NewSRational(-101, 200), "-1/2"},
+		{NewSRational(5, 127), "+5/127"},
+	} {
+		if eb := NewExposureBiasFromSRational(tt.r); eb.String() != tt.str {
+			t.Errorf("NewExposureBiasFromSRational(%s), wanted %s got %s", tt.r, tt.str, eb)
+		}
+	}
 }
 
 func BenchmarkExposureBias(b *testing.B) {
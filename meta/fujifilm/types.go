@@ -0,0 +1,301 @@
+// Package fujifilm provides types for Fujifilm Makernote values
+package fujifilm
+
+import "fmt"
+
+// MakerNote is the decoded Fujifilm Makernote
+type MakerNote struct {
+	Version                 string
+	InternalSerialNumber    string
+	WhiteBalanceFineTune    WhiteBalanceFineTune
+	FilmSimulation          FilmSimulation
+	DynamicRange            DynamicRange
+	DynamicRangeSetting     DynamicRangeSetting
+	DevelopmentDynamicRange uint16 // DevelopmentDynamicRange in percent (100, 200, 400)
+	GrainEffectRoughness    GrainEffect
+	GrainEffectSize         GrainSize
+	ShutterType             ShutterType
+	ImageCount              uint16
+	SequenceNumber          uint16
+	Rating                  uint32
+}
+
+// WhiteBalanceFineTune is the Red and Blue white balance fine tune shift
+// as recorded by the camera in WBFineTune units.
+type WhiteBalanceFineTune struct {
+	Red  WBFineTune
+	Blue WBFineTune
+}
+
+// String returns the WhiteBalanceFineTune in steps, ex: "Red +2, Blue -3"
+func (wb WhiteBalanceFineTune) String() string {
+	return fmt.Sprintf("Red %+d, Blue %+d", wb.Red.Steps(), wb.Blue.Steps())
+}
+
+// WBFineTuneStep is the number of WBFineTune units in a fine tune step
+const WBFineTuneStep = 20
+
+// WBFineTune is a white balance fine tune shift (0x100a) in units of
+// 1/20 of a fine tune step, ex: 40 is a shift of +2 steps.
+type WBFineTune int32
+
+// Steps returns the fine tune shift in steps as set in the camera
+func (wb WBFineTune) Steps() int32 {
+	return int32(wb) / WBFineTuneStep
+}
+
+// FilmSimulation is the Fujifilm Film Simulation. It is derived from the
+// FilmMode tag (0x1401) or, for monochrome simulations, the Saturation tag (0x1003).
+type FilmSimulation uint8
+
+// Film Simulations
+const (
+	FilmSimulationUnknown FilmSimulation = iota
+	FilmSimulationProvia
+	FilmSimulationVelvia
+	FilmSimulationAstia
+	FilmSimulationClassicChrome
+	FilmSimulationProNegHi
+	FilmSimulationProNegStd
+	FilmSimulationClassicNeg
+	FilmSimulationEterna
+	FilmSimulationEternaBleachBypass
+	FilmSimulationNostalgicNeg
+	FilmSimulationRealaAce
+	FilmSimulationStudioPortrait
+	FilmSimulationStudioPortraitEx
+	FilmSimulationMonochrome
+	FilmSimulationMonochromeYe
+	FilmSimulationMonochromeR
+	FilmSimulationMonochromeG
+	FilmSimulationSepia
+	FilmSimulationAcros
+	FilmSimulationAcrosYe
+	FilmSimulationAcrosR
+	FilmSimulationAcrosG
+
+	// FilmSimulationName
+	_FilmSimulationName = "UnknownProviaVelviaAstiaClassic ChromePro Neg. HiPro Neg. StdClassic Neg.EternaEterna Bleach BypassNostalgic Neg.Reala ACEStudio PortraitStudio Portrait ExMonochromeMonochrome+Ye FilterMonochrome+R FilterMonochrome+G FilterSepiaAcrosAcros+Ye FilterAcros+R FilterAcros+G Filter"
+)
+
+// FilmSimulation values
+// Derived from https://exiftool.org/TagNames/FujiFilm.html (19/10/2026)
+var (
+	_FilmSimulationIndex = [...]uint16{0, 7, 13, 19, 24, 38, 49, 61, 73, 79, 99, 113, 122, 137, 155, 165, 185, 204, 223, 228, 233, 248, 262, 276}
+
+	mapFilmModeFilmSimulation = map[uint16]FilmSimulation{
+		0x000: FilmSimulationProvia,
+		0x100: FilmSimulationStudioPortrait,
+		0x110: FilmSimulationStudioPortrait,
+		0x120: FilmSimulationAstia,
+		0x130: FilmSimulationStudioPortrait,
+		0x200: FilmSimulationVelvia,
+		0x300: FilmSimulationStudioPortraitEx,
+		0x400: FilmSimulationVelvia,
+		0x500: FilmSimulationProNegStd,
+		0x501: FilmSimulationProNegHi,
+		0x600: FilmSimulationClassicChrome,
+		0x700: FilmSimulationEterna,
+		0x800: FilmSimulationClassicNeg,
+		0x900: FilmSimulationEternaBleachBypass,
+		0xa00: FilmSimulationNostalgicNeg,
+		0xb00: FilmSimulationRealaAce,
+	}
+
+	mapSaturationFilmSimulation = map[uint16]FilmSimulation{
+		0x300: FilmSimulationMonochrome,
+		0x301: FilmSimulationMonochromeR,
+		0x302: FilmSimulationMonochromeYe,
+		0x303: FilmSimulationMonochromeG,
+		0x310: FilmSimulationSepia,
+		0x500: FilmSimulationAcros,
+		0x501: FilmSimulationAcrosR,
+		0x502: FilmSimulationAcrosYe,
+		0x503: FilmSimulationAcrosG,
+	}
+)
+
+// NewFilmSimulation returns a FilmSimulation from the FilmMode and Saturation
+// tag values. Monochrome Saturation values take precedence over FilmMode.
+func NewFilmSimulation(filmMode uint16, saturation uint16, hasFilmMode bool) FilmSimulation {
+	if fs, ok := mapSaturationFilmSimulation[saturation]; ok {
+		return fs
+	}
+	if hasFilmMode {
+		return mapFilmModeFilmSimulation[filmMode]
+	}
+	return FilmSimulationUnknown
+}
+
+// IsMonochrome returns true if the FilmSimulation is a monochrome simulation
+func (fs FilmSimulation) IsMonochrome() bool {
+	return fs >= FilmSimulationMonochrome && fs <= FilmSimulationAcrosG
+}
+
+// String returns the FilmSimulation as a string
+func (fs FilmSimulation) String() string {
+	if int(fs) < len(_FilmSimulationIndex)-1 {
+		return _FilmSimulationName[_FilmSimulationIndex[fs]:_FilmSimulationIndex[fs+1]]
+	}
+	return _FilmSimulationName[:_FilmSimulationIndex[1]]
+}
+
+// MarshalText implements the TextMarshaler interface
+func (fs FilmSimulation) MarshalText() (text []byte, err error) {
+	return []byte(fs.String()), nil
+}
+
+// DynamicRange is the Fujifilm DynamicRange tag (0x1400)
+//
+//	1: "Standard",
+//	3: "Wide",
+type DynamicRange uint16
+
+// String returns the DynamicRange as a string
+func (dr DynamicRange) String() string {
+	switch dr {
+	case 1:
+		return "Standard"
+	case 3:
+		return "Wide"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (dr DynamicRange) MarshalText() (text []byte, err error) {
+	return []byte(dr.String()), nil
+}
+
+// DynamicRangeSetting is the Fujifilm DynamicRangeSetting tag (0x1402)
+//
+//	0x0:    "Auto",
+//	0x1:    "Manual",
+//	0x100:  "Standard (100%)",
+//	0x200:  "Wide1 (230%)",
+//	0x201:  "Wide2 (400%)",
+//	0x8000: "Film Simulation",
+type DynamicRangeSetting uint16
+
+// String returns the DynamicRangeSetting as a string
+func (drs DynamicRangeSetting) String() string {
+	switch drs {
+	case 0x0:
+		return "Auto"
+	case 0x1:
+		return "Manual"
+	case 0x100:
+		return "Standard (100%)"
+	case 0x200:
+		return "Wide1 (230%)"
+	case 0x201:
+		return "Wide2 (400%)"
+	case 0x8000:
+		return "Film Simulation"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (drs DynamicRangeSetting) MarshalText() (text []byte, err error) {
+	return []byte(drs.String()), nil
+}
+
+// GrainEffect is the Fujifilm GrainEffectRoughness tag (0x1047).
+// Recorded as 0, 32 or 64.
+type GrainEffect uint8
+
+// Grain Effects
+const (
+	GrainEffectOff GrainEffect = iota
+	GrainEffectWeak
+	GrainEffectStrong
+)
+
+// NewGrainEffect returns a GrainEffect from the tag value
+func NewGrainEffect(v uint32) GrainEffect {
+	if v/32 <= uint32(GrainEffectStrong) {
+		return GrainEffect(v / 32)
+	}
+	return GrainEffectOff
+}
+
+// String returns the GrainEffect as a string
+func (ge GrainEffect) String() string {
+	switch ge {
+	case GrainEffectWeak:
+		return "Weak"
+	case GrainEffectStrong:
+		return "Strong"
+	}
+	return "Off"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ge GrainEffect) MarshalText() (text []byte, err error) {
+	return []byte(ge.String()), nil
+}
+
+// GrainSize is the Fujifilm GrainEffectSize tag (0x104c).
+// Recorded as 0, 16 or 32.
+type GrainSize uint8
+
+// Grain Sizes
+const (
+	GrainSizeOff GrainSize = iota
+	GrainSizeSmall
+	GrainSizeLarge
+)
+
+// NewGrainSize returns a GrainSize from the tag value
+func NewGrainSize(v uint32) GrainSize {
+	if v/16 <= uint32(GrainSizeLarge) {
+		return GrainSize(v / 16)
+	}
+	return GrainSizeOff
+}
+
+// String returns the GrainSize as a string
+func (gs GrainSize) String() string {
+	switch gs {
+	case GrainSizeSmall:
+		return "Small"
+	case GrainSizeLarge:
+		return "Large"
+	}
+	return "Off"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (gs GrainSize) MarshalText() (text []byte, err error) {
+	return []byte(gs.String()), nil
+}
+
+// ShutterType is the Fujifilm ShutterType tag (0x1050)
+type ShutterType uint8
+
+// Shutter Types
+const (
+	ShutterTypeMechanical ShutterType = iota
+	ShutterTypeElectronic
+	ShutterTypeElectronicLongShutter
+	ShutterTypeElectronicFrontCurtain
+
+	// ShutterTypeName
+	_ShutterTypeName = "MechanicalElectronicElectronic (long shutter)Electronic Front Curtain"
+)
+
+var _ShutterTypeIndex = [...]uint8{0, 10, 20, 45, 69}
+
+// String returns the ShutterType as a string
+func (st ShutterType) String() string {
+	if int(st) < len(_ShutterTypeIndex)-1 {
+		return _ShutterTypeName[_ShutterTypeIndex[st]:_ShutterTypeIndex[st+1]]
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (st ShutterType) MarshalText() (text []byte, err error) {
+	return []byte(st.String()), nil
+}
@@ -0,0 +1,310 @@
+package meta
+
+import "fmt"
+
+// GPS values are single ASCII characters.
+// Derived from https://exiftool.org/TagNames/GPS.html (19/10/2026)
+
+// GPSSpeedRef is the unit of GPSSpeed (GPS / 0x000c)
+//
+//	'K': "km/h",
+//	'M': "mph",
+//	'N': "knots",
+type GPSSpeedRef uint8
+
+// GPSSpeedRef values
+const (
+	GPSSpeedRefUnknown           GPSSpeedRef = 0
+	GPSSpeedRefKilometersPerHour GPSSpeedRef = 'K'
+	GPSSpeedRefMilesPerHour      GPSSpeedRef = 'M'
+	GPSSpeedRefKnots             GPSSpeedRef = 'N'
+)
+
+// String returns a GPSSpeedRef as a string
+func (ref GPSSpeedRef) String() string {
+	switch ref {
+	case GPSSpeedRefKilometersPerHour:
+		return "km/h"
+	case GPSSpeedRefMilesPerHour:
+		return "mph"
+	case GPSSpeedRefKnots:
+		return "knots"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ref GPSSpeedRef) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(ref.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (ref *GPSSpeedRef) UnmarshalText(text []byte) (err error) {
+	*ref = GPSSpeedRefUnknown
+	for _, v := range []GPSSpeedRef{GPSSpeedRefKilometersPerHour, GPSSpeedRefMilesPerHour, GPSSpeedRefKnots} {
+		if v.String() == string(text) {
+			*ref = v
+		}
+	}
+	return nil
+}
+
+// MetresPerSecond returns speed in the unit of GPSSpeedRef as metres per
+// second. The unit is km/h if the GPSSpeedRef is unknown.
+func (ref GPSSpeedRef) MetresPerSecond(speed float64) float64 {
+	switch ref {
+	case GPSSpeedRefMilesPerHour:
+		return speed * 0.44704
+	case GPSSpeedRefKnots:
+		return speed * 1852 / 3600
+	}
+	return speed / 3.6
+}
+
+// GPSDirectionRef is the reference of a GPS direction for GPSTrack,
+// GPSImgDirection and GPSDestBearing.
+//
+//	'T': "True North",
+//	'M': "Magnetic North",
+type GPSDirectionRef uint8
+
+// GPSDirectionRef values
+const (
+	GPSDirectionRefUnknown       GPSDirectionRef = 0
+	GPSDirectionRefTrueNorth     GPSDirectionRef = 'T'
+	GPSDirectionRefMagneticNorth GPSDirectionRef = 'M'
+)
+
+// String returns a GPSDirectionRef as a string
+func (ref GPSDirectionRef) String() string {
+	switch ref {
+	case GPSDirectionRefTrueNorth:
+		return "True North"
+	case GPSDirectionRefMagneticNorth:
+		return "Magnetic North"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ref GPSDirectionRef) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(ref.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (ref *GPSDirectionRef) UnmarshalText(text []byte) (err error) {
+	switch string(text) {
+	case GPSDirectionRefTrueNorth.String():
+		*ref = GPSDirectionRefTrueNorth
+	case GPSDirectionRefMagneticNorth.String():
+		*ref = GPSDirectionRefMagneticNorth
+	default:
+		*ref = GPSDirectionRefUnknown
+	}
+	return nil
+}
+
+// GPSDistanceRef is the unit of GPSDestDistance (GPS / 0x0019)
+//
+//	'K': "Kilometers",
+//	'M': "Miles",
+//	'N': "Nautical Miles",
+type GPSDistanceRef uint8
+
+// GPSDistanceRef values
+const (
+	GPSDistanceRefUnknown       GPSDistanceRef = 0
+	GPSDistanceRefKilometers    GPSDistanceRef = 'K'
+	GPSDistanceRefMiles         GPSDistanceRef = 'M'
+	GPSDistanceRefNauticalMiles GPSDistanceRef = 'N'
+)
+
+// String returns a GPSDistanceRef as a string
+func (ref GPSDistanceRef) String() string {
+	switch ref {
+	case GPSDistanceRefKilometers:
+		return "Kilometers"
+	case GPSDistanceRefMiles:
+		return "Miles"
+	case GPSDistanceRefNauticalMiles:
+		return "Nautical Miles"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (ref GPSDistanceRef) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(ref.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (ref *GPSDistanceRef) UnmarshalText(text []byte) (err error) {
+	*ref = GPSDistanceRefUnknown
+	for _, v := range []GPSDistanceRef{GPSDistanceRefKilometers, GPSDistanceRefMiles, GPSDistanceRefNauticalMiles} {
+		if v.String() == string(text) {
+			*ref = v
+		}
+	}
+	return nil
+}
+
+// Metres returns distance in the unit of GPSDistanceRef as metres.
+// The unit is kilometers if the GPSDistanceRef is unknown.
+func (ref GPSDistanceRef) Metres(distance float64) float64 {
+	switch ref {
+	case GPSDistanceRefMiles:
+		return distance * 1609.344
+	case GPSDistanceRefNauticalMiles:
+		return distance * 1852
+	}
+	return distance * 1000
+}
+
+// GPSMeasureMode is the GPS measurement mode (GPS / 0x000a)
+//
+//	'2': "2-Dimensional Measurement",
+//	'3': "3-Dimensional Measurement",
+type GPSMeasureMode uint8
+
+// GPSMeasureMode values
+const (
+	GPSMeasureModeUnknown GPSMeasureMode = 0
+	GPSMeasureMode2D      GPSMeasureMode = '2'
+	GPSMeasureMode3D      GPSMeasureMode = '3'
+)
+
+// String returns a GPSMeasureMode as a string
+func (mm GPSMeasureMode) String() string {
+	switch mm {
+	case GPSMeasureMode2D:
+		return "2-Dimensional Measurement"
+	case GPSMeasureMode3D:
+		return "3-Dimensional Measurement"
+	case GPSMeasureModeUnknown:
+		return "Unknown"
+	}
+	return fmt.Sprintf("Unknown (%c)", mm)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (mm GPSMeasureMode) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(mm.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (mm *GPSMeasureMode) UnmarshalText(text []byte) (err error) {
+	switch string(text) {
+	case GPSMeasureMode2D.String():
+		*mm = GPSMeasureMode2D
+	case GPSMeasureMode3D.String():
+		*mm = GPSMeasureMode3D
+	default:
+		*mm = GPSMeasureModeUnknown
+	}
+	return nil
+}
+
+// GPSStatus is the status of the GPS receiver (GPS / 0x0009)
+//
+//	'A': "Measurement Active",
+//	'V': "Measurement Void",
+type GPSStatus uint8
+
+// GPSStatus values
+const (
+	GPSStatusUnknown GPSStatus = 0
+	GPSStatusActive  GPSStatus = 'A'
+	GPSStatusVoid    GPSStatus = 'V'
+)
+
+// String returns a GPSStatus as a string
+func (s GPSStatus) String() string {
+	switch s {
+	case GPSStatusActive:
+		return "Measurement Active"
+	case GPSStatusVoid:
+		return "Measurement Void"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (s GPSStatus) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(s.String()), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json
+func (s *GPSStatus) UnmarshalText(text []byte) (err error) {
+	switch string(text) {
+	case GPSStatusActive.String():
+		*s = GPSStatusActive
+	case GPSStatusVoid.String():
+		*s = GPSStatusVoid
+	default:
+		*s = GPSStatusUnknown
+	}
+	return nil
+}
+
+// CharacterCode is the character code of an Exif string with an 8 byte
+// character code prefix, ex: UserComment and GPSProcessingMethod.
+type CharacterCode uint8
+
+// CharacterCode values
+const (
+	CharacterCodeUndefined CharacterCode = iota
+	CharacterCodeASCII
+	CharacterCodeJIS
+	CharacterCodeUnicode
+)
+
+// CharacterCode prefixes
+const (
+	characterCodePrefixLength = 8
+	characterCodeASCII        = "ASCII\x00\x00\x00"
+	characterCodeJIS          = "JIS\x00\x00\x00\x00\x00"
+	characterCodeUnicode      = "UNICODE\x00"
+)
+
+// NewCharacterCode returns the CharacterCode from the 8 byte prefix of buf
+// and the value following the prefix. If buf does not begin with a known
+// prefix, CharacterCodeUndefined and buf are returned.
+func NewCharacterCode(buf []byte) (CharacterCode, []byte) {
+	if len(buf) < characterCodePrefixLength {
+		return CharacterCodeUndefined, buf
+	}
+	switch string(buf[:characterCodePrefixLength]) {
+	case characterCodeASCII:
+		return CharacterCodeASCII, buf[characterCodePrefixLength:]
+	case characterCodeJIS:
+		return CharacterCodeJIS, buf[characterCodePrefixLength:]
+	case characterCodeUnicode:
+		return CharacterCodeUnicode, buf[characterCodePrefixLength:]
+	case "\x00\x00\x00\x00\x00\x00\x00\x00":
+		return CharacterCodeUndefined, buf[characterCodePrefixLength:]
+	}
+	return CharacterCodeUndefined, buf
+}
+
+// String returns a CharacterCode as a string
+func (cc CharacterCode) String() string {
+	switch cc {
+	case CharacterCodeASCII:
+		return "ASCII"
+	case CharacterCodeJIS:
+		return "JIS"
+	case CharacterCodeUnicode:
+		return "Unicode"
+	}
+	return "Undefined"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (cc CharacterCode) MarshalText() (text []byte, err error) {
+	return unsafeGetBytes(cc.String()), nil
+}
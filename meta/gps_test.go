@@ -0,0 +1,88 @@
+package meta
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGPS(t *testing.T) {
+	speeds := []struct {
+		ref   GPSSpeedRef
+		str   string
+		speed float64
+	}{
+		{GPSSpeedRefKilometersPerHour, "km/h", 10},
+		{GPSSpeedRefMilesPerHour, "mph", 16.09344},
+		{GPSSpeedRefKnots, "knots", 18.52},
+		{GPSSpeedRefUnknown, "Unknown", 10},
+	}
+	for _, v := range speeds {
+		if v.ref.String() != v.str {
+			t.Errorf("Incorrect GPSSpeedRef wanted %s got %s", v.str, v.ref)
+		}
+		if mps := v.ref.MetresPerSecond(36); math.Abs(mps-v.speed) > 1e-6 {
+			t.Errorf("Incorrect %s MetresPerSecond wanted %f got %f", v.ref, v.speed, mps)
+		}
+		var ref GPSSpeedRef
+		text, _ := v.ref.MarshalText()
+		if _ = ref.UnmarshalText(text); ref != v.ref {
+			t.Errorf("Incorrect GPSSpeedRef UnmarshalText wanted %s got %s", v.ref, ref)
+		}
+	}
+
+	distances := []struct {
+		ref    GPSDistanceRef
+		str    string
+		metres float64
+	}{
+		{GPSDistanceRefKilometers, "Kilometers", 2000},
+		{GPSDistanceRefMiles, "Miles", 3218.688},
+		{GPSDistanceRefNauticalMiles, "Nautical Miles", 3704},
+	}
+	for _, v := range distances {
+		if v.ref.String() != v.str {
+			t.Errorf("Incorrect GPSDistanceRef wanted %s got %s", v.str, v.ref)
+		}
+		if m := v.ref.Metres(2); math.Abs(m-v.metres) > 1e-6 {
+			t.Errorf("Incorrect %s Metres wanted %f got %f", v.ref, v.metres, m)
+		}
+		var ref GPSDistanceRef
+		text, _ := v.ref.MarshalText()
+		if _ = ref.UnmarshalText(text); ref != v.ref {
+			t.Errorf("Incorrect GPSDistanceRef UnmarshalText wanted %s got %s", v.ref, ref)
+		}
+	}
+
+	var dr GPSDirectionRef
+	if _ = dr.UnmarshalText([]byte("Magnetic North")); dr != GPSDirectionRefMagneticNorth {
+		t.Errorf("Incorrect GPSDirectionRef wanted %s got %s", GPSDirectionRefMagneticNorth, dr)
+	}
+	var mm GPSMeasureMode
+	if _ = mm.UnmarshalText([]byte("2-Dimensional Measurement")); mm != GPSMeasureMode2D {
+		t.Errorf("Incorrect GPSMeasureMode wanted %s got %s", GPSMeasureMode2D, mm)
+	}
+	var s GPSStatus
+	if _ = s.UnmarshalText([]byte("Measurement Void")); s != GPSStatusVoid {
+		t.Errorf("Incorrect GPSStatus wanted %s got %s", GPSStatusVoid, s)
+	}
+}
+
+func TestCharacterCode(t *testing.T) {
+	tests := []struct {
+		buf string
+		cc  CharacterCode
+		val string
+	}{
+		{"ASCII\x00\x00\x00GPS", CharacterCodeASCII, "GPS"},
+		{"JIS\x00\x00\x00\x00\x00GPS", CharacterCodeJIS, "GPS"},
+		{"UNICODE\x00G\x00", CharacterCodeUnicode, "G\x00"},
+		{"\x00\x00\x00\x00\x00\x00\x00\x00GPS", CharacterCodeUndefined, "GPS"},
+		{"GPS", CharacterCodeUndefined, "GPS"},
+	}
+	for _, v := range tests {
+		cc, val := NewCharacterCode([]byte(v.buf))
+		if cc != v.cc || string(val) != v.val {
+			t.Errorf("Incorrect CharacterCode wanted %s %q got %s %q", v.cc, v.val, cc, val)
+		}
+	}
+}
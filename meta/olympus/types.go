@@ -0,0 +1,404 @@
+// Package olympus provides types for Olympus and OM System Makernote values
+package olympus
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MakerNote is the decoded Olympus Makernote
+type MakerNote struct {
+	CameraType           string             // Equipment / 0x0100
+	SerialNumber         string             // Equipment / 0x0101 (body serial number)
+	InternalSerialNumber string             // Equipment / 0x0102
+	BodyFirmwareVersion  string             // Equipment / 0x0104
+	LensModel            string             // Equipment / 0x0203
+	LensSerialNumber     string             // Equipment / 0x0202
+	LensType             LensType           // Equipment / 0x0201
+	RawDevelopment       RawDevelopment     // 0x2030 or 0x2031
+	ImageProcessing      ImageProcessing    // 0x2040
+	StackedImage         StackedImage       // CameraSettings / 0x0804
+	DriveMode            DriveMode          // CameraSettings / 0x0600
+	ImageStabilization   ImageStabilization // CameraSettings / 0x0604
+	ArtFilter            ArtFilter          // CameraSettings / 0x0529
+	FocusBracketStepSize uint8              // CameraSettings / 0x0308
+}
+
+// IsFocusStacked returns true if the image was focus stacked in camera
+func (mn MakerNote) IsFocusStacked() bool {
+	return mn.StackedImage.IsFocusStacked()
+}
+
+// IsFocusBracketing returns true if the image was taken with focus bracketing
+func (mn MakerNote) IsFocusBracketing() bool {
+	return mn.DriveMode.IsFocusBracketing()
+}
+
+// RawDevelopment is the in-camera raw development of the Olympus
+// RawDevelopment (0x2030) or RawDevelopment2 (0x2031) Ifd
+type RawDevelopment struct {
+	ExposureBias      float32          // RawDevExposureBiasValue
+	WhiteBalanceValue uint16           // RawDevWhiteBalanceValue
+	WBFineAdjustment  int16            // RawDevWBFineAdjustment
+	GrayPoint         [3]uint16        // RawDevGrayPoint
+	ColorSpace        RawDevColorSpace // RawDevColorSpace
+	Engine            RawDevEngine     // RawDevEngine
+}
+
+// RawDevColorSpace is the Olympus RawDevColorSpace
+//
+//	0: "sRGB",
+//	1: "Adobe RGB",
+//	2: "Pro Photo RGB",
+type RawDevColorSpace uint8
+
+// String returns the RawDevColorSpace as a string
+func (cs RawDevColorSpace) String() string {
+	switch cs {
+	case 0:
+		return "sRGB"
+	case 1:
+		return "Adobe RGB"
+	case 2:
+		return "Pro Photo RGB"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (cs RawDevColorSpace) MarshalText() (text []byte, err error) {
+	return []byte(cs.String()), nil
+}
+
+// RawDevEngine is the Olympus RawDevEngine
+//
+//	0: "High Speed",
+//	1: "High Function",
+//	2: "Advanced High Speed",
+//	3: "Advanced High Function",
+type RawDevEngine uint8
+
+// String returns the RawDevEngine as a string
+func (re RawDevEngine) String() string {
+	switch re {
+	case 0:
+		return "High Speed"
+	case 1:
+		return "High Function"
+	case 2:
+		return "Advanced High Speed"
+	case 3:
+		return "Advanced High Function"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (re RawDevEngine) MarshalText() (text []byte, err error) {
+	return []byte(re.String()), nil
+}
+
+// ImageProcessing is the image processing of the Olympus ImageProcessing
+// Ifd (0x2040) with the white balance, black levels and sensor crop of the
+// raw image.
+type ImageProcessing struct {
+	WBRBLevels           [2]uint16 // 0x0100 Red and Blue white balance levels
+	BlackLevel           [4]uint16 // 0x0600 BlackLevel2
+	ValidBits            uint16    // 0x0611
+	CropLeft             uint32    // 0x0612
+	CropTop              uint32    // 0x0613
+	CropWidth            uint32    // 0x0614
+	CropHeight           uint32    // 0x0615
+	DistortionCorrection bool      // 0x1011 DistortionCorrection2
+	ShadingCompensation  bool      // 0x1012 ShadingCompensation2
+}
+
+// LensType is the Olympus LensType (Equipment / 0x0201). It is identified
+// by the Make, Model and SubModel bytes of the 6 byte value.
+type LensType struct {
+	Make     uint8
+	Model    uint8
+	SubModel uint8
+}
+
+// NewLensType returns a LensType from the 6 byte Olympus LensType value
+func NewLensType(buf []byte) LensType {
+	if len(buf) < 4 {
+		return LensType{}
+	}
+	return LensType{Make: buf[0], Model: buf[2], SubModel: buf[3]}
+}
+
+// IsValid returns true if the LensType is not empty
+func (lt LensType) IsValid() bool {
+	return lt.Model != 0 || lt.SubModel != 0
+}
+
+// ID returns the exiftool style identifier of the LensType "0 10 10"
+func (lt LensType) ID() string {
+	return fmt.Sprintf("%x %02x %02x", lt.Make, lt.Model, lt.SubModel)
+}
+
+// String returns the LensType name if known, otherwise the LensType ID
+func (lt LensType) String() string {
+	if name, ok := mapLensTypeString[lt]; ok {
+		return name
+	}
+	return lt.ID()
+}
+
+// MarshalText implements the TextMarshaler interface
+func (lt LensType) MarshalText() (text []byte, err error) {
+	return []byte(lt.String()), nil
+}
+
+// mapLensTypeString is a partial list of Olympus lenses
+// Derived from https://exiftool.org/TagNames/Olympus.html#LensType (19/10/2026)
+var mapLensTypeString = map[LensType]string{
+	{0, 0x01, 0x00}: "Olympus Zuiko Digital ED 50mm F2.0 Macro",
+	{0, 0x01, 0x01}: "Olympus Zuiko Digital 40-150mm F3.5-4.5",
+	{0, 0x01, 0x10}: "Olympus M.Zuiko Digital ED 14-42mm F3.5-5.6",
+	{0, 0x02, 0x10}: "Olympus M.Zuiko Digital 17mm F2.8 Pancake",
+	{0, 0x03, 0x10}: "Olympus M.Zuiko Digital ED 14-150mm F4.0-5.6 [II]",
+	{0, 0x04, 0x10}: "Olympus M.Zuiko Digital ED 9-18mm F4.0-5.6",
+	{0, 0x05, 0x10}: "Olympus M.Zuiko Digital ED 14-42mm F3.5-5.6 L",
+	{0, 0x06, 0x10}: "Olympus M.Zuiko Digital ED 40-150mm F4.0-5.6",
+	{0, 0x07, 0x10}: "Olympus M.Zuiko Digital ED 12mm F2.0",
+	{0, 0x08, 0x10}: "Olympus M.Zuiko Digital ED 75-300mm F4.8-6.7",
+	{0, 0x09, 0x10}: "Olympus M.Zuiko Digital 14-42mm F3.5-5.6 II",
+	{0, 0x10, 0x10}: "Olympus M.Zuiko Digital ED 12-50mm F3.5-6.3 EZ",
+	{0, 0x11, 0x10}: "Olympus M.Zuiko Digital 45mm F1.8",
+	{0, 0x12, 0x10}: "Olympus M.Zuiko Digital ED 60mm F2.8 Macro",
+	{0, 0x13, 0x10}: "Olympus M.Zuiko Digital 14-42mm F3.5-5.6 II R",
+	{0, 0x14, 0x10}: "Olympus M.Zuiko Digital ED 40-150mm F4.0-5.6 R",
+	{0, 0x15, 0x10}: "Olympus M.Zuiko Digital ED 75mm F1.8",
+	{0, 0x16, 0x10}: "Olympus M.Zuiko Digital 17mm F1.8",
+	{0, 0x18, 0x10}: "Olympus M.Zuiko Digital ED 75-300mm F4.8-6.7 II",
+	{0, 0x19, 0x10}: "Olympus M.Zuiko Digital ED 12-40mm F2.8 Pro",
+	{0, 0x20, 0x10}: "Olympus M.Zuiko Digital ED 40-150mm F2.8 Pro",
+	{0, 0x21, 0x10}: "Olympus M.Zuiko Digital ED 14-42mm F3.5-5.6 EZ",
+	{0, 0x22, 0x10}: "Olympus M.Zuiko Digital 25mm F1.8",
+	{0, 0x23, 0x10}: "Olympus M.Zuiko Digital ED 7-14mm F2.8 Pro",
+	{0, 0x24, 0x10}: "Olympus M.Zuiko Digital ED 300mm F4.0 IS Pro",
+	{0, 0x25, 0x10}: "Olympus M.Zuiko Digital ED 8mm F1.8 Fisheye Pro",
+	{0, 0x26, 0x10}: "Olympus M.Zuiko Digital ED 12-100mm F4.0 IS Pro",
+	{0, 0x27, 0x10}: "Olympus M.Zuiko Digital ED 30mm F3.5 Macro",
+	{0, 0x28, 0x10}: "Olympus M.Zuiko Digital ED 25mm F1.2 Pro",
+	{0, 0x29, 0x10}: "Olympus M.Zuiko Digital ED 17mm F1.2 Pro",
+	{0, 0x30, 0x10}: "Olympus M.Zuiko Digital ED 45mm F1.2 Pro",
+	{0, 0x32, 0x10}: "Olympus M.Zuiko Digital ED 12-200mm F3.5-6.3",
+	{0, 0x33, 0x10}: "Olympus M.Zuiko Digital ED 150-400mm F4.5 TC1.25x IS Pro",
+	{0, 0x34, 0x10}: "Olympus M.Zuiko Digital ED 12-45mm F4.0 Pro",
+	{0, 0x35, 0x10}: "Olympus M.Zuiko Digital ED 100-400mm F5.0-6.3 IS",
+	{0, 0x36, 0x10}: "Olympus M.Zuiko Digital ED 8-25mm F4 Pro",
+	{0, 0x37, 0x10}: "Olympus M.Zuiko Digital ED 40-150mm F4.0 Pro",
+	{0, 0x39, 0x10}: "Olympus M.Zuiko Digital ED 90mm F3.5 Macro IS Pro",
+	{0, 0x40, 0x10}: "Olympus M.Zuiko Digital ED 150-600mm F5.0-6.3 IS",
+}
+
+// DriveMode is the Olympus DriveMode (CameraSettings / 0x0600)
+//
+//	Mode:
+//	0: "Single Shot",
+//	1: "Continuous Shooting",
+//	2: "Exposure Bracketing",
+//	3: "White Balance Bracketing",
+//	4: "Exposure+WB Bracketing",
+//	5: Bracketing defined by BracketFlags
+type DriveMode struct {
+	Mode         uint16
+	ShotNumber   uint16
+	BracketFlags uint16
+}
+
+// Bracketing flags of DriveMode.BracketFlags
+const (
+	BracketAE    uint16 = 1 << 0
+	BracketWB    uint16 = 1 << 1
+	BracketFL    uint16 = 1 << 2
+	BracketMF    uint16 = 1 << 3
+	BracketFocus uint16 = 1 << 6
+)
+
+// NewDriveMode returns a DriveMode from the int16u values of the DriveMode tag
+func NewDriveMode(vals []uint16) (dm DriveMode) {
+	if len(vals) > 0 {
+		dm.Mode = vals[0]
+	}
+	if len(vals) > 1 {
+		dm.ShotNumber = vals[1]
+	}
+	if len(vals) > 2 && dm.Mode == 5 {
+		dm.BracketFlags = vals[2]
+	}
+	return dm
+}
+
+// IsFocusBracketing returns true if the DriveMode is Focus Bracketing
+func (dm DriveMode) IsFocusBracketing() bool {
+	return dm.Mode == 5 && dm.BracketFlags&BracketFocus != 0
+}
+
+// String returns the DriveMode as a string
+func (dm DriveMode) String() string {
+	switch dm.Mode {
+	case 0:
+		return "Single Shot"
+	case 1:
+		return "Continuous Shooting"
+	case 2:
+		return "Exposure Bracketing"
+	case 3:
+		return "White Balance Bracketing"
+	case 4:
+		return "Exposure+WB Bracketing"
+	case 5:
+		var s []string
+		for _, f := range []struct {
+			flag uint16
+			name string
+		}{{BracketAE, "AE"}, {BracketWB, "WB"}, {BracketFL, "FL"}, {BracketMF, "MF"}, {BracketFocus, "Focus"}} {
+			if dm.BracketFlags&f.flag != 0 {
+				s = append(s, f.name)
+			}
+		}
+		if len(s) > 0 {
+			return strings.Join(s, ", ") + " Bracketing"
+		}
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (dm DriveMode) MarshalText() (text []byte, err error) {
+	return []byte(dm.String()), nil
+}
+
+// StackedImage is the Olympus StackedImage (CameraSettings / 0x0804)
+// with the stack Type and the number of images in the stack.
+type StackedImage struct {
+	Type  uint32
+	Count uint32
+}
+
+// IsFocusStacked returns true if the image was focus stacked in camera
+func (si StackedImage) IsFocusStacked() bool {
+	return si.Type == 9
+}
+
+// String returns the StackedImage as a string
+func (si StackedImage) String() string {
+	switch si.Type {
+	case 0:
+		return "No"
+	case 1:
+		return fmt.Sprintf("Live Composite (%d images)", si.Count)
+	case 3:
+		return "ND Filter"
+	case 4:
+		return fmt.Sprintf("Live Time/Bulb (%d images)", si.Count)
+	case 5:
+		return "HDR1"
+	case 6:
+		return "HDR2"
+	case 8:
+		return "Tripod high resolution"
+	case 9:
+		return fmt.Sprintf("Focus-stacked (%d images)", si.Count)
+	case 11:
+		return "Hand-held high resolution"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (si StackedImage) MarshalText() (text []byte, err error) {
+	return []byte(si.String()), nil
+}
+
+// ImageStabilization is the Olympus ImageStabilization mode (CameraSettings / 0x0604)
+//
+//	0: "Off",
+//	1: "On, Mode 1",
+//	2: "On, Mode 2",
+//	3: "On, Mode 3",
+//	4: "On, Mode 4",
+type ImageStabilization uint8
+
+// String returns the ImageStabilization as a string
+func (is ImageStabilization) String() string {
+	switch is {
+	case 0:
+		return "Off"
+	case 1, 2, 3, 4:
+		return fmt.Sprintf("On, Mode %d", is)
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (is ImageStabilization) MarshalText() (text []byte, err error) {
+	return []byte(is.String()), nil
+}
+
+// ArtFilter is the Olympus ArtFilter (CameraSettings / 0x0529)
+type ArtFilter uint16
+
+// String returns the ArtFilter as a string
+func (af ArtFilter) String() string {
+	if str, ok := mapArtFilterString[af]; ok {
+		return str
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (af ArtFilter) MarshalText() (text []byte, err error) {
+	return []byte(af.String()), nil
+}
+
+// mapArtFilterString is a map of ArtFilter values
+// Derived from https://exiftool.org/TagNames/Olympus.html#CameraSettings (19/10/2026)
+var mapArtFilterString = map[ArtFilter]string{
+	0:  "Off",
+	1:  "Soft Focus",
+	2:  "Pop Art",
+	3:  "Pale & Light Color",
+	4:  "Light Tone",
+	5:  "Pin Hole",
+	6:  "Grainy Film",
+	9:  "Diorama",
+	10: "Cross Process",
+	12: "Fish Eye",
+	13: "Drawing",
+	14: "Gentle Sepia",
+	15: "Pale & Light Color II",
+	16: "Pop Art II",
+	17: "Pin Hole II",
+	18: "Pin Hole III",
+	19: "Grainy Film II",
+	20: "Dramatic Tone",
+	21: "Punk",
+	22: "Soft Focus 2",
+	23: "Sparkle",
+	24: "Watercolor",
+	25: "Key Line",
+	26: "Key Line II",
+	27: "Miniature",
+	28: "Reflection",
+	29: "Fragmented",
+	31: "Cross Process II",
+	32: "Dramatic Tone II",
+	33: "Watercolor I",
+	34: "Watercolor II",
+	35: "Diorama II",
+	36: "Vintage",
+	37: "Vintage II",
+	38: "Vintage III",
+	39: "Partial Color",
+	40: "Partial Color II",
+	41: "Partial Color III",
+	42: "Bleach Bypass",
+	43: "Bleach Bypass II",
+	44: "Instant Film",
+}
@@ -0,0 +1,126 @@
+// Package panasonic provides types for Panasonic Makernote values
+package panasonic
+
+// MakerNote is the decoded Panasonic Makernote
+type MakerNote struct {
+	InternalSerialNumber string      // 0x0025 "F541201130055" factory code, date and number
+	LensType             string      // 0x0051
+	LensSerialNumber     string      // 0x0052
+	Faces                []FaceInfo  // 0x004e
+	SequenceNumber       uint32      // 0x002b
+	BurstMode            BurstMode   // 0x002a
+	ShutterType          ShutterType // 0x009f
+	FacesDetected        uint8       // 0x003f
+}
+
+// FaceInfo is the position of a detected face (0x004e). The values are
+// the center of the face and its size in a 320x240 frame.
+type FaceInfo struct {
+	X      uint16
+	Y      uint16
+	Width  uint16
+	Height uint16
+}
+
+// NewFaceInfo returns the faces from the FaceDetInfo values. The first
+// value is the number of faces followed by 4 values for each face.
+func NewFaceInfo(vals []uint16) []FaceInfo {
+	if len(vals) == 0 {
+		return nil
+	}
+	n := int(vals[0])
+	if n > (len(vals)-1)/4 {
+		n = (len(vals) - 1) / 4
+	}
+	if n == 0 {
+		return nil
+	}
+	faces := make([]FaceInfo, n)
+	for i := range faces {
+		v := vals[1+4*i:]
+		faces[i] = FaceInfo{X: v[0], Y: v[1], Width: v[2], Height: v[3]}
+	}
+	return faces
+}
+
+// BurstMode is the Panasonic BurstMode (0x002a)
+//
+//	0: "Off",
+//	1: "On",
+//	2: "Auto Exposure Bracketing (AEB)",
+//	3: "Focus Bracketing",
+//	4: "Unlimited",
+//	8: "White Balance Bracketing",
+//	17: "On (with flash)",
+//	18: "Aperture Bracketing",
+type BurstMode uint8
+
+// BurstMode values
+const (
+	BurstModeOff                BurstMode = 0
+	BurstModeOn                 BurstMode = 1
+	BurstModeAEB                BurstMode = 2
+	BurstModeFocusBracketing    BurstMode = 3
+	BurstModeUnlimited          BurstMode = 4
+	BurstModeWBBracketing       BurstMode = 8
+	BurstModeOnWithFlash        BurstMode = 17
+	BurstModeApertureBracketing BurstMode = 18
+)
+
+// String returns the BurstMode as a string
+func (bm BurstMode) String() string {
+	if str, ok := mapBurstModeString[bm]; ok {
+		return str
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (bm BurstMode) MarshalText() (text []byte, err error) {
+	return []byte(bm.String()), nil
+}
+
+// IsBracketing returns true if the BurstMode is a bracketing mode
+func (bm BurstMode) IsBracketing() bool {
+	switch bm {
+	case BurstModeAEB, BurstModeFocusBracketing, BurstModeWBBracketing, BurstModeApertureBracketing:
+		return true
+	}
+	return false
+}
+
+var mapBurstModeString = map[BurstMode]string{
+	BurstModeOff:                "Off",
+	BurstModeOn:                 "On",
+	BurstModeAEB:                "Auto Exposure Bracketing (AEB)",
+	BurstModeFocusBracketing:    "Focus Bracketing",
+	BurstModeUnlimited:          "Unlimited",
+	BurstModeWBBracketing:       "White Balance Bracketing",
+	BurstModeOnWithFlash:        "On (with flash)",
+	BurstModeApertureBracketing: "Aperture Bracketing",
+}
+
+// ShutterType is the Panasonic ShutterType (0x009f)
+//
+//	0: "Mechanical",
+//	1: "Electronic",
+//	2: "Hybrid",
+type ShutterType uint8
+
+// String returns the ShutterType as a string
+func (st ShutterType) String() string {
+	switch st {
+	case 0:
+		return "Mechanical"
+	case 1:
+		return "Electronic"
+	case 2:
+		return "Hybrid"
+	}
+	return "Unknown"
+}
+
+// MarshalText implements the TextMarshaler interface
+func (st ShutterType) MarshalText() (text []byte, err error) {
+	return []byte(st.String()), nil
+}
@@ -0,0 +1,233 @@
+// Package pentax provides types for Pentax and Ricoh Makernote values
+package pentax
+
+import "fmt"
+
+// MakerNote is the decoded Pentax Makernote
+type MakerNote struct {
+	SerialNumber    string         // 0x0229
+	LensType        LensType       // 0x003f LensRec or 0x0207 LensInfo
+	ShutterCount    uint32         // 0x005d decoded with Date (0x0006) and Time (0x0007)
+	Level           LevelInfo      // 0x022b
+	ShakeReduction  ShakeReduction // 0x005c
+	AFPointSelected AFPoint        // 0x000e
+}
+
+// LensType is the Pentax LensType identified by the lens series and
+// model bytes.
+type LensType struct {
+	Series uint8
+	Model  uint8
+}
+
+// NewLensType returns a LensType from the first 2 bytes of the Pentax
+// LensRec or LensInfo value. The lens series is the low 4 bits of the
+// first byte.
+func NewLensType(buf []byte) LensType {
+	if len(buf) < 2 {
+		return LensType{}
+	}
+	return LensType{Series: buf[0] & 0x0f, Model: buf[1]}
+}
+
+// ID returns the exiftool style identifier of the LensType "3 17"
+func (lt LensType) ID() string {
+	return fmt.Sprintf("%d %d", lt.Series, lt.Model)
+}
+
+// String returns the LensType name if known, otherwise the LensType ID
+func (lt LensType) String() string {
+	if name, ok := mapLensTypeString[lt]; ok {
+		return name
+	}
+	return lt.ID()
+}
+
+// MarshalText implements the TextMarshaler interface
+func (lt LensType) MarshalText() (text []byte, err error) {
+	return []byte(lt.String()), nil
+}
+
+// mapLensTypeString is a partial list of Pentax lenses, the common K mount
+// lenses of the digital bodies. LensType.String returns the LensType ID for
+// lenses that are not listed.
+// Derived from https://exiftool.org/TagNames/Pentax.html#LensType (19/10/2026)
+var mapLensTypeString = map[LensType]string{
+	{0, 0}:   "M-42 or No Lens",
+	{1, 0}:   "K or M Lens",
+	{2, 0}:   "A Series Lens",
+	{3, 0}:   "Sigma",
+	{3, 17}:  "smc PENTAX-FA SOFT 85mm F2.8",
+	{3, 18}:  "smc PENTAX-F 1.7X AF ADAPTER",
+	{3, 19}:  "smc PENTAX-F 24-50mm F4",
+	{3, 20}:  "smc PENTAX-F 35-80mm F4-5.6",
+	{3, 21}:  "smc PENTAX-F 80-200mm F4.7-5.6",
+	{3, 22}:  "smc PENTAX-F FISH-EYE 17-28mm F3.5-4.5",
+	{3, 24}:  "smc PENTAX-F 35-135mm F3.5-4.5",
+	{4, 1}:   "smc PENTAX-FA SOFT 28mm F2.8",
+	{4, 2}:   "smc PENTAX-FA 80-320mm F4.5-5.6",
+	{4, 3}:   "smc PENTAX-FA 43mm F1.9 Limited",
+	{4, 6}:   "smc PENTAX-FA 35-80mm F4-5.6",
+	{4, 12}:  "smc PENTAX-FA 50mm F1.4",
+	{4, 15}:  "smc PENTAX-FA 28-105mm F4-5.6 [IF]",
+	{4, 20}:  "smc PENTAX-FA 28-80mm F3.5-5.6",
+	{4, 229}: "smc PENTAX-DA 18-55mm F3.5-5.6 AL II",
+	{4, 231}: "smc PENTAX-DA 18-250mm F3.5-6.3 ED AL [IF]",
+	{4, 243}: "smc PENTAX-DA 70mm F2.4 Limited",
+	{4, 244}: "smc PENTAX-DA 21mm F3.2 AL Limited",
+	{4, 247}: "smc PENTAX-DA FISH-EYE 10-17mm F3.5-4.5 ED[IF]",
+	{4, 248}: "smc PENTAX-DA 12-24mm F4 ED AL [IF]",
+	{4, 250}: "smc PENTAX-DA 50-200mm F4-5.6 ED",
+	{4, 251}: "smc PENTAX-DA 40mm F2.8 Limited",
+	{4, 252}: "smc PENTAX-DA 18-55mm F3.5-5.6 AL",
+	{4, 253}: "smc PENTAX-DA 14mm F2.8 ED[IF]",
+	{4, 254}: "smc PENTAX-DA 16-45mm F4 ED AL",
+	{7, 0}:   "smc PENTAX-DA 21mm F3.2 AL Limited",
+	{7, 58}:  "smc PENTAX-D FA Macro 100mm F2.8 WR",
+	{7, 201}: "smc PENTAX-DA L 50-200mm F4-5.6 ED WR",
+	{7, 202}: "smc PENTAX-DA L 18-55mm F3.5-5.6 AL WR",
+	{7, 203}: "HD PENTAX-DA 55-300mm F4-5.8 ED WR",
+	{7, 204}: "HD PENTAX-DA 15mm F4 ED AL Limited",
+	{7, 205}: "HD PENTAX-DA 35mm F2.8 Macro Limited",
+	{7, 206}: "HD PENTAX-DA 70mm F2.4 Limited",
+	{7, 207}: "HD PENTAX-DA 21mm F3.2 ED AL Limited",
+	{7, 208}: "HD PENTAX-DA 40mm F2.8 Limited",
+	{7, 212}: "smc PENTAX-DA 50mm F1.8",
+	{7, 213}: "smc PENTAX-DA 40mm F2.8 XS",
+	{7, 214}: "smc PENTAX-DA 35mm F2.4 AL",
+	{7, 216}: "smc PENTAX-DA L 55-300mm F4-5.8 ED",
+	{7, 217}: "smc PENTAX-DA 50-200mm F4-5.6 ED WR",
+	{7, 218}: "smc PENTAX-DA 18-55mm F3.5-5.6 AL WR",
+	{8, 226}: "smc PENTAX-DA* 55mm F1.4 SDM",
+	{8, 227}: "smc PENTAX-DA* 60-250mm F4 [IF] SDM",
+	{8, 232}: "smc PENTAX-DA 17-70mm F4 AL [IF] SDM",
+	{8, 234}: "smc PENTAX-DA* 300mm F4 ED [IF] SDM",
+	{8, 235}: "smc PENTAX-DA* 200mm F2.8 ED [IF] SDM",
+	{8, 241}: "smc PENTAX-DA* 50-135mm F2.8 ED [IF] SDM",
+	{8, 242}: "smc PENTAX-DA* 16-50mm F2.8 ED AL [IF] SDM",
+}
+
+// ShakeReduction is the Pentax ShakeReductionInfo (0x005c)
+type ShakeReduction struct {
+	Result uint8              // SRResult: bit 0 "Stabilized", bit 6 "Not Ready"
+	Mode   ShakeReductionMode // ShakeReduction
+}
+
+// IsStabilized returns true if the image was stabilized
+func (sr ShakeReduction) IsStabilized() bool {
+	return sr.Result&0x01 == 0x01
+}
+
+// ShakeReductionMode is the Pentax ShakeReduction setting
+//
+//	0: "Off",
+//	1: "On",
+//	4: "Off (4)",
+//	5: "On but Disabled",
+//	6: "On (Video)",
+//	7: "On (7)",
+//	15: "On (15)",
+//	39: "On (mode 2)",
+//	135: "On (135)",
+//	167: "On (mode 1)",
+type ShakeReductionMode uint8
+
+// IsOn returns true if ShakeReduction was enabled
+func (srm ShakeReductionMode) IsOn() bool {
+	switch srm {
+	case 1, 5, 6, 7, 15, 39, 135, 167:
+		return true
+	}
+	return false
+}
+
+// String returns the ShakeReductionMode as a string
+func (srm ShakeReductionMode) String() string {
+	switch srm {
+	case 0:
+		return "Off"
+	case 1:
+		return "On"
+	case 4:
+		return "Off (4)"
+	case 5:
+		return "On but Disabled"
+	case 6:
+		return "On (Video)"
+	case 7, 15, 135:
+		return fmt.Sprintf("On (%d)", srm)
+	case 39:
+		return "On (mode 2)"
+	case 167:
+		return "On (mode 1)"
+	}
+	return fmt.Sprintf("Unknown (%d)", srm)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (srm ShakeReductionMode) MarshalText() (text []byte, err error) {
+	return []byte(srm.String()), nil
+}
+
+// AFPoint is the Pentax AFPointSelected (0x000e)
+//
+//	0xffff: "Auto",
+//	0xfffe: "Fixed Center",
+//	0xfffd: "Automatic Tracking AF",
+//	0xfffc: "Face Detect AF",
+//	0xfffb: "AF Select",
+//	0: "None",
+//	1-11: 11 point AF layout
+type AFPoint uint16
+
+// String returns the AFPoint as a string
+func (af AFPoint) String() string {
+	switch af {
+	case 0xffff:
+		return "Auto"
+	case 0xfffe:
+		return "Fixed Center"
+	case 0xfffd:
+		return "Automatic Tracking AF"
+	case 0xfffc:
+		return "Face Detect AF"
+	case 0xfffb:
+		return "AF Select"
+	}
+	if int(af) < len(afPointNames) {
+		return afPointNames[af]
+	}
+	return fmt.Sprintf("Unknown (%d)", af)
+}
+
+// MarshalText implements the TextMarshaler interface
+func (af AFPoint) MarshalText() (text []byte, err error) {
+	return []byte(af.String()), nil
+}
+
+var afPointNames = [...]string{"None", "Upper-left", "Top", "Upper-right", "Left", "Mid-left", "Center", "Mid-right", "Right", "Lower-left", "Bottom", "Lower-right"}
+
+// LevelInfo is the Pentax LevelInfo (0x022b) from the electronic level.
+type LevelInfo struct {
+	Orientation uint8   // 1: "Horizontal (normal)", 2: "Rotate 180", 3: "Rotate 90 CW", 4: "Rotate 270 CW"
+	Roll        float32 // degrees, positive is clockwise
+	Pitch       float32 // degrees, positive is upwards
+}
+
+// NewLevelInfo returns a LevelInfo from the Pentax LevelInfo value.
+// The roll and pitch angles are signed values in units of -0.5 degrees.
+func NewLevelInfo(buf []byte) LevelInfo {
+	if len(buf) < 3 {
+		return LevelInfo{}
+	}
+	return LevelInfo{
+		Orientation: buf[0] & 0x0f,
+		Roll:        -float32(int8(buf[1])) / 2,
+		Pitch:       -float32(int8(buf[2])) / 2,
+	}
+}
+
+// IsLevel returns true if the camera was within 1 degree of level
+func (li LevelInfo) IsLevel() bool {
+	return li.Roll > -1 && li.Roll < 1 && li.Pitch > -1 && li.Pitch < 1
+}
@@ -0,0 +1,169 @@
+package meta
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// ErrRationalText is returned when a Rational or SRational can not be
+// parsed from text.
+var ErrRationalText = errors.New("error parsing rational text")
+
+// Rational is an unsigned rational number that preserves the numerator and
+// denominator of an Exif RATIONAL value.
+type Rational struct {
+	Numerator   uint32
+	Denominator uint32
+}
+
+// NewRational returns a new Rational with the "n" numerator and the "d"
+// denominator.
+func NewRational(n, d uint32) Rational {
+	return Rational{Numerator: n, Denominator: d}
+}
+
+// Float returns the value of the Rational. Returns 0 if the denominator is 0.
+func (r Rational) Float() float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	return float64(r.Numerator) / float64(r.Denominator)
+}
+
+// IsZero returns true if the Rational is the zero value
+func (r Rational) IsZero() bool {
+	return r.Numerator == 0 && r.Denominator == 0
+}
+
+// String returns the Rational as "n/d"
+func (r Rational) String() string {
+	buf, _ := r.MarshalText()
+	return string(buf)
+}
+
+// MarshalText implements the TextMarshaler interface that is
+// used by encoding/json
+func (r Rational) MarshalText() (text []byte, err error) {
+	text = strconv.AppendUint(make([]byte, 0, 12), uint64(r.Numerator), 10)
+	text = append(text, '/')
+	return strconv.AppendUint(text, uint64(r.Denominator), 10), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json. Accepts "n/d" or "n".
+func (r *Rational) UnmarshalText(text []byte) (err error) {
+	n, d, err := splitRationalText(text)
+	if err != nil {
+		return err
+	}
+	num, err := strconv.ParseUint(string(n), 10, 32)
+	if err != nil {
+		return ErrRationalText
+	}
+	den := uint64(1)
+	if d != nil {
+		if den, err = strconv.ParseUint(string(d), 10, 32); err != nil {
+			return ErrRationalText
+		}
+	}
+	*r = Rational{Numerator: uint32(num), Denominator: uint32(den)}
+	return nil
+}
+
+// SRational is a signed rational number that preserves the numerator and
+// denominator of an Exif SRATIONAL value.
+type SRational struct {
+	Numerator   int32
+	Denominator int32
+}
+
+// NewSRational returns a new SRational with the "n" numerator and the "d"
+// denominator.
+func NewSRational(n, d int32) SRational {
+	return SRational{Numerator: n, Denominator: d}
+}
+
+// Float returns the value of the SRational. Returns 0 if the denominator
+// is 0.
+func (r SRational) Float() float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	return float64(r.Numerator) / float64(r.Denominator)
+}
+
+// IsZero returns true if the SRational is the zero value
+func (r SRational) IsZero() bool {
+	return r.Numerator == 0 && r.Denominator == 0
+}
+
+// Reduce returns the SRational reduced to its lowest terms with a positive
+// denominator. Returns r if the denominator is 0.
+func (r SRational) Reduce() SRational {
+	n, d := int64(r.Numerator), int64(r.Denominator)
+	if d == 0 {
+		return r
+	}
+	if d < 0 {
+		n, d = -n, -d
+	}
+	a, b := n, d
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if n/a > 1<<31-1 || d/a > 1<<31-1 { // -(-1 << 31) does not fit
+		return r
+	}
+	return SRational{Numerator: int32(n / a), Denominator: int32(d / a)}
+}
+
+// String returns the SRational as "n/d"
+func (r SRational) String() string {
+	buf, _ := r.MarshalText()
+	return string(buf)
+}
+
+// MarshalText implements the TextMarshaler interface that is
+// used by encoding/json
+func (r SRational) MarshalText() (text []byte, err error) {
+	text = strconv.AppendInt(make([]byte, 0, 12), int64(r.Numerator), 10)
+	text = append(text, '/')
+	return strconv.AppendInt(text, int64(r.Denominator), 10), nil
+}
+
+// UnmarshalText implements the TextUnmarshaler interface that is
+// used by encoding/json. Accepts "n/d" or "n" with an optional sign.
+func (r *SRational) UnmarshalText(text []byte) (err error) {
+	n, d, err := splitRationalText(text)
+	if err != nil {
+		return err
+	}
+	num, err := strconv.ParseInt(string(n), 10, 32)
+	if err != nil {
+		return ErrRationalText
+	}
+	den := int64(1)
+	if d != nil {
+		if den, err = strconv.ParseInt(string(d), 10, 32); err != nil {
+			return ErrRationalText
+		}
+	}
+	*r = SRational{Numerator: int32(num), Denominator: int32(den)}
+	return nil
+}
+
+// splitRationalText splits "n/d" into n and d. d is nil for "n".
+func splitRationalText(text []byte) (n []byte, d []byte, err error) {
+	text = bytes.TrimSpace(text)
+	if len(text) == 0 {
+		return nil, nil, ErrRationalText
+	}
+	if i := bytes.IndexByte(text, '/'); i >= 0 {
+		return text[:i], text[i+1:], nil
+	}
+	return text, nil, nil
+}
@@ -0,0 +1,65 @@
+package meta
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRational(t *testing.T) {
+	r := NewRational(1, 3)
+	if r.String() != "1/3" || r.Float() != 1.0/3.0 {
+		t.Errorf("Incorrect Rational wanted %s got %s %f", "1/3", r, r.Float())
+	}
+	var r2 Rational
+	if err := r2.UnmarshalText([]byte("71/10")); err != nil || r2 != NewRational(71, 10) {
+		t.Errorf("Incorrect Rational.UnmarshalText wanted %s got %s %v", "71/10", r2, err)
+	}
+	if err := r2.UnmarshalText([]byte("72")); err != nil || r2 != NewRational(72, 1) {
+		t.Errorf("Incorrect Rational.UnmarshalText wanted %s got %s %v", "72/1", r2, err)
+	}
+	if (Rational{Numerator: 1}).Float() != 0 || !(Rational{}).IsZero() {
+		t.Errorf("Incorrect Rational with 0 denominator")
+	}
+	for _, s := range []string{"", "a/b", "-1/3", "1/"} {
+		if err := r2.UnmarshalText([]byte(s)); err != ErrRationalText {
+			t.Errorf("Incorrect Rational.UnmarshalText error for %q got %v", s, err)
+		}
+	}
+
+	buf, err := json.Marshal(struct{ R Rational }{NewRational(10, 4000)})
+	if err != nil || string(buf) != `{"R":"10/4000"}` {
+		t.Errorf("Incorrect Rational json got %s %v", buf, err)
+	}
+}
+
+func TestSRational(t *testing.T) {
+	r := NewSRational(-2, 3)
+	if r.String() != "-2/3" || r.Float() != -2.0/3.0 {
+		t.Errorf("Incorrect SRational wanted %s got %s %f", "-2/3", r, r.Float())
+	}
+	var r2 SRational
+	for _, s := range []string{"-2/3", "+1/3", "5"} {
+		if err := r2.UnmarshalText([]byte(s)); err != nil {
+			t.Errorf("Incorrect SRational.UnmarshalText for %q got %v", s, err)
+		}
+	}
+	if r2 != NewSRational(5, 1) {
+		t.Errorf("Incorrect SRational wanted %s got %s", "5/1", r2)
+	}
+	if err := r2.UnmarshalText([]byte("1/x")); err != ErrRationalText {
+		t.Errorf("Incorrect SRational.UnmarshalText error got %v", err)
+	}
+
+	// Reduce
+	for _, tt := range []struct{ r, want SRational }{
+		{NewSRational(-6, 9), NewSRational(-2, 3)},
+		{NewSRational(4, -6), NewSRational(-2, 3)},
+		{NewSRational(0, 1000000), NewSRational(0, 1)},
+		{NewSRational(1, 0), NewSRational(1, 0)},
+		{NewSRational(-1<<31, -1), NewSRational(-1<<31, -1)},
+	} {
+		if r := tt.r.Reduce(); r != tt.want {
+			t.Errorf("Incorrect SRational.Reduce of %s wanted %s got %s", tt.r, tt.want, r)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Package raf reads metadata information from a Fujifilm RAF Image.
+package raf
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/evanoberholster/imagemeta/jpeg"
+	"github.com/evanoberholster/imagemeta/meta"
+)
+
+// Errors
+var (
+	ErrInvalidHeader    = errors.New("error RAF header not valid")
+	ErrDirectoryLength  = errors.New("error RAF directory length not valid")
+	ErrDirectoryEntries = errors.New("error RAF directory entry count not valid")
+)
+
+const (
+	// HeaderLength is the length of the RAF Header
+	HeaderLength = 0x6c
+
+	// directoryMaxLength is the maximum length of a RAF directory read into memory
+	directoryMaxLength = 64 * 1024
+)
+
+// RAF Directory Tags
+//
+// Derived from https://exiftool.org/TagNames/FujiFilm.html#RAF (19/10/2026)
+const (
+	TagRawImageFullSize uint16 = 0x0100
+	TagCropTopLeft      uint16 = 0x0110
+	TagCroppedSize      uint16 = 0x0111
+	TagRawImageSize     uint16 = 0x0121
+	TagFujiLayout       uint16 = 0x0130
+	TagXTransLayout     uint16 = 0x0131
+	TagWBGRGBLevels     uint16 = 0x2ff0
+)
+
+var rafSignature = []byte("FUJIFILMCCD-RAW ")
+
+// RAF is a Fujifilm RAF Header and Directory
+type RAF struct {
+	Header
+	Directory
+}
+
+// Header is the header of a Fujifilm RAF Image. All values are big endian.
+type Header struct {
+	FormatVersion string // 0x10
+	CameraID      string // 0x14
+	Model         string // 0x1c
+	Version       string // 0x3c
+	JPEGOffset    uint32 // 0x54
+	JPEGLength    uint32 // 0x58
+	DirOffset     uint32 // 0x5c
+	DirLength     uint32 // 0x60
+	CFAOffset     uint32 // 0x64
+	CFALength     uint32 // 0x68
+}
+
+// Directory is the RAF directory with the raw image information
+type Directory struct {
+	CFAPattern         string    // CFAPattern of the XTransLayout as a 6x6 string of "R", "G", "B"
+	WBGRGBLevels       [4]uint16 // 0x2ff0
+	RawImageFullWidth  uint16    // 0x0100
+	RawImageFullHeight uint16    // 0x0100
+	CropTop            uint16    // 0x0110
+	CropLeft           uint16    // 0x0110
+	CroppedWidth       uint16    // 0x0111
+	CroppedHeight      uint16    // 0x0111
+	RawImageWidth      uint16    // 0x0121
+	RawImageHeight     uint16    // 0x0121
+	FujiLayout         [4]uint8  // 0x0130
+}
+
+// IsXTrans returns true if the Directory has an X-Trans CFA Pattern
+func (d Directory) IsXTrans() bool {
+	return len(d.CFAPattern) == 36
+}
+
+// ParseHeader parses a RAF Header from buf
+func ParseHeader(buf []byte) (h Header, err error) {
+	if len(buf) < HeaderLength || string(buf[:len(rafSignature)]) != string(rafSignature) {
+		return h, ErrInvalidHeader
+	}
+	h.FormatVersion = trimString(buf[0x10:0x14])
+	h.CameraID = trimString(buf[0x14:0x1c])
+	h.Model = trimString(buf[0x1c:0x3c])
+	h.Version = trimString(buf[0x3c:0x40])
+	h.JPEGOffset = binary.BigEndian.Uint32(buf[0x54:])
+	h.JPEGLength = binary.BigEndian.Uint32(buf[0x58:])
+	h.DirOffset = binary.BigEndian.Uint32(buf[0x5c:])
+	h.DirLength = binary.BigEndian.Uint32(buf[0x60:])
+	h.CFAOffset = binary.BigEndian.Uint32(buf[0x64:])
+	h.CFALength = binary.BigEndian.Uint32(buf[0x68:])
+	return h, nil
+}
+
+// ParseDirectory parses a RAF Directory from buf. The directory is a
+// uint32 entry count followed by entries of uint16 tag, uint16 size and data.
+func ParseDirectory(buf []byte) (d Directory, err error) {
+	if len(buf) < 4 {
+		return d, ErrDirectoryLength
+	}
+	count := binary.BigEndian.Uint32(buf[:4])
+	if count > uint32(len(buf))/4 {
+		return d, ErrDirectoryEntries
+	}
+	pos := 4
+	for i := uint32(0); i < count && pos+4 <= len(buf); i++ {
+		tag := binary.BigEndian.Uint16(buf[pos:])
+		size := int(binary.BigEndian.Uint16(buf[pos+2:]))
+		pos += 4
+		if pos+size > len(buf) {
+			return d, ErrDirectoryLength
+		}
+		d.parseEntry(tag, buf[pos:pos+size])
+		pos += size
+	}
+	return d, nil
+}
+
+func (d *Directory) parseEntry(tag uint16, val []byte) {
+	switch tag {
+	case TagRawImageFullSize:
+		if len(val) >= 4 {
+			d.RawImageFullHeight = binary.BigEndian.Uint16(val[0:])
+			d.RawImageFullWidth = binary.BigEndian.Uint16(val[2:])
+		}
+	case TagCropTopLeft:
+		if len(val) >= 4 {
+			d.CropTop = binary.BigEndian.Uint16(val[0:])
+			d.CropLeft = binary.BigEndian.Uint16(val[2:])
+		}
+	case TagCroppedSize:
+		if len(val) >= 4 {
+			d.CroppedHeight = binary.BigEndian.Uint16(val[0:])
+			d.CroppedWidth = binary.BigEndian.Uint16(val[2:])
+		}
+	case TagRawImageSize:
+		if len(val) >= 4 {
+			d.RawImageHeight = binary.BigEndian.Uint16(val[0:])
+			d.RawImageWidth = binary.BigEndian.Uint16(val[2:])
+		}
+	case TagFujiLayout:
+		copy(d.FujiLayout[:], val)
+	case TagXTransLayout:
+		if len(val) == 36 {
+			d.CFAPattern = xTransPattern(val)
+		}
+	case TagWBGRGBLevels:
+		for i := 0; i < len(d.WBGRGBLevels) && 2*i+2 <= len(val); i++ {
+			d.WBGRGBLevels[i] = binary.BigEndian.Uint16(val[2*i:])
+		}
+	}
+}
+
+// xTransPattern returns the X-Trans CFA Pattern as a string. The layout is
+// stored in reverse order with each value 0 = Red, 1 = Green, 2 = Blue.
+func xTransPattern(val []byte) string {
+	const colors = "RGB?"
+	b := make([]byte, len(val))
+	for i := range val {
+		b[len(val)-1-i] = colors[val[i]&3]
+	}
+	return string(b)
+}
+
+func trimString(buf []byte) string {
+	for i := 0; i < len(buf); i++ {
+		if buf[i] == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
+
+// ScanRAF reads the RAF Header, the Exif and XMP metadata of the embedded JPEG
+// preview, and the RAF Directory from r. exifReader and xmpReader are run
+// with the embedded JPEG.
+func ScanRAF(r io.ReadSeeker, exifReader func(r io.Reader, header meta.ExifHeader) error, xmpReader func(r io.Reader) error) (raf RAF, err error) {
+	var buf [HeaderLength]byte
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	if _, err = io.ReadFull(r, buf[:]); err != nil {
+		return
+	}
+	if raf.Header, err = ParseHeader(buf[:]); err != nil {
+		return
+	}
+
+	// Embedded JPEG
+	if raf.JPEGLength > 0 {
+		if _, err = r.Seek(int64(raf.JPEGOffset), io.SeekStart); err != nil {
+			return
+		}
+		err = jpeg.ScanJPEG(io.LimitReader(r, int64(raf.JPEGLength)), exifReader, xmpReader)
+		if err != nil && err != jpeg.ErrEndOfImage {
+			return
+		}
+		err = nil
+	}
+
+	// RAF Directory
+	if raf.DirLength > 0 {
+		if raf.DirLength > directoryMaxLength {
+			return raf, ErrDirectoryLength
+		}
+		if _, err = r.Seek(int64(raf.DirOffset), io.SeekStart); err != nil {
+			return
+		}
+		dir := make([]byte, raf.DirLength)
+		if _, err = io.ReadFull(r, dir); err != nil {
+			return
+		}
+		raf.Directory, err = ParseDirectory(dir)
+	}
+	return raf, err
+}
@@ -0,0 +1,59 @@
+package raf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func testRAF() []byte {
+	buf := make([]byte, HeaderLength, 0x100)
+	copy(buf, rafSignature)
+	copy(buf[0x10:], "0201")
+	copy(buf[0x14:], "FF129502")
+	copy(buf[0x1c:], "X-T3")
+	copy(buf[0x3c:], "0100")
+
+	// Directory
+	dir := make([]byte, 4)
+	binary.BigEndian.PutUint32(dir, 4)
+	entry := func(tag uint16, val []byte) {
+		dir = binary.BigEndian.AppendUint16(dir, tag)
+		dir = binary.BigEndian.AppendUint16(dir, uint16(len(val)))
+		dir = append(dir, val...)
+	}
+	entry(TagRawImageFullSize, []byte{0x0f, 0xa0, 0x17, 0x70}) // 4000 x 6000
+	entry(TagCropTopLeft, []byte{0x00, 0x05, 0x00, 0x0a})
+	entry(TagCroppedSize, []byte{0x0f, 0x90, 0x17, 0x60})
+	layout := []byte(strings.Repeat("\x01\x00\x02", 12))
+	entry(TagXTransLayout, layout)
+
+	binary.BigEndian.PutUint32(buf[0x5c:], uint32(len(buf)))
+	binary.BigEndian.PutUint32(buf[0x60:], uint32(len(dir)))
+	return append(buf, dir...)
+}
+
+func TestScanRAF(t *testing.T) {
+	r, err := ScanRAF(bytes.NewReader(testRAF()), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Model != "X-T3" || r.CameraID != "FF129502" || r.FormatVersion != "0201" {
+		t.Errorf("Incorrect RAF Header: %v", r.Header)
+	}
+	if r.RawImageFullWidth != 6000 || r.RawImageFullHeight != 4000 {
+		t.Errorf("Incorrect RawImageFullSize wanted 6000x4000 got %dx%d", r.RawImageFullWidth, r.RawImageFullHeight)
+	}
+	if r.CropTop != 5 || r.CropLeft != 10 || r.CroppedWidth != 5984 || r.CroppedHeight != 3984 {
+		t.Errorf("Incorrect Crop: %v", r.Directory)
+	}
+	if !r.IsXTrans() || r.CFAPattern != strings.Repeat("BRG", 12) {
+		t.Errorf("Incorrect CFAPattern got %s", r.CFAPattern)
+	}
+
+	// Error Invalid Header
+	if _, err = ScanRAF(bytes.NewReader(make([]byte, HeaderLength)), nil, nil); err != ErrInvalidHeader {
+		t.Errorf("Incorrect error wanted %v got %v", ErrInvalidHeader, err)
+	}
+}
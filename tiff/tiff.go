@@ -28,15 +28,20 @@ func ScanTiffHeader(r io.Reader, it imagetype.ImageType) (header meta.ExifHeader
 	var buf []byte
 
 	for {
+		if discarded == 0 {
+			if t, err := imagetype.ScanBuf(br); err == nil {
+				it = t
+			}
+		}
 		if buf, err = br.Peek(TiffHeaderLength); err != nil {
 			err = meta.ErrNoExif
 			return
 		}
-		if discarded == 0 {
-			it, _ = imagetype.Buf(buf)
-		}
 
 		byteOrder := utils.BinaryOrder(buf)
+		if byteOrder == utils.UnknownEndian && discarded == 0 {
+			byteOrder = rawBinaryOrder(buf)
+		}
 		if byteOrder == utils.UnknownEndian {
 			// Exif not identified. Move forward by one byte.
 			if buf[1] == 0x49 || buf[1] == 0x4d {
@@ -57,3 +62,18 @@ func ScanTiffHeader(r io.Reader, it imagetype.ImageType) (header meta.ExifHeader
 		return header, nil
 	}
 }
+
+// rawBinaryOrder returns the ByteOrder for the alternate Tiff Headers
+// used by camera raw formats at the beginning of the file.
+//
+//	Olympus ORF: "IIRO", "IIRS", "MMOR"
+//	Panasonic RW2: "IIU\0"
+func rawBinaryOrder(buf []byte) utils.ByteOrder {
+	switch string(buf[:4]) {
+	case "IIRO", "IIRS", "IIU\x00":
+		return utils.LittleEndian
+	case "MMOR":
+		return utils.BigEndian
+	}
+	return utils.UnknownEndian
+}
@@ -57,9 +57,31 @@ func TestTiff(t *testing.T) {
 		})
 	}
 
+	// Olympus ORF Header
+	buf := make([]byte, 64)
+	copy(buf, "IIRO\x08\x00\x00\x00")
+	h, err := ScanTiffHeader(bytes.NewReader(buf), imagetype.ImageUnknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.ByteOrder != utils.LittleEndian || h.FirstIfdOffset != 0x08 || h.ImageType != imagetype.ImageORF {
+		t.Errorf("Incorrect ORF Header wanted %s 0x0008 %s got %s 0x%04x %s", utils.LittleEndian, imagetype.ImageORF, h.ByteOrder, h.FirstIfdOffset, h.ImageType)
+	}
+
+	// Panasonic RW2 Header
+	buf = make([]byte, 64)
+	copy(buf, "IIU\x00\x18\x00\x00\x00\x88\xe7\x74\xd8")
+	h, err = ScanTiffHeader(bytes.NewReader(buf), imagetype.ImageUnknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.ByteOrder != utils.LittleEndian || h.FirstIfdOffset != 0x18 || h.ImageType != imagetype.ImagePanaRAW {
+		t.Errorf("Incorrect RW2 Header wanted %s 0x0018 %s got %s 0x%04x %s", utils.LittleEndian, imagetype.ImagePanaRAW, h.ByteOrder, h.FirstIfdOffset, h.ImageType)
+	}
+
 	// Error No Tiff Header
-	buf := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
-	_, err := ScanTiffHeader(bytes.NewReader(buf), imagetype.ImageTiff)
+	buf = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	_, err = ScanTiffHeader(bytes.NewReader(buf), imagetype.ImageTiff)
 	if err != meta.ErrNoExif {
 		t.Errorf("Incorrect err wanted %s got %s ", meta.ErrNoExif, err)
 	}
@@ -1,6 +1,7 @@
 package xmp
 
 import (
+	"strings"
 	"time"
 
 	"github.com/evanoberholster/imagemeta/meta"
@@ -20,23 +21,7 @@ func (basic *Basic) parse(p property) (err error) {
 	case xmpns.ModifyDate:
 		basic.ModifyDate, err = parseDate(p.Value())
 	case xmpns.Rating:
-		basic.Rating = int8(parseUint8(p.Value()))
-	default:
-		return ErrPropertyNotSet
-	}
-	return
-}
-
-func (mm *XMPMM) parse(p property) (err error) {
-	switch p.Property().Name() {
-	case xmpns.DocumentID:
-		mm.DocumentID = parseUUID(p.Value())
-	case xmpns.OriginalDocumentID:
-		mm.OriginalDocumentID = parseUUID(p.Value())
-	case xmpns.PreservedFileName:
-		mm.PreservedFileName = parseString(p.Value())
-	case xmpns.InstanceID:
-		mm.InstanceID = parseUUID(p.Value())
+		basic.Rating = int8(parseInt(p.Value()))
 	default:
 		return ErrPropertyNotSet
 	}
@@ -70,7 +55,7 @@ type Basic struct {
 // XMPMM - The XMP Media Management namespace contains properties that provide information
 // regarding the identification, composition, and history of a resource.
 // XMP spec Section 8.6
-// Incomplete
+// xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
 type XMPMM struct {
 	// DocumentId is the common identifier for all versions and renditions of a resource.
 	DocumentID meta.UUID
@@ -84,16 +69,136 @@ type XMPMM struct {
 	// but should retain the ID of the source file here.
 	OriginalDocumentID meta.UUID
 
+	// RawDocumentID, RawInstanceID and RawOriginalDocumentID are the
+	// identifiers as written, ex: "xmp.did:1234". The UUID fields above are
+	// NilUUID when an identifier is not a UUID.
+	RawDocumentID         string
+	RawInstanceID         string
+	RawOriginalDocumentID string
+
+	// History is the high-level actions that resulted in this resource, oldest first.
 	History []History
+	// DerivedFrom is a reference to the resource from which this one is derived,
+	// typically the resource before a "save as" or an export.
+	DerivedFrom ResourceRef
+	// Ingredients are references to the resources that were incorporated,
+	// by inclusion or by reference, into this resource.
+	Ingredients []ResourceRef
+	// Pantry is the xmpMM identifiers of the Ingredients, as embedded in this
+	// resource. The other properties of a Pantry item are in the Document.
+	Pantry []XMPMM
 
 	PreservedFileName string
 }
 
 // History is an XMPMM History sequence
+//
+//	xmlns:stEvt="http://ns.adobe.com/xap/1.0/sType/ResourceEvent#"
 type History struct {
 	Changed    string
 	Action     string
 	InstanceID meta.UUID
 	Date       time.Time
 	Software   string // softwareAgent
+	Parameters string
+}
+
+// ResourceRef is a reference to a resource, or to a part of a resource.
+//
+//	xmlns:stRef="http://ns.adobe.com/xap/1.0/sType/ResourceRef#"
+type ResourceRef struct {
+	DocumentID         meta.UUID
+	InstanceID         meta.UUID
+	OriginalDocumentID meta.UUID
+	// RawDocumentID, RawInstanceID and RawOriginalDocumentID are the
+	// identifiers as written, the UUID fields are NilUUID when an
+	// identifier is not a UUID.
+	RawDocumentID         string
+	RawInstanceID         string
+	RawOriginalDocumentID string
+	FilePath              string
+	RenditionClass        string
+	FromPart              string
+	ToPart                string
+}
+
+// IsZero returns true when the ResourceRef does not identify a resource.
+func (ref ResourceRef) IsZero() bool {
+	return ref.RawDocumentID == "" && ref.RawInstanceID == "" &&
+		ref.RawOriginalDocumentID == "" && ref.FilePath == ""
+}
+
+// parseDocument parses the xmpMM properties from the Document. Pantry items
+// have their own xmpMM properties and are not decoded while reading the
+// packet.
+func (mm *XMPMM) parseDocument(d *Document) {
+	mm.parseNodes(d.Properties)
+}
+
+func (mm *XMPMM) parseNodes(nodes []Node) {
+	uri := xmpns.XmpMMNS.URI()
+	for i := range nodes {
+		n := &nodes[i]
+		if n.Namespace != uri {
+			continue
+		}
+		switch n.Name {
+		case "DocumentID":
+			mm.RawDocumentID = strings.TrimSpace(n.Value)
+			mm.DocumentID = parseUUID([]byte(mm.RawDocumentID))
+		case "InstanceID":
+			mm.RawInstanceID = strings.TrimSpace(n.Value)
+			mm.InstanceID = parseUUID([]byte(mm.RawInstanceID))
+		case "OriginalDocumentID":
+			mm.RawOriginalDocumentID = strings.TrimSpace(n.Value)
+			mm.OriginalDocumentID = parseUUID([]byte(mm.RawOriginalDocumentID))
+		case "PreservedFileName":
+			mm.PreservedFileName = n.Value
+		case "History":
+			evtURI := xmpns.StEvtNS.URI()
+			for _, item := range structItems(n) {
+				h := History{
+					Action:     fieldValue(item, evtURI, "action"),
+					Changed:    fieldValue(item, evtURI, "changed"),
+					InstanceID: parseUUID([]byte(fieldValue(item, evtURI, "instanceID"))),
+					Software:   fieldValue(item, evtURI, "softwareAgent"),
+					Parameters: fieldValue(item, evtURI, "parameters"),
+				}
+				h.Date, _ = parseDate([]byte(fieldValue(item, evtURI, "when")))
+				mm.History = append(mm.History, h)
+			}
+		case "DerivedFrom":
+			if n.Kind == StructKind {
+				mm.DerivedFrom = resourceRef(n)
+			}
+		case "Ingredients":
+			for _, item := range structItems(n) {
+				mm.Ingredients = append(mm.Ingredients, resourceRef(item))
+			}
+		case "Pantry":
+			for _, item := range structItems(n) {
+				var p XMPMM
+				p.parseNodes(item.Fields)
+				mm.Pantry = append(mm.Pantry, p)
+			}
+		}
+	}
+}
+
+// resourceRef returns the stRef struct n as a ResourceRef
+func resourceRef(n *Node) ResourceRef {
+	uri := xmpns.StRefNS.URI()
+	ref := ResourceRef{
+		RawDocumentID:         strings.TrimSpace(fieldValue(n, uri, "documentID")),
+		RawInstanceID:         strings.TrimSpace(fieldValue(n, uri, "instanceID")),
+		RawOriginalDocumentID: strings.TrimSpace(fieldValue(n, uri, "originalDocumentID")),
+		FilePath:              fieldValue(n, uri, "filePath"),
+		RenditionClass:        fieldValue(n, uri, "renditionClass"),
+		FromPart:              fieldValue(n, uri, "fromPart"),
+		ToPart:                fieldValue(n, uri, "toPart"),
+	}
+	ref.DocumentID = parseUUID([]byte(ref.RawDocumentID))
+	ref.InstanceID = parseUUID([]byte(ref.RawInstanceID))
+	ref.OriginalDocumentID = parseUUID([]byte(ref.RawOriginalDocumentID))
+	return ref
 }
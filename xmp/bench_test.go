@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
 )
 
 var (
@@ -21,6 +24,7 @@ var (
 // BenchmarkXMPRead200 	   23542	     50447 ns/op	    7248 B/op	      29 allocs/op
 
 // BenchmarkXMPRead 	   47311	     26398 ns/op	    2304 B/op	      44 allocs/op
+// BenchmarkXMPRead 	   18446	    104467 ns/op	   33384 B/op	     125 allocs/op
 func BenchmarkXMPRead(b *testing.B) {
 	f, err := os.Open(dir) //+ "6D.xmp")
 	if err != nil {
@@ -46,5 +50,47 @@ func BenchmarkXMPRead(b *testing.B) {
 			}
 		}
 	}
+}
+
+// BenchmarkReadTagValue/text         	  111099	     11966 ns/op	       0 B/op	       0 allocs/op
+// BenchmarkReadTagValue/entities     	   90256	     12949 ns/op	       0 B/op	       0 allocs/op
+// BenchmarkReadTagValue/CDATA        	   92900	     14177 ns/op	       0 B/op	       0 allocs/op
+func BenchmarkReadTagValue(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		value string
+	}{
+		{"text", "Canon EOS 7D Mark II"},
+		{"entities", "Fish &amp; Chips"},
+		{"CDATA", "<![CDATA[<b>Fish</b> & Chips]]>"},
+	}
+	const n = 32
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			data := []byte(strings.Repeat("\n <tiff:Model>"+bm.value+"</tiff:Model>", n))
+			r := bytes.NewReader(data)
+			br := newXMPReader(r)
+			var parent Tag
+			parent.self = xmpns.XMPRootProperty
 
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.Reset(data)
+				br.r.Reset(r)
+				for j := 0; j < n; j++ {
+					tag, err := br.readTagHeader(parent)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if _, err = br.readTagValue(); err != nil {
+						b.Fatal(err)
+					}
+					if _, err = br.readTagHeader(tag); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
 }
@@ -1,6 +1,9 @@
 package xmp
 
 import (
+	"math"
+	"strings"
+
 	"github.com/evanoberholster/imagemeta/xmp/xmpns"
 )
 
@@ -8,21 +11,501 @@ import (
 type Double [2]uint16
 
 // CRS is Camera Raw Settings. Photoshop Camera Raw namespace tags.
-//	 xmlns:crs="http://ns.adobe.com/camera-raw-settings/1.0/"
+//
+//	xmlns:crs="http://ns.adobe.com/camera-raw-settings/1.0/"
+//
+// The develop settings of Lightroom and Adobe Camera Raw. Settings are
+// decoded from the Document, the crs properties nested in crs:Look are not
+// the settings of the image.
 // This implementation is incomplete and based on https://exiftool.org/TagNames/XMP.html#crs
 type CRS struct {
-	RawFileName string
+	Version            string // Camera Raw version, ex: "11.4.1"
+	ProcessVersion     string // ex: "11.0"
+	RawFileName        string
+	CameraProfile      string // ex: "Adobe Standard"
+	HasSettings        bool
+	AlreadyApplied     bool
+	ConvertToGrayscale bool
+
+	// White Balance
+	WhiteBalance           string // ex: "As Shot", "Auto", "Custom"
+	Temperature            uint16 // Kelvin
+	Tint                   int16
+	IncrementalTemperature int16 // Temperature of non-raw images
+	IncrementalTint        int16 // Tint of non-raw images
+
+	// Basic
+	Exposure2012   float64 // Exposure in stops
+	Contrast2012   int16
+	Highlights2012 int16
+	Shadows2012    int16
+	Whites2012     int16
+	Blacks2012     int16
+	Clarity2012    int16
+	Texture        int16
+	Dehaze         int16
+	Vibrance       int16
+	Saturation     int16
+
+	HSL  HSL
+	Crop Crop
+
+	// Tone Curves
+	ToneCurveName     string
+	ToneCurveName2012 string
+	ToneCurve         ToneCurve // Process Version 2010 and earlier
+	ToneCurvePV2012   ToneCurve
+
+	LensProfile LensProfile
+	Look        Look
+
+	// Corrections are the local corrections: gradients, radial gradients,
+	// brushes and masks.
+	Corrections []Correction
 }
 
-func (crs *CRS) parse(p property) (err error) {
-	switch p.Name() {
-	case xmpns.RawFileName:
-		crs.RawFileName = parseString(p.Value())
-	// Null Operation
-	case xmpns.ToneCurve, xmpns.ToneCurveRed, xmpns.ToneCurveGreen, xmpns.ToneCurveBlue:
-		return
-	case xmpns.ToneCurvePV2012, xmpns.ToneCurvePV2012Red, xmpns.ToneCurvePV2012Green, xmpns.ToneCurvePV2012Blue:
+// HSL are the hue, saturation and luminance adjustments of the HSL colors.
+// Adjustments are indexed by HSLColor.
+type HSL struct {
+	Hue        [8]int16
+	Saturation [8]int16
+	Luminance  [8]int16
+}
+
+// HSLColor is the index of an HSL adjustment
+type HSLColor uint8
+
+// HSL Colors
+const (
+	HSLRed HSLColor = iota
+	HSLOrange
+	HSLYellow
+	HSLGreen
+	HSLAqua
+	HSLBlue
+	HSLPurple
+	HSLMagenta
+)
+
+var mapHSLColor = map[string]HSLColor{
+	"Red":     HSLRed,
+	"Orange":  HSLOrange,
+	"Yellow":  HSLYellow,
+	"Green":   HSLGreen,
+	"Aqua":    HSLAqua,
+	"Blue":    HSLBlue,
+	"Purple":  HSLPurple,
+	"Magenta": HSLMagenta,
+}
+
+// Crop is the crop of the image. Top, Left, Bottom and Right are normalized
+// to the image before rotation by Angle.
+type Crop struct {
+	HasCrop                  bool
+	Top, Left, Bottom, Right float64
+	Angle                    float64 // degrees
+	ConstrainToWarp          bool
+}
+
+// ToneCurve are the point lists of the master and color channel tone
+// curves. Points are input and output values from 0 to 255.
+type ToneCurve struct {
+	Master []Double
+	Red    []Double
+	Green  []Double
+	Blue   []Double
+}
+
+// LensProfile is the lens profile correction.
+type LensProfile struct {
+	Enable                 bool
+	Setup                  string // ex: "LensDefaults", "Auto", "Custom"
+	Name                   string
+	Filename               string
+	Digest                 string
+	DistortionScale        int16
+	VignettingScale        int16
+	ManualDistortionAmount int16
+	AutoLateralCA          bool
+}
+
+// Look is the creative profile applied to the image.
+type Look struct {
+	Name   string
+	UUID   string
+	Amount float64
+}
+
+// CorrectionKind is the kind of a local Correction
+type CorrectionKind uint8
+
+// Correction Kinds
+const (
+	GradientCorrection  CorrectionKind = iota // crs:GradientBasedCorrections
+	RadialCorrection                          // crs:CircularGradientBasedCorrections
+	BrushCorrection                           // crs:PaintBasedCorrections
+	MaskGroupCorrection                       // crs:MaskGroupBasedCorrections
+)
+
+// String returns the CorrectionKind as a string
+func (k CorrectionKind) String() string {
+	switch k {
+	case GradientCorrection:
+		return "Gradient"
+	case RadialCorrection:
+		return "Radial"
+	case BrushCorrection:
+		return "Brush"
+	case MaskGroupCorrection:
+		return "MaskGroup"
+	}
+	return "Unknown"
+}
+
+// Correction is a local correction of the image. The Adjustments are applied
+// to the area of the Masks.
+type Correction struct {
+	Kind        CorrectionKind
+	Name        string // crs:CorrectionName
+	Active      bool
+	Amount      float64
+	Adjustments LocalAdjustments
+	Masks       []Mask
+}
+
+// LocalAdjustments are the adjustments of a local Correction, from -1.0 to
+// 1.0. Exposure is in stops.
+type LocalAdjustments struct {
+	Exposure       float64 // crs:LocalExposure2012
+	Contrast       float64 // crs:LocalContrast2012
+	Highlights     float64 // crs:LocalHighlights2012
+	Shadows        float64 // crs:LocalShadows2012
+	Whites         float64 // crs:LocalWhites2012
+	Blacks         float64 // crs:LocalBlacks2012
+	Clarity        float64 // crs:LocalClarity2012
+	Texture        float64
+	Dehaze         float64
+	Temperature    float64
+	Tint           float64
+	Saturation     float64
+	Sharpness      float64
+	LuminanceNoise float64
+	Moire          float64
+	Defringe       float64
+}
+
+// Mask is the area of a local Correction. The fields used depend on What.
+// Coordinates are normalized to the cropped image.
+type Mask struct {
+	What  string  // ex: "Mask/Gradient", "Mask/CircularGradient", "Mask/Paint", "Mask/Image"
+	Value float64 // crs:MaskValue, the opacity of the mask
+
+	// Mask/Gradient from the zero point to the full point
+	ZeroX, ZeroY, FullX, FullY float64
+
+	// Mask/CircularGradient
+	Top, Left, Bottom, Right float64
+	Angle                    float64
+	Midpoint                 float64
+	Roundness                float64
+	Feather                  float64
+	Flipped                  bool // the correction is outside the ellipse
+
+	// Mask/Paint
+	Radius       float64
+	Flow         float64
+	CenterWeight float64
+	Dabs         []Dab
+
+	// Masks of a MaskGroupCorrection
+	Name    string // crs:MaskName
+	SubType int16  // crs:MaskSubType
+	Masks   []Mask // combined masks, ex: intersections
+}
+
+// Dab is a normalized point of a brush stroke.
+type Dab struct {
+	X, Y float64
+}
+
+// parseDocument parses the top level crs properties of the Document.
+func (crs *CRS) parseDocument(d *Document) {
+	uri := xmpns.CrsNS.URI()
+	for i := range d.Properties {
+		if n := &d.Properties[i]; n.Namespace == uri {
+			crs.parseNode(n)
+		}
+	}
+}
+
+func (crs *CRS) parseNode(n *Node) {
+	v := n.Value
+	switch n.Name {
+	case "Version":
+		crs.Version = v
+	case "ProcessVersion":
+		crs.ProcessVersion = v
+	case "RawFileName":
+		crs.RawFileName = v
+	case "CameraProfile":
+		crs.CameraProfile = v
+	case "HasSettings":
+		crs.HasSettings = parseBool([]byte(v))
+	case "AlreadyApplied":
+		crs.AlreadyApplied = parseBool([]byte(v))
+	case "ConvertToGrayscale":
+		crs.ConvertToGrayscale = parseBool([]byte(v))
+	case "WhiteBalance":
+		crs.WhiteBalance = v
+	case "Temperature":
+		crs.Temperature = crsUint(v)
+	case "Tint":
+		crs.Tint = crsInt(v)
+	case "IncrementalTemperature":
+		crs.IncrementalTemperature = crsInt(v)
+	case "IncrementalTint":
+		crs.IncrementalTint = crsInt(v)
+	case "Exposure2012":
+		crs.Exposure2012 = crsFloat(v)
+	case "Contrast2012":
+		crs.Contrast2012 = crsInt(v)
+	case "Highlights2012":
+		crs.Highlights2012 = crsInt(v)
+	case "Shadows2012":
+		crs.Shadows2012 = crsInt(v)
+	case "Whites2012":
+		crs.Whites2012 = crsInt(v)
+	case "Blacks2012":
+		crs.Blacks2012 = crsInt(v)
+	case "Clarity2012":
+		crs.Clarity2012 = crsInt(v)
+	case "Texture":
+		crs.Texture = crsInt(v)
+	case "Dehaze":
+		crs.Dehaze = crsInt(v)
+	case "Vibrance":
+		crs.Vibrance = crsInt(v)
+	case "Saturation":
+		crs.Saturation = crsInt(v)
+
+	// Crop
+	case "HasCrop":
+		crs.Crop.HasCrop = parseBool([]byte(v))
+	case "CropTop":
+		crs.Crop.Top = crsFloat(v)
+	case "CropLeft":
+		crs.Crop.Left = crsFloat(v)
+	case "CropBottom":
+		crs.Crop.Bottom = crsFloat(v)
+	case "CropRight":
+		crs.Crop.Right = crsFloat(v)
+	case "CropAngle":
+		crs.Crop.Angle = crsFloat(v)
+	case "CropConstrainToWarp":
+		crs.Crop.ConstrainToWarp = parseBool([]byte(v))
+
+	// Tone Curves
+	case "ToneCurveName":
+		crs.ToneCurveName = v
+	case "ToneCurveName2012":
+		crs.ToneCurveName2012 = v
+	case "ToneCurve":
+		crs.ToneCurve.Master = parseCurve(n)
+	case "ToneCurveRed":
+		crs.ToneCurve.Red = parseCurve(n)
+	case "ToneCurveGreen":
+		crs.ToneCurve.Green = parseCurve(n)
+	case "ToneCurveBlue":
+		crs.ToneCurve.Blue = parseCurve(n)
+	case "ToneCurvePV2012":
+		crs.ToneCurvePV2012.Master = parseCurve(n)
+	case "ToneCurvePV2012Red":
+		crs.ToneCurvePV2012.Red = parseCurve(n)
+	case "ToneCurvePV2012Green":
+		crs.ToneCurvePV2012.Green = parseCurve(n)
+	case "ToneCurvePV2012Blue":
+		crs.ToneCurvePV2012.Blue = parseCurve(n)
+
+	// Lens Profile
+	case "LensProfileEnable":
+		crs.LensProfile.Enable = parseBool([]byte(v))
+	case "LensProfileSetup":
+		crs.LensProfile.Setup = v
+	case "LensProfileName":
+		crs.LensProfile.Name = v
+	case "LensProfileFilename":
+		crs.LensProfile.Filename = v
+	case "LensProfileDigest":
+		crs.LensProfile.Digest = v
+	case "LensProfileDistortionScale":
+		crs.LensProfile.DistortionScale = crsInt(v)
+	case "LensProfileVignettingScale":
+		crs.LensProfile.VignettingScale = crsInt(v)
+	case "LensManualDistortionAmount":
+		crs.LensProfile.ManualDistortionAmount = crsInt(v)
+	case "AutoLateralCA":
+		crs.LensProfile.AutoLateralCA = parseBool([]byte(v))
+
+	case "Look":
+		uri := xmpns.CrsNS.URI()
+		crs.Look = Look{
+			Name:   fieldValue(n, uri, "Name"),
+			UUID:   fieldValue(n, uri, "UUID"),
+			Amount: crsFloat(fieldValue(n, uri, "Amount")),
+		}
+
+	// Local Corrections
+	case "GradientBasedCorrections":
+		crs.Corrections = appendCorrections(crs.Corrections, n, GradientCorrection)
+	case "CircularGradientBasedCorrections":
+		crs.Corrections = appendCorrections(crs.Corrections, n, RadialCorrection)
+	case "PaintBasedCorrections":
+		crs.Corrections = appendCorrections(crs.Corrections, n, BrushCorrection)
+	case "MaskGroupBasedCorrections":
+		crs.Corrections = appendCorrections(crs.Corrections, n, MaskGroupCorrection)
+
+	default:
+		crs.parseHSL(n.Name, v)
+	}
+}
+
+// parseHSL parses the HSL adjustments, ex: "HueAdjustmentRed".
+func (crs *CRS) parseHSL(name string, v string) {
+	var adj *[8]int16
+	switch {
+	case strings.HasPrefix(name, "HueAdjustment"):
+		adj, name = &crs.HSL.Hue, name[len("HueAdjustment"):]
+	case strings.HasPrefix(name, "SaturationAdjustment"):
+		adj, name = &crs.HSL.Saturation, name[len("SaturationAdjustment"):]
+	case strings.HasPrefix(name, "LuminanceAdjustment"):
+		adj, name = &crs.HSL.Luminance, name[len("LuminanceAdjustment"):]
+	default:
 		return
 	}
-	return ErrPropertyNotSet
+	if c, ok := mapHSLColor[name]; ok {
+		adj[c] = crsInt(v)
+	}
+}
+
+// appendCorrections appends the local Corrections of the array n to c.
+func appendCorrections(c []Correction, n *Node, kind CorrectionKind) []Correction {
+	uri := xmpns.CrsNS.URI()
+	for _, item := range structItems(n) {
+		corr := Correction{
+			Kind:   kind,
+			Name:   fieldValue(item, uri, "CorrectionName"),
+			Active: parseBool([]byte(fieldValue(item, uri, "CorrectionActive"))),
+			Amount: crsFloat(fieldValue(item, uri, "CorrectionAmount")),
+			Adjustments: LocalAdjustments{
+				Exposure:       crsFloat(fieldValue(item, uri, "LocalExposure2012")),
+				Contrast:       crsFloat(fieldValue(item, uri, "LocalContrast2012")),
+				Highlights:     crsFloat(fieldValue(item, uri, "LocalHighlights2012")),
+				Shadows:        crsFloat(fieldValue(item, uri, "LocalShadows2012")),
+				Whites:         crsFloat(fieldValue(item, uri, "LocalWhites2012")),
+				Blacks:         crsFloat(fieldValue(item, uri, "LocalBlacks2012")),
+				Clarity:        crsFloat(fieldValue(item, uri, "LocalClarity2012")),
+				Texture:        crsFloat(fieldValue(item, uri, "LocalTexture")),
+				Dehaze:         crsFloat(fieldValue(item, uri, "LocalDehaze")),
+				Temperature:    crsFloat(fieldValue(item, uri, "LocalTemperature")),
+				Tint:           crsFloat(fieldValue(item, uri, "LocalTint")),
+				Saturation:     crsFloat(fieldValue(item, uri, "LocalSaturation")),
+				Sharpness:      crsFloat(fieldValue(item, uri, "LocalSharpness")),
+				LuminanceNoise: crsFloat(fieldValue(item, uri, "LocalLuminanceNoise")),
+				Moire:          crsFloat(fieldValue(item, uri, "LocalMoire")),
+				Defringe:       crsFloat(fieldValue(item, uri, "LocalDefringe")),
+			},
+		}
+		if masks := item.Field(uri, "CorrectionMasks"); masks != nil {
+			corr.Masks = parseMasks(masks)
+		}
+		c = append(c, corr)
+	}
+	return c
+}
+
+// parseMasks parses the Masks of the array n.
+func parseMasks(n *Node) (masks []Mask) {
+	uri := xmpns.CrsNS.URI()
+	for _, item := range structItems(n) {
+		m := Mask{
+			What:         fieldValue(item, uri, "What"),
+			Value:        crsFloat(fieldValue(item, uri, "MaskValue")),
+			ZeroX:        crsFloat(fieldValue(item, uri, "ZeroX")),
+			ZeroY:        crsFloat(fieldValue(item, uri, "ZeroY")),
+			FullX:        crsFloat(fieldValue(item, uri, "FullX")),
+			FullY:        crsFloat(fieldValue(item, uri, "FullY")),
+			Top:          crsFloat(fieldValue(item, uri, "Top")),
+			Left:         crsFloat(fieldValue(item, uri, "Left")),
+			Bottom:       crsFloat(fieldValue(item, uri, "Bottom")),
+			Right:        crsFloat(fieldValue(item, uri, "Right")),
+			Angle:        crsFloat(fieldValue(item, uri, "Angle")),
+			Midpoint:     crsFloat(fieldValue(item, uri, "Midpoint")),
+			Roundness:    crsFloat(fieldValue(item, uri, "Roundness")),
+			Feather:      crsFloat(fieldValue(item, uri, "Feather")),
+			Flipped:      parseBool([]byte(fieldValue(item, uri, "Flipped"))),
+			Radius:       crsFloat(fieldValue(item, uri, "Radius")),
+			Flow:         crsFloat(fieldValue(item, uri, "Flow")),
+			CenterWeight: crsFloat(fieldValue(item, uri, "CenterWeight")),
+			Name:         fieldValue(item, uri, "MaskName"),
+			SubType:      crsInt(fieldValue(item, uri, "MaskSubType")),
+		}
+		for _, dab := range fieldValues(item, uri, "Dabs") {
+			if d, ok := parseDab(dab); ok {
+				m.Dabs = append(m.Dabs, d)
+			}
+		}
+		if sub := item.Field(uri, "Masks"); sub != nil {
+			m.Masks = parseMasks(sub)
+		}
+		masks = append(masks, m)
+	}
+	return masks
+}
+
+// parseDab parses a brush Dab "d x y".
+func parseDab(str string) (d Dab, ok bool) {
+	f := strings.Fields(str)
+	if len(f) != 3 || f[0] != "d" {
+		return d, false
+	}
+	return Dab{X: crsFloat(f[1]), Y: crsFloat(f[2])}, true
+}
+
+// parseCurve parses the tone curve points "x, y" of the array n.
+func parseCurve(n *Node) (curve []Double) {
+	for _, point := range n.Values() {
+		if i := strings.IndexByte(point, ','); i > 0 {
+			curve = append(curve, Double{uint16(crsInt(point[:i])), uint16(crsInt(point[i+1:]))})
+		}
+	}
+	return curve
+}
+
+// crsFloat parses a crs number, ex: "+0.25".
+func crsFloat(str string) float64 {
+	return parseFloat64([]byte(strings.TrimSpace(str)))
+}
+
+// crsUint parses a crs value, ex: "50000". Values out of the range of a
+// uint16 are clamped.
+func crsUint(str string) uint16 {
+	f := math.Round(crsFloat(str))
+	if f > math.MaxUint16 {
+		return math.MaxUint16
+	} else if f < 0 {
+		return 0
+	}
+	return uint16(f)
+}
+
+// crsInt parses a crs slider value, ex: "+11". Values out of the range of an
+// int16 are clamped.
+func crsInt(str string) int16 {
+	f := math.Round(crsFloat(str))
+	if f > math.MaxInt16 {
+		return math.MaxInt16
+	} else if f < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(f)
 }
@@ -0,0 +1,147 @@
+package xmp
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCRSXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:crs="http://ns.adobe.com/camera-raw-settings/1.0/"
+    crs:HasCrop="True"
+    crs:Temperature="50000"
+    crs:CropTop="0.1"
+    crs:CropLeft="0.05"
+    crs:CropBottom="0.9"
+    crs:CropRight="0.95"
+    crs:CropAngle="-1.5"
+    crs:Texture="+15"
+    crs:Dehaze="-5"
+    crs:HueAdjustmentOrange="-10"
+    crs:LuminanceAdjustmentMagenta="+20"
+    crs:LensProfileName="Adobe (Canon EF 35mm f/1.4L USM)">
+   <crs:GradientBasedCorrections>
+    <rdf:Seq>
+     <rdf:li>
+      <rdf:Description
+       crs:What="Correction"
+       crs:CorrectionAmount="1.000000"
+       crs:CorrectionActive="true"
+       crs:LocalExposure2012="-0.500000"
+       crs:LocalDehaze="0.250000">
+      <crs:CorrectionMasks>
+       <rdf:Seq>
+        <rdf:li
+         crs:What="Mask/Gradient"
+         crs:MaskValue="1.000000"
+         crs:ZeroX="0.5"
+         crs:ZeroY="0.2"
+         crs:FullX="0.5"
+         crs:FullY="0.4"/>
+       </rdf:Seq>
+      </crs:CorrectionMasks>
+      </rdf:Description>
+     </rdf:li>
+    </rdf:Seq>
+   </crs:GradientBasedCorrections>
+   <crs:CircularGradientBasedCorrections>
+    <rdf:Seq>
+     <rdf:li crs:CorrectionActive="true" crs:LocalClarity2012="0.3">
+      <crs:CorrectionMasks>
+       <rdf:Seq>
+        <rdf:li crs:What="Mask/CircularGradient" crs:Top="0.2" crs:Left="0.3" crs:Bottom="0.6" crs:Right="0.7" crs:Angle="10" crs:Feather="50" crs:Flipped="true"/>
+       </rdf:Seq>
+      </crs:CorrectionMasks>
+     </rdf:li>
+    </rdf:Seq>
+   </crs:CircularGradientBasedCorrections>
+   <crs:PaintBasedCorrections>
+    <rdf:Seq>
+     <rdf:li crs:CorrectionActive="false" crs:LocalTemperature="-0.2">
+      <crs:CorrectionMasks>
+       <rdf:Seq>
+        <rdf:li crs:What="Mask/Paint" crs:Radius="0.05" crs:Flow="1" crs:CenterWeight="0">
+         <crs:Dabs>
+          <rdf:Seq>
+           <rdf:li>d 0.100000 0.200000</rdf:li>
+           <rdf:li>d 0.150000 0.250000</rdf:li>
+          </rdf:Seq>
+         </crs:Dabs>
+        </rdf:li>
+       </rdf:Seq>
+      </crs:CorrectionMasks>
+     </rdf:li>
+    </rdf:Seq>
+   </crs:PaintBasedCorrections>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestCRS(t *testing.T) {
+	f, err := os.Open("test/1.xmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := ParseXmp(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	crs := x.CRS
+	assert.Equal(t, "11.4.1", crs.Version)
+	assert.Equal(t, "11.0", crs.ProcessVersion)
+	assert.Equal(t, "_MG_1563.CR2", crs.RawFileName)
+	assert.Equal(t, "As Shot", crs.WhiteBalance)
+	assert.Equal(t, uint16(6050), crs.Temperature)
+	assert.Equal(t, int16(11), crs.Tint)
+	assert.Equal(t, -0.25, crs.Exposure2012)
+	assert.Equal(t, int16(9), crs.Contrast2012)
+	assert.Equal(t, int16(22), crs.Shadows2012)
+	assert.Equal(t, int16(16), crs.Whites2012)
+	assert.Equal(t, int16(-33), crs.Blacks2012)
+	assert.Equal(t, "Medium Contrast", crs.ToneCurveName)
+	assert.Equal(t, []Double{{0, 0}, {32, 22}, {64, 56}, {128, 128}, {192, 196}, {255, 255}}, crs.ToneCurve.Master)
+	// the tone curve of the Look is not the tone curve of the image
+	assert.Equal(t, []Double{{0, 0}, {255, 255}}, crs.ToneCurvePV2012.Master)
+	assert.Equal(t, Look{Name: "Adobe Color", UUID: "B952C231111CD8E0ECCF14B86BAA7077", Amount: 1}, crs.Look)
+	assert.Equal(t, LensProfile{Setup: "LensDefaults"}, crs.LensProfile)
+	assert.True(t, crs.HasSettings)
+
+	x, err = ParseXmp(strings.NewReader(testCRSXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	crs = x.CRS
+	assert.Equal(t, uint16(50000), crs.Temperature)
+	assert.Equal(t, Crop{HasCrop: true, Top: 0.1, Left: 0.05, Bottom: 0.9, Right: 0.95, Angle: -1.5}, crs.Crop)
+	assert.Equal(t, int16(15), crs.Texture)
+	assert.Equal(t, int16(-5), crs.Dehaze)
+	assert.Equal(t, int16(-10), crs.HSL.Hue[HSLOrange])
+	assert.Equal(t, int16(20), crs.HSL.Luminance[HSLMagenta])
+	assert.Equal(t, "Adobe (Canon EF 35mm f/1.4L USM)", crs.LensProfile.Name)
+	if !assert.Len(t, crs.Corrections, 3) {
+		return
+	}
+	assert.Equal(t, Correction{
+		Kind:        GradientCorrection,
+		Active:      true,
+		Amount:      1,
+		Adjustments: LocalAdjustments{Exposure: -0.5, Dehaze: 0.25},
+		Masks:       []Mask{{What: "Mask/Gradient", Value: 1, ZeroX: 0.5, ZeroY: 0.2, FullX: 0.5, FullY: 0.4}},
+	}, crs.Corrections[0])
+	assert.Equal(t, Correction{
+		Kind:        RadialCorrection,
+		Active:      true,
+		Adjustments: LocalAdjustments{Clarity: 0.3},
+		Masks:       []Mask{{What: "Mask/CircularGradient", Top: 0.2, Left: 0.3, Bottom: 0.6, Right: 0.7, Angle: 10, Feather: 50, Flipped: true}},
+	}, crs.Corrections[1])
+	assert.Equal(t, "Brush", crs.Corrections[2].Kind.String())
+	assert.False(t, crs.Corrections[2].Active)
+	assert.Equal(t, -0.2, crs.Corrections[2].Adjustments.Temperature)
+	assert.Equal(t, []Dab{{0.1, 0.2}, {0.15, 0.25}}, crs.Corrections[2].Masks[0].Dabs)
+	assert.Equal(t, 0.05, crs.Corrections[2].Masks[0].Radius)
+}
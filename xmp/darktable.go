@@ -0,0 +1,276 @@
+package xmp
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// Darktable Errors
+var (
+	// ErrDarktableParams is returned when the params of a darktable operation
+	// are not of the module or of a supported module version.
+	ErrDarktableParams = errors.New("xmp: error unsupported darktable params")
+)
+
+// Darktable is the darktable editing history of an XMP sidecar.
+//
+//	xmlns:darktable="http://darktable.sf.net/"
+//
+// This implementation is based on https://github.com/darktable-org/darktable/blob/master/src/common/exif.cc
+type Darktable struct {
+	XMPVersion      int
+	IOPOrderVersion int
+	// HistoryEnd is the number of History operations applied to the image
+	HistoryEnd         int
+	AutoPresetsApplied bool
+	History            []DarktableOperation
+	MasksHistory       []DarktableMask
+}
+
+// Applied returns the History operations applied to the image, the history
+// up to HistoryEnd.
+func (dt Darktable) Applied() []DarktableOperation {
+	if dt.HistoryEnd < len(dt.History) {
+		return dt.History[:dt.HistoryEnd]
+	}
+	return dt.History
+}
+
+// DarktableOperation is an operation of the darktable History. Params are
+// the parameters of the module, ex: exposure, in the C struct layout of the
+// ModVersion.
+type DarktableOperation struct {
+	Num            int
+	Operation      string // Module name, ex: "exposure"
+	Enabled        bool
+	ModVersion     int
+	Params         []byte
+	MultiName      string
+	MultiPriority  int
+	BlendopVersion int
+	BlendopParams  []byte
+}
+
+// DarktableMask is a mask shape of the darktable masks history.
+type DarktableMask struct {
+	Num     int // Num of the History operation
+	ID      int
+	Type    int
+	Name    string
+	Version int
+	Points  []byte
+	NbPoint int
+	Src     []byte
+}
+
+// parseDocument parses the darktable history from the Document.
+func (dt *Darktable) parseDocument(d *Document) {
+	uri := xmpns.DarktableNS.URI()
+	for i := range d.Properties {
+		n := &d.Properties[i]
+		if n.Namespace != uri {
+			continue
+		}
+		switch n.Name {
+		case "xmp_version":
+			dt.XMPVersion = dtInt(n.Value)
+		case "iop_order_version":
+			dt.IOPOrderVersion = dtInt(n.Value)
+		case "history_end":
+			dt.HistoryEnd = dtInt(n.Value)
+		case "auto_presets_applied":
+			dt.AutoPresetsApplied = parseBool([]byte(n.Value))
+		case "history":
+			for _, item := range structItems(n) {
+				op := DarktableOperation{
+					Num:            dtInt(fieldValue(item, uri, "num")),
+					Operation:      fieldValue(item, uri, "operation"),
+					Enabled:        parseBool([]byte(fieldValue(item, uri, "enabled"))),
+					ModVersion:     dtInt(fieldValue(item, uri, "modversion")),
+					MultiName:      fieldValue(item, uri, "multi_name"),
+					MultiPriority:  dtInt(fieldValue(item, uri, "multi_priority")),
+					BlendopVersion: dtInt(fieldValue(item, uri, "blendop_version")),
+				}
+				op.Params, _ = decodeDarktableBlob(fieldValue(item, uri, "params"))
+				op.BlendopParams, _ = decodeDarktableBlob(fieldValue(item, uri, "blendop_params"))
+				dt.History = append(dt.History, op)
+			}
+		case "masks_history":
+			for _, item := range structItems(n) {
+				m := DarktableMask{
+					Num:     dtInt(fieldValue(item, uri, "mask_num")),
+					ID:      dtInt(fieldValue(item, uri, "mask_id")),
+					Type:    dtInt(fieldValue(item, uri, "mask_type")),
+					Name:    fieldValue(item, uri, "mask_name"),
+					Version: dtInt(fieldValue(item, uri, "mask_version")),
+					NbPoint: dtInt(fieldValue(item, uri, "mask_nb")),
+				}
+				m.Points, _ = decodeDarktableBlob(fieldValue(item, uri, "mask_points"))
+				m.Src, _ = decodeDarktableBlob(fieldValue(item, uri, "mask_src"))
+				dt.MasksHistory = append(dt.MasksHistory, m)
+			}
+		}
+	}
+}
+
+// decodeDarktableBlob decodes a darktable binary blob. Blobs are hex
+// encoded, or zlib compressed and base64 encoded with a "gz" prefix and a
+// two digit compression factor, ex: "gz12eJxjYGBg...".
+func decodeDarktableBlob(str string) ([]byte, error) {
+	if str == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(str, "gz") {
+		buf, err := hex.DecodeString(str)
+		if err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+	if len(str) < 4 {
+		return nil, base64.CorruptInputError(len(str))
+	}
+	buf, err := base64.StdEncoding.DecodeString(str[4:])
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// DarktableExposure is the params of the darktable exposure module.
+type DarktableExposure struct {
+	Mode                   int32 // 0 is manual, 1 is automatic (deflicker)
+	Black                  float32
+	Exposure               float32 // EV
+	DeflickerPercentile    float32
+	DeflickerTargetLevel   float32
+	CompensateExposureBias bool
+}
+
+// Exposure returns the params of an exposure operation.
+// Supports module versions 5 and 6.
+func (op DarktableOperation) Exposure() (e DarktableExposure, err error) {
+	p := op.Params
+	if op.Operation != "exposure" || op.ModVersion < 5 || len(p) < 20 {
+		return e, ErrDarktableParams
+	}
+	e = DarktableExposure{
+		Mode:                 int32(binary.LittleEndian.Uint32(p)),
+		Black:                dtFloat(p[4:]),
+		Exposure:             dtFloat(p[8:]),
+		DeflickerPercentile:  dtFloat(p[12:]),
+		DeflickerTargetLevel: dtFloat(p[16:]),
+	}
+	if op.ModVersion >= 6 && len(p) >= 24 {
+		e.CompensateExposureBias = binary.LittleEndian.Uint32(p[20:]) != 0
+	}
+	return e, nil
+}
+
+// DarktableTemperature is the params of the darktable white balance
+// (temperature) module. The coefficients are channel multipliers.
+type DarktableTemperature struct {
+	Red, Green, Blue, G2 float32
+}
+
+// Temperature returns the params of a temperature operation.
+// Supports module versions 3 and later.
+func (op DarktableOperation) Temperature() (t DarktableTemperature, err error) {
+	p := op.Params
+	if op.Operation != "temperature" || op.ModVersion < 3 || len(p) < 16 {
+		return t, ErrDarktableParams
+	}
+	return DarktableTemperature{
+		Red:   dtFloat(p),
+		Green: dtFloat(p[4:]),
+		Blue:  dtFloat(p[8:]),
+		G2:    dtFloat(p[12:]),
+	}, nil
+}
+
+// DarktableCrop is the params of the darktable crop module. Left, Top,
+// Right and Bottom are normalized to the image.
+type DarktableCrop struct {
+	Left, Top, Right, Bottom float32
+	RatioN, RatioD           int32 // Aspect ratio, -1 is free
+}
+
+// Crop returns the params of a crop operation.
+// Supports module version 1.
+func (op DarktableOperation) Crop() (c DarktableCrop, err error) {
+	p := op.Params
+	if op.Operation != "crop" || op.ModVersion != 1 || len(p) < 24 {
+		return c, ErrDarktableParams
+	}
+	return DarktableCrop{
+		Left:   dtFloat(p),
+		Top:    dtFloat(p[4:]),
+		Right:  dtFloat(p[8:]),
+		Bottom: dtFloat(p[12:]),
+		RatioN: int32(binary.LittleEndian.Uint32(p[16:])),
+		RatioD: int32(binary.LittleEndian.Uint32(p[20:])),
+	}, nil
+}
+
+// DarktableFlip is the params of the darktable orientation (flip) module.
+// Orientation is a bitmask of flip y (1), flip x (2) and swap xy (4),
+// -1 is the orientation of the image.
+type DarktableFlip struct {
+	Orientation int32
+}
+
+// mapDarktableOrientation maps darktable orientations to EXIF Orientations
+var mapDarktableOrientation = [8]meta.Orientation{
+	meta.OrientationHorizontal,
+	meta.OrientationMirrorVertical,
+	meta.OrientationMirrorHorizontal,
+	meta.OrientationRotate180,
+	meta.OrientationMirrorHorizontalRotate270,
+	meta.OrientationRotate90,
+	meta.OrientationRotate270,
+	meta.OrientationMirrorHorizontalRotate90,
+}
+
+// ExifOrientation returns the EXIF Orientation of the flip. Returns 0 when
+// the orientation of the image is used.
+func (f DarktableFlip) ExifOrientation() meta.Orientation {
+	if f.Orientation < 0 || f.Orientation > 7 {
+		return 0
+	}
+	return mapDarktableOrientation[f.Orientation]
+}
+
+// Flip returns the params of a flip operation.
+// Supports module versions 1 and 2.
+func (op DarktableOperation) Flip() (f DarktableFlip, err error) {
+	p := op.Params
+	if op.Operation != "flip" || op.ModVersion < 1 || op.ModVersion > 2 || len(p) < 4 {
+		return f, ErrDarktableParams
+	}
+	return DarktableFlip{Orientation: int32(binary.LittleEndian.Uint32(p))}, nil
+}
+
+func dtFloat(buf []byte) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf))
+}
+
+func dtInt(str string) int {
+	i, _ := strconv.Atoi(strings.TrimSpace(str))
+	return i
+}
@@ -0,0 +1,140 @@
+package xmp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+var testDarktableXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 4.4.0-Exiv2">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:darktable="http://darktable.sf.net/"
+   darktable:xmp_version="5"
+   darktable:raw_params="0"
+   darktable:auto_presets_applied="1"
+   darktable:history_end="4"
+   darktable:iop_order_version="3">
+   <darktable:masks_history>
+    <rdf:Seq>
+     <rdf:li
+      darktable:mask_num="2"
+      darktable:mask_id="1651234567"
+      darktable:mask_type="2"
+      darktable:mask_name="circle #1"
+      darktable:mask_version="6"
+      darktable:mask_points="0000003f0000003fcdcc4c3d"
+      darktable:mask_nb="1"
+      darktable:mask_src="0000000000000000"/>
+    </rdf:Seq>
+   </darktable:masks_history>
+   <darktable:history>
+    <rdf:Seq>
+     <rdf:li
+      darktable:num="0"
+      darktable:operation="exposure"
+      darktable:enabled="1"
+      darktable:modversion="6"
+      darktable:params="00000000000080b90000403f00004842000080c001000000"
+      darktable:multi_name=""
+      darktable:multi_priority="0"
+      darktable:blendop_version="11"
+      darktable:blendop_params="gz01eNo7e+aM7dkzPnZpaWn2QNqeAQoAkXsIWw=="/>
+     <rdf:li
+      darktable:num="1"
+      darktable:operation="temperature"
+      darktable:enabled="1"
+      darktable:modversion="3"
+      darktable:params="000000400000803f0000c03f0000803f"
+      darktable:multi_name=""
+      darktable:multi_priority="0"/>
+     <rdf:li
+      darktable:num="2"
+      darktable:operation="crop"
+      darktable:enabled="1"
+      darktable:modversion="1"
+      darktable:params="gz01eNo7e+aM7dkzPnZpaWn2QNqeAQoAkXsIWw=="
+      darktable:multi_name=""
+      darktable:multi_priority="0"/>
+     <rdf:li
+      darktable:num="3"
+      darktable:operation="flip"
+      darktable:enabled="1"
+      darktable:modversion="2"
+      darktable:params="06000000"
+      darktable:multi_name=""
+      darktable:multi_priority="0"/>
+     <rdf:li
+      darktable:num="4"
+      darktable:operation="exposure"
+      darktable:enabled="0"
+      darktable:modversion="6"
+      darktable:params="zz"
+      darktable:multi_name="1"
+      darktable:multi_priority="1"/>
+    </rdf:Seq>
+   </darktable:history>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestDarktable(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testDarktableXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dt := x.Darktable
+	assert.Equal(t, 5, dt.XMPVersion)
+	assert.Equal(t, 3, dt.IOPOrderVersion)
+	assert.Equal(t, 4, dt.HistoryEnd)
+	assert.True(t, dt.AutoPresetsApplied)
+	if !assert.Len(t, dt.History, 5) {
+		return
+	}
+	assert.Len(t, dt.Applied(), 4)
+
+	op := dt.History[0]
+	assert.Equal(t, "exposure", op.Operation)
+	assert.True(t, op.Enabled)
+	assert.Equal(t, 11, op.BlendopVersion)
+	assert.Len(t, op.BlendopParams, 24)
+	e, err := op.Exposure()
+	assert.NoError(t, err)
+	assert.Equal(t, DarktableExposure{Black: -0.000244140625, Exposure: 0.75, DeflickerPercentile: 50, DeflickerTargetLevel: -4, CompensateExposureBias: true}, e)
+	_, err = op.Crop()
+	assert.ErrorIs(t, err, ErrDarktableParams)
+
+	wb, err := dt.History[1].Temperature()
+	assert.NoError(t, err)
+	assert.Equal(t, DarktableTemperature{Red: 2, Green: 1, Blue: 1.5, G2: 1}, wb)
+
+	c, err := dt.History[2].Crop()
+	assert.NoError(t, err)
+	assert.Equal(t, DarktableCrop{Left: 0.1, Top: 0.2, Right: 0.9, Bottom: 0.8}, c)
+
+	f, err := dt.History[3].Flip()
+	assert.NoError(t, err)
+	assert.Equal(t, meta.OrientationRotate270, f.ExifOrientation())
+	assert.Equal(t, meta.OrientationRotate90, DarktableFlip{Orientation: 5}.ExifOrientation())
+	assert.Equal(t, meta.Orientation(0), DarktableFlip{Orientation: -1}.ExifOrientation())
+
+	// invalid params
+	op = dt.History[4]
+	assert.False(t, op.Enabled)
+	assert.Nil(t, op.Params)
+	_, err = op.Exposure()
+	assert.ErrorIs(t, err, ErrDarktableParams)
+
+	if assert.Len(t, dt.MasksHistory, 1) {
+		m := dt.MasksHistory[0]
+		assert.Equal(t, DarktableMask{Num: 2, ID: 1651234567, Type: 2, Name: "circle #1", Version: 6, NbPoint: 1,
+			Points: []byte{0, 0, 0, 0x3f, 0, 0, 0, 0x3f, 0xcd, 0xcc, 0x4c, 0x3d}, Src: make([]byte, 8)}, m)
+	}
+
+	for _, s := range []string{"gz", "gz01!!", "gz01AAAA"} {
+		_, err = decodeDarktableBlob(s)
+		assert.Error(t, err, s)
+	}
+}
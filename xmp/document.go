@@ -0,0 +1,441 @@
+package xmp
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// Kind is the kind of value of an XMP Node
+type Kind uint8
+
+// Node Kinds
+const (
+	SimpleKind Kind = iota // Simple value
+	StructKind             // Struct with Fields
+	BagKind                // rdf:Bag unordered array with Items
+	SeqKind                // rdf:Seq ordered array with Items
+	AltKind                // rdf:Alt alternative array with Items
+)
+
+// String returns the Kind as a string
+func (k Kind) String() string {
+	switch k {
+	case SimpleKind:
+		return "Simple"
+	case StructKind:
+		return "Struct"
+	case BagKind:
+		return "Bag"
+	case SeqKind:
+		return "Seq"
+	case AltKind:
+		return "Alt"
+	}
+	return "Unknown"
+}
+
+// IsArray returns true for the Bag, Seq and Alt Kinds
+func (k Kind) IsArray() bool {
+	return k == BagKind || k == SeqKind || k == AltKind
+}
+
+// Node is a property of an XMP Document. Struct fields, array items and
+// qualifiers are Nodes. Array items have no Namespace or Name.
+type Node struct {
+	Namespace  string // Namespace URI
+	Prefix     string // Namespace prefix
+	Name       string
+	Value      string
+	Kind       Kind
+	Qualifiers []Node // Qualifiers ex: xml:lang
+	Fields     []Node // Struct fields
+	Items      []Node // Array items
+}
+
+// Lang returns the xml:lang qualifier of the Node
+func (n *Node) Lang() string {
+	for i := range n.Qualifiers {
+		if n.Qualifiers[i].Namespace == xmpns.XMLNS.URI() && n.Qualifiers[i].Name == "lang" {
+			return n.Qualifiers[i].Value
+		}
+	}
+	return ""
+}
+
+// AltValue returns the value of the Item with the lang language. Falls back
+// to the "x-default" Item and then to the first Item. Returns the Value of a
+// Simple Node.
+func (n *Node) AltValue(lang string) string {
+	if !n.Kind.IsArray() {
+		return n.Value
+	}
+	if item := n.langItem(lang); item != nil {
+		return item.Value
+	}
+	if item := n.langItem("x-default"); item != nil {
+		return item.Value
+	}
+	if len(n.Items) > 0 {
+		return n.Items[0].Value
+	}
+	return ""
+}
+
+// Values returns the values of the Items of an array Node, or the Value of a
+// Simple Node.
+func (n *Node) Values() []string {
+	if !n.Kind.IsArray() {
+		if n.Kind == SimpleKind && n.Value != "" {
+			return []string{n.Value}
+		}
+		return nil
+	}
+	v := make([]string, 0, len(n.Items))
+	for _, item := range n.Items {
+		v = append(v, item.Value)
+	}
+	return v
+}
+
+// Field returns the struct field of the Node with the namespace URI and name.
+func (n *Node) Field(uri string, name string) *Node {
+	for i := range n.Fields {
+		if n.Fields[i].Namespace == uri && n.Fields[i].Name == name {
+			return &n.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Get returns the Node at the path relative to n, or nil if not found.
+// See Document.Get for the path syntax.
+func (n *Node) Get(path string) *Node {
+	return getPath(n, nil, path)
+}
+
+// structItems returns the structs of n. n is an array of structs or a
+// single struct.
+func structItems(n *Node) []*Node {
+	if n.Kind == StructKind {
+		return []*Node{n}
+	}
+	items := make([]*Node, 0, len(n.Items))
+	for i := range n.Items {
+		if n.Items[i].Kind == StructKind {
+			items = append(items, &n.Items[i])
+		}
+	}
+	return items
+}
+
+// fieldValue returns the value of the struct field of n, or the default
+// language value when the field is a language alternative.
+func fieldValue(n *Node, uri string, name string) string {
+	if f := n.Field(uri, name); f != nil {
+		return f.AltValue("x-default")
+	}
+	return ""
+}
+
+// fieldValues returns the values of the struct field array of n.
+func fieldValues(n *Node, uri string, name string) []string {
+	if f := n.Field(uri, name); f != nil {
+		return f.Values()
+	}
+	return nil
+}
+
+func (n *Node) langItem(lang string) *Node {
+	for i := range n.Items {
+		if strings.EqualFold(n.Items[i].Lang(), lang) {
+			return &n.Items[i]
+		}
+	}
+	return nil
+}
+
+// Document is the property tree of an XMP packet. It holds every property
+// of the packet, including the properties that are not decoded into the
+// typed structs of XMP.
+type Document struct {
+	Properties []Node
+	// Namespaces are the prefixes and URIs of the declared namespaces that
+	// are not known by xmpns.
+	Namespaces map[string]string
+}
+
+// Get returns the Node at the path, or nil if not found. A path is a list of
+// "prefix:name" steps separated by '/', ex:
+//
+//	Iptc4xmpCore:CreatorContactInfo/Iptc4xmpCore:CiEmailWork
+//
+// A step can select an array item by its 1-based index "dc:subject[2]", or
+// by its language "dc:title[?xml:lang=\"en-US\"]".
+func (d *Document) Get(path string) *Node {
+	return getPath(nil, d, path)
+}
+
+// Property returns the top level property with the namespace URI and name,
+// or nil if not found.
+func (d *Document) Property(uri string, name string) *Node {
+	for i := range d.Properties {
+		if d.Properties[i].Namespace == uri && d.Properties[i].Name == name {
+			return &d.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Set sets the top level property n. The property with the same Namespace
+// and Name is replaced, otherwise n is appended to the Properties.
+func (d *Document) Set(n Node) {
+	if p := d.Property(n.Namespace, n.Name); p != nil {
+		*p = n
+		return
+	}
+	d.Properties = append(d.Properties, n)
+}
+
+// Delete removes the top level property with the namespace URI and name.
+// Returns false if the property was not found.
+func (d *Document) Delete(uri string, name string) bool {
+	for i := range d.Properties {
+		if d.Properties[i].Namespace == uri && d.Properties[i].Name == name {
+			d.Properties = append(d.Properties[:i], d.Properties[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceURI returns the namespace URI of the prefix. Returns "" if the
+// prefix is not known.
+func (d *Document) namespaceURI(prefix string) string {
+	if ns := xmpns.IdentifyNamespace([]byte(prefix)); ns != xmpns.UnknownNS {
+		return ns.URI()
+	}
+	if d != nil {
+		return d.Namespaces[prefix]
+	}
+	return ""
+}
+
+// declare adds the namespace declaration of prefix to Namespaces.
+func (d *Document) declare(prefix string, uri string) {
+	if d.Namespaces == nil {
+		d.Namespaces = make(map[string]string)
+	}
+	if _, ok := d.Namespaces[prefix]; !ok {
+		d.Namespaces[prefix] = uri
+	}
+}
+
+// getPath returns the Node at the path relative to n, or to the Properties
+// of d when n is nil.
+func getPath(n *Node, d *Document, path string) *Node {
+	for _, step := range strings.Split(path, "/") {
+		name, sel := step, ""
+		if i := strings.IndexByte(step, '['); i >= 0 && strings.HasSuffix(step, "]") {
+			name, sel = step[:i], step[i+1:len(step)-1]
+		}
+		prefix := ""
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			prefix, name = name[:i], name[i+1:]
+		}
+		uri := d.namespaceURI(prefix)
+
+		var nodes []Node
+		if n == nil {
+			if d == nil {
+				return nil
+			}
+			nodes = d.Properties
+		} else {
+			nodes = n.Fields
+		}
+		if name != "" {
+			var next *Node
+			for i := range nodes {
+				if nodes[i].Name == name && (nodes[i].Namespace == uri || (uri == "" && nodes[i].Prefix == prefix)) {
+					next = &nodes[i]
+					break
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			n = next
+		}
+		if n == nil {
+			return nil
+		}
+		if sel != "" {
+			if n = selectItem(n, sel); n == nil {
+				return nil
+			}
+		}
+	}
+	return n
+}
+
+// selectItem returns the array item of n selected by sel, either a 1-based
+// index or a language ?xml:lang="lang".
+func selectItem(n *Node, sel string) *Node {
+	if !n.Kind.IsArray() {
+		return nil
+	}
+	if strings.HasPrefix(sel, "?xml:lang=") {
+		return n.langItem(strings.Trim(sel[len("?xml:lang="):], "\"'"))
+	}
+	i, err := strconv.Atoi(sel)
+	if err != nil || i < 1 || i > len(n.Items) {
+		return nil
+	}
+	return &n.Items[i-1]
+}
+
+// frameKind is the RDF role of an element of the packet.
+type frameKind uint8
+
+const (
+	frameNone        frameKind = iota // rdf:RDF and elements outside of rdf:Description
+	frameDescription                  // top level rdf:Description
+	frameProperty                     // property element, struct field or array item
+	frameArray                        // rdf:Bag, rdf:Seq or rdf:Alt of the parent Node
+	frameStruct                       // rdf:Description of the parent Node
+)
+
+type docFrame struct {
+	node *Node
+	kind frameKind
+}
+
+// docBuilder builds a Document from the elements, attributes and values
+// read by xmpReader. Nodes are only appended to the Node at the top of the
+// stack, so pointers into the stack remain valid.
+type docBuilder struct {
+	d      *Document
+	frames []docFrame
+}
+
+func (b *docBuilder) top() docFrame {
+	if len(b.frames) == 0 {
+		return docFrame{}
+	}
+	return b.frames[len(b.frames)-1]
+}
+
+// start adds the element p named by n.
+func (b *docBuilder) start(p xmpns.Property, n Node) {
+	if b.d == nil {
+		return
+	}
+	top, f := b.top(), docFrame{}
+	switch top.kind {
+	case frameNone:
+		if p.Equals(xmpns.RDFDescription) {
+			f.kind = frameDescription
+		}
+	case frameDescription:
+		if p.Namespace() != xmpns.RdfNS {
+			b.d.Properties = append(b.d.Properties, n)
+			f = docFrame{kind: frameProperty, node: &b.d.Properties[len(b.d.Properties)-1]}
+		}
+	case frameProperty, frameStruct:
+		switch {
+		case top.kind == frameProperty && p.Equals(xmpns.RDFBag):
+			top.node.Kind, f = BagKind, docFrame{kind: frameArray, node: top.node}
+		case top.kind == frameProperty && p.Equals(xmpns.RDFSeq):
+			top.node.Kind, f = SeqKind, docFrame{kind: frameArray, node: top.node}
+		case top.kind == frameProperty && p.Equals(xmpns.RDFAlt):
+			top.node.Kind, f = AltKind, docFrame{kind: frameArray, node: top.node}
+		case top.kind == frameProperty && p.Equals(xmpns.RDFDescription):
+			top.node.Kind, f = StructKind, docFrame{kind: frameStruct, node: top.node}
+		case p.Namespace() != xmpns.RdfNS || n.Name == "value":
+			top.node.Kind = StructKind
+			top.node.Fields = append(top.node.Fields, n)
+			f = docFrame{kind: frameProperty, node: &top.node.Fields[len(top.node.Fields)-1]}
+		}
+	case frameArray:
+		if p.Equals(xmpns.RDFLi) {
+			top.node.Items = append(top.node.Items, Node{})
+			f = docFrame{kind: frameProperty, node: &top.node.Items[len(top.node.Items)-1]}
+		}
+	}
+	b.frames = append(b.frames, f)
+}
+
+// rename sets the namespace of the current element when it is declared by
+// its own attributes.
+func (b *docBuilder) rename(n Node) {
+	if top := b.top(); top.kind == frameProperty && top.node.Name != "" {
+		top.node.Namespace, top.node.Prefix = n.Namespace, n.Prefix
+	}
+}
+
+// attr adds the attribute p of the current element with the name and value
+// of n.
+func (b *docBuilder) attr(p xmpns.Property, n Node) {
+	if b.d == nil || p.Namespace() == xmpns.XMLnsNS {
+		return
+	}
+	top := b.top()
+	switch top.kind {
+	case frameDescription:
+		if p.Namespace() != xmpns.RdfNS && p.Namespace() != xmpns.XMLNS {
+			b.d.Properties = append(b.d.Properties, n)
+		}
+	case frameProperty:
+		switch p.Namespace() {
+		case xmpns.RdfNS:
+			if n.Name == "parseType" && n.Value == "Resource" {
+				top.node.Kind = StructKind
+			} else if n.Name == "resource" {
+				top.node.Value = n.Value
+			}
+		case xmpns.XMLNS:
+			top.node.Qualifiers = append(top.node.Qualifiers, n)
+		default:
+			top.node.Kind = StructKind
+			top.node.Fields = append(top.node.Fields, n)
+		}
+	case frameStruct:
+		if p.Namespace() != xmpns.RdfNS && p.Namespace() != xmpns.XMLNS {
+			top.node.Fields = append(top.node.Fields, n)
+		}
+	}
+}
+
+// text sets the value of the current element.
+func (b *docBuilder) text(buf []byte) {
+	if b.d == nil || len(bytes.TrimSpace(buf)) == 0 {
+		return
+	}
+	if top := b.top(); top.kind == frameProperty && top.node.Kind == SimpleKind {
+		top.node.Value = string(buf)
+	}
+}
+
+// end closes the current element. A struct with an rdf:value field is a
+// value with qualifiers and is converted to a Simple Node.
+func (b *docBuilder) end() {
+	if b.d == nil || len(b.frames) == 0 {
+		return
+	}
+	f := b.frames[len(b.frames)-1]
+	b.frames = b.frames[:len(b.frames)-1]
+	if f.kind != frameProperty || f.node.Kind != StructKind {
+		return
+	}
+	for i := range f.node.Fields {
+		if v := f.node.Fields[i]; v.Namespace == xmpns.RdfNS.URI() && v.Name == "value" {
+			f.node.Qualifiers = append(f.node.Qualifiers, f.node.Fields[:i]...)
+			f.node.Qualifiers = append(f.node.Qualifiers, f.node.Fields[i+1:]...)
+			f.node.Kind, f.node.Value, f.node.Fields, f.node.Items = v.Kind, v.Value, v.Fields, v.Items
+			return
+		}
+	}
+}
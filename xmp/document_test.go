@@ -0,0 +1,129 @@
+package xmp
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDocumentXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+    xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
+    xmlns:stRef="http://ns.adobe.com/xap/1.0/sType/ResourceRef#"
+    xmlns:ex="http://example.com/ns/1.0/"
+    ex:Simple="value">
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Title</rdf:li>
+     <rdf:li xml:lang="de-DE">Titel</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+   <dc:subject>
+    <rdf:Bag>
+     <rdf:li>one</rdf:li>
+     <rdf:li>two</rdf:li>
+    </rdf:Bag>
+   </dc:subject>
+   <Iptc4xmpCore:CreatorContactInfo rdf:parseType="Resource">
+    <Iptc4xmpCore:CiEmailWork>name@example.com</Iptc4xmpCore:CiEmailWork>
+    <Iptc4xmpCore:CiAdrCity>Hong Kong</Iptc4xmpCore:CiAdrCity>
+   </Iptc4xmpCore:CreatorContactInfo>
+   <xmpMM:DerivedFrom stRef:documentID="doc1" stRef:instanceID="iid1"/>
+   <xmpMM:Ingredients>
+    <rdf:Bag>
+     <rdf:li>
+      <rdf:Description stRef:filePath="a.jpg">
+       <stRef:documentID>doc2</stRef:documentID>
+      </rdf:Description>
+     </rdf:li>
+    </rdf:Bag>
+   </xmpMM:Ingredients>
+   <ex:Qualified>
+    <rdf:Description>
+     <rdf:value>main</rdf:value>
+     <ex:Note>qualifier</ex:Note>
+    </rdf:Description>
+   </ex:Qualified>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestDocument(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testDocumentXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &x.Document
+	assert.Equal(t, map[string]string{"ex": "http://example.com/ns/1.0/"}, d.Namespaces)
+
+	tests := []struct {
+		path  string
+		kind  Kind
+		value string
+	}{
+		{"ex:Simple", SimpleKind, "value"},
+		{"dc:title", AltKind, ""},
+		{"dc:title[2]", SimpleKind, "Titel"},
+		{"dc:title[?xml:lang=\"de-de\"]", SimpleKind, "Titel"},
+		{"dc:subject[1]", SimpleKind, "one"},
+		{"Iptc4xmpCore:CreatorContactInfo", StructKind, ""},
+		{"Iptc4xmpCore:CreatorContactInfo/Iptc4xmpCore:CiEmailWork", SimpleKind, "name@example.com"},
+		{"xmpMM:DerivedFrom/stRef:instanceID", SimpleKind, "iid1"},
+		{"xmpMM:Ingredients[1]/stRef:filePath", SimpleKind, "a.jpg"},
+		{"xmpMM:Ingredients[1]/stRef:documentID", SimpleKind, "doc2"},
+		{"ex:Qualified", SimpleKind, "main"},
+	}
+	for _, tt := range tests {
+		n := d.Get(tt.path)
+		if !assert.NotNil(t, n, tt.path) {
+			continue
+		}
+		assert.Equal(t, tt.kind, n.Kind, tt.path)
+		assert.Equal(t, tt.value, n.Value, tt.path)
+	}
+	for _, path := range []string{"dc:title[3]", "dc:subject[x]", "dc:creator", "ex:Simple[1]", "Iptc4xmpCore:CreatorContactInfo/dc:title"} {
+		assert.Nil(t, d.Get(path), path)
+	}
+
+	title := d.Get("dc:title")
+	assert.Equal(t, "Titel", title.AltValue("de-DE"))
+	assert.Equal(t, "Title", title.AltValue("fr-FR"))
+	assert.Equal(t, "de-DE", title.Items[1].Lang())
+	assert.Equal(t, []string{"one", "two"}, d.Get("dc:subject").Values())
+	assert.Equal(t, "qualifier", d.Get("ex:Qualified").Qualifiers[0].Value)
+	assert.Equal(t, "Hong Kong", d.Get("Iptc4xmpCore:CreatorContactInfo").Get("Iptc4xmpCore:CiAdrCity").Value)
+
+	// typed structs are decoded alongside the Document
+	assert.Equal(t, []string{"one", "two"}, x.DC.Subject)
+}
+
+func TestParseXmpDocument(t *testing.T) {
+	packets := map[string]string{
+		"document":  testDocumentXMP,
+		"crs":       testCRSXMP,
+		"darktable": testDarktableXMP,
+		"iptc":      testIptcXMP,
+		"mm":        testPantryXMP,
+		"regions":   testRegionsXMP,
+	}
+	for _, name := range []string{"test/1.xmp", "test/jpeg.xmp"} {
+		buf, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packets[name] = string(buf)
+	}
+	for name, packet := range packets {
+		x, err := ParseXmp(strings.NewReader(packet))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every packet is read into the Document by default
+		assert.NotEmpty(t, x.Document.Properties, name)
+	}
+}
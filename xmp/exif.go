@@ -57,10 +57,31 @@ type Exif struct {
 	Aperture         meta.Aperture
 	FocalLength      meta.FocalLength
 	SubjectDistance  float32
-	GPSLatitude      float64
-	GPSLongitude     float64
-	GPSAltitude      float32
-	GPSTimestamp     time.Time
+	ImageUniqueID    string // Unique identifier of the image, 128 bit hex string
+
+	// GPS
+	GPSLatitude          float64 // Decimal degrees, negative in the southern hemisphere
+	GPSLongitude         float64 // Decimal degrees, negative in the western hemisphere
+	GPSAltitude          float32 // Metres, negative below sea level
+	GPSAltitudeRef       uint8   // 0 is above sea level, 1 is below sea level
+	GPSTimestamp         time.Time
+	GPSImgDirection      float32 // Degrees
+	GPSImgDirectionRef   meta.GPSDirectionRef
+	GPSSpeed             float32 // In the unit of GPSSpeedRef
+	GPSSpeedRef          meta.GPSSpeedRef
+	GPSTrack             float32 // Degrees
+	GPSTrackRef          meta.GPSDirectionRef
+	GPSDestBearing       float32 // Degrees
+	GPSDestBearingRef    meta.GPSDirectionRef
+	GPSDOP               float32
+	GPSHPositioningError float32 // Metres, exifEX:GPSHPositioningError
+	GPSStatus            meta.GPSStatus
+	GPSMeasureMode       meta.GPSMeasureMode
+	GPSDifferential      bool
+	GPSMapDatum          string
+	GPSSatellites        string
+	GPSProcessingMethod  string
+	GPSVersionID         string // ex: "2.3.0.0"
 }
 
 func (exif *Exif) parse(p property) (err error) {
@@ -97,11 +118,59 @@ func (exif *Exif) parse(p property) (err error) {
 	case xmpns.ISOSpeedRatings:
 		exif.ISOSpeedRatings = parseUint32(p.val)
 	case xmpns.GPSLatitude:
-		exif.GPSLatitude = parseFloat64(p.Value())
+		exif.GPSLatitude, err = parseGPSCoordinate(p.Value())
 	case xmpns.GPSLongitude:
-		exif.GPSLongitude = parseFloat64(p.Value())
+		exif.GPSLongitude, err = parseGPSCoordinate(p.Value())
 	case xmpns.GPSAltitude:
-		exif.GPSAltitude = float32(parseFloat64(p.Value()))
+		exif.GPSAltitude = float32(parseRationalFloat(p.Value()))
+		if exif.GPSAltitudeRef == 1 {
+			exif.GPSAltitude = -exif.GPSAltitude
+		}
+	case xmpns.GPSAltitudeRef:
+		if exif.GPSAltitudeRef = parseUint8(p.Value()); exif.GPSAltitudeRef == 1 && exif.GPSAltitude > 0 {
+			exif.GPSAltitude = -exif.GPSAltitude
+		}
+	case xmpns.GPSTimeStamp:
+		// GPS time is UTC
+		if exif.GPSTimestamp, err = parseDate(p.Value()); err == nil {
+			exif.GPSTimestamp = exif.GPSTimestamp.UTC()
+		}
+	case xmpns.GPSImgDirection:
+		exif.GPSImgDirection = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSImgDirectionRef:
+		exif.GPSImgDirectionRef = meta.GPSDirectionRef(parseGPSRef(p.Value()))
+	case xmpns.GPSSpeed:
+		exif.GPSSpeed = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSSpeedRef:
+		exif.GPSSpeedRef = meta.GPSSpeedRef(parseGPSRef(p.Value()))
+	case xmpns.GPSTrack:
+		exif.GPSTrack = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSTrackRef:
+		exif.GPSTrackRef = meta.GPSDirectionRef(parseGPSRef(p.Value()))
+	case xmpns.GPSDestBearing:
+		exif.GPSDestBearing = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSDestBearingRef:
+		exif.GPSDestBearingRef = meta.GPSDirectionRef(parseGPSRef(p.Value()))
+	case xmpns.GPSDOP:
+		exif.GPSDOP = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSHPositioningError:
+		exif.GPSHPositioningError = float32(parseRationalFloat(p.Value()))
+	case xmpns.GPSStatus:
+		exif.GPSStatus = meta.GPSStatus(parseGPSRef(p.Value()))
+	case xmpns.GPSMeasureMode:
+		exif.GPSMeasureMode = meta.GPSMeasureMode(parseGPSRef(p.Value()))
+	case xmpns.GPSDifferential:
+		exif.GPSDifferential = parseUint8(p.Value()) == 1
+	case xmpns.GPSMapDatum:
+		exif.GPSMapDatum = parseString(p.Value())
+	case xmpns.GPSSatellites:
+		exif.GPSSatellites = parseString(p.Value())
+	case xmpns.GPSProcessingMethod:
+		exif.GPSProcessingMethod = parseString(p.Value())
+	case xmpns.GPSVersionID:
+		exif.GPSVersionID = parseString(p.Value())
+	case xmpns.ImageUniqueID:
+		exif.ImageUniqueID = parseString(p.Value())
 	//case xmpns.Flash:
 	default:
 		return ErrPropertyNotSet
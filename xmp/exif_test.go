@@ -0,0 +1,101 @@
+package xmp
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGPSCoordinate(t *testing.T) {
+	tests := []struct {
+		str string
+		f   float64
+		err error
+	}{
+		{"11,57.1312N", 11.952186666666667, nil},
+		{"120,11.573E", 120.19288333333333, nil},
+		{"33,51,54.36S", -33.8651, nil},
+		{"151, 12, 36W", -151.21, nil},
+		{"48.8584", 48.8584, nil},
+		{"-0.1278", -0.1278, nil},
+		{"45,60.0N", 0, ErrGPSCoordinate},
+		{"181,0.0E", 0, ErrGPSCoordinate},
+		{"1,2,3,4N", 0, ErrGPSCoordinate},
+		{"12,a.0N", 0, ErrGPSCoordinate},
+		{"", 0, ErrGPSCoordinate},
+	}
+	for _, tt := range tests {
+		f, err := parseGPSCoordinate([]byte(tt.str))
+		assert.ErrorIs(t, err, tt.err, tt.str)
+		if math.Abs(f-tt.f) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.str, tt.f, f)
+		}
+	}
+}
+
+var testGPSXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:exif="http://ns.adobe.com/exif/1.0/"
+    xmlns:exifEX="http://cipa.jp/exif/1.0/"
+    exif:GPSAltitudeRef="1"
+    exif:GPSAltitude="1234/10"
+    exif:GPSLatitude="33,51,54.36S"
+    exif:GPSLongitude="70,39.0W"
+    exif:GPSTimeStamp="2021-06-01T18:30:00+08:00"
+    exif:GPSImgDirectionRef="M"
+    exif:GPSImgDirection="27105/100"
+    exif:GPSSpeedRef="N"
+    exif:GPSSpeed="12/1"
+    exif:GPSTrackRef="T"
+    exif:GPSTrack="90"
+    exif:GPSDifferential="1"
+    exifEX:GPSHPositioningError="5/2"/>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestExifGPS(t *testing.T) {
+	f, err := os.Open("test/1.xmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := ParseXmp(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := x.Exif
+	assert.InDelta(t, 11.952186666666667, e.GPSLatitude, 1e-9)
+	assert.InDelta(t, 120.19288333333333, e.GPSLongitude, 1e-9)
+	assert.Equal(t, float32(6.9), e.GPSAltitude)
+	assert.Equal(t, time.Date(2021, 1, 10, 9, 30, 34, 576000000, time.UTC), e.GPSTimestamp)
+	assert.Equal(t, "2.3.0.0", e.GPSVersionID)
+	assert.Equal(t, "12", e.GPSSatellites)
+	assert.Equal(t, meta.GPSStatusActive, e.GPSStatus)
+	assert.Equal(t, meta.GPSMeasureMode3D, e.GPSMeasureMode)
+	assert.Equal(t, float32(8.6), e.GPSDOP)
+
+	x, err = ParseXmp(strings.NewReader(testGPSXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = x.Exif
+	assert.InDelta(t, -33.8651, e.GPSLatitude, 1e-9)
+	assert.InDelta(t, -70.65, e.GPSLongitude, 1e-9)
+	assert.Equal(t, float32(-123.4), e.GPSAltitude)
+	assert.Equal(t, uint8(1), e.GPSAltitudeRef)
+	assert.Equal(t, time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC), e.GPSTimestamp)
+	assert.Equal(t, float32(271.05), e.GPSImgDirection)
+	assert.Equal(t, meta.GPSDirectionRefMagneticNorth, e.GPSImgDirectionRef)
+	assert.Equal(t, float32(12), e.GPSSpeed)
+	assert.Equal(t, meta.GPSSpeedRefKnots, e.GPSSpeedRef)
+	assert.Equal(t, float32(90), e.GPSTrack)
+	assert.Equal(t, meta.GPSDirectionRefTrueNorth, e.GPSTrackRef)
+	assert.True(t, e.GPSDifferential)
+	assert.Equal(t, float32(2.5), e.GPSHPositioningError)
+}
@@ -0,0 +1,176 @@
+package xmp
+
+import (
+	"time"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// IptcCore is the IPTC Core schema for XMP.
+//
+//	xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+//
+// This implementation is incomplete and based on https://www.iptc.org/std/photometadata/specification/IPTC-PhotoMetadata
+type IptcCore struct {
+	CreatorContactInfo CreatorContactInfo
+	// Location is the name of a sublocation, ex: a street or a landmark
+	Location string
+	// CountryCode is the ISO 3166 country code of the location shown
+	CountryCode string
+	// IntellectualGenre describes the nature, intellectual, artistic or journalistic
+	// characteristic of the content
+	IntellectualGenre string
+	// Scene are IPTC Scene-NewsCodes, ex: "011900"
+	Scene []string
+	// SubjectCode are IPTC Subject-NewsCodes, ex: "15065000"
+	SubjectCode []string
+}
+
+// CreatorContactInfo is the contact information of the creator of the image.
+type CreatorContactInfo struct {
+	Address    string // Iptc4xmpCore:CiAdrExtadr
+	City       string // Iptc4xmpCore:CiAdrCity
+	Region     string // Iptc4xmpCore:CiAdrRegion
+	PostalCode string // Iptc4xmpCore:CiAdrPcode
+	Country    string // Iptc4xmpCore:CiAdrCtry
+	Phone      string // Iptc4xmpCore:CiTelWork
+	Email      string // Iptc4xmpCore:CiEmailWork
+	URL        string // Iptc4xmpCore:CiUrlWork
+}
+
+func (core *IptcCore) parse(p property) (err error) {
+	switch p.Name() {
+	case xmpns.Location:
+		core.Location = parseString(p.Value())
+	case xmpns.CountryCode:
+		core.CountryCode = parseString(p.Value())
+	case xmpns.IntellectualGenre:
+		core.IntellectualGenre = parseString(p.Value())
+	case xmpns.Scene:
+		core.Scene = append(core.Scene, parseString(p.Value()))
+	case xmpns.SubjectCode:
+		core.SubjectCode = append(core.SubjectCode, parseString(p.Value()))
+	case xmpns.CiAdrExtadr:
+		core.CreatorContactInfo.Address = parseString(p.Value())
+	case xmpns.CiAdrCity:
+		core.CreatorContactInfo.City = parseString(p.Value())
+	case xmpns.CiAdrRegion:
+		core.CreatorContactInfo.Region = parseString(p.Value())
+	case xmpns.CiAdrPcode:
+		core.CreatorContactInfo.PostalCode = parseString(p.Value())
+	case xmpns.CiAdrCtry:
+		core.CreatorContactInfo.Country = parseString(p.Value())
+	case xmpns.CiTelWork:
+		core.CreatorContactInfo.Phone = parseString(p.Value())
+	case xmpns.CiEmailWork:
+		core.CreatorContactInfo.Email = parseString(p.Value())
+	case xmpns.CiUrlWork:
+		core.CreatorContactInfo.URL = parseString(p.Value())
+	default:
+		return ErrPropertyNotSet
+	}
+	return
+}
+
+// IptcExt is the IPTC Extension schema for XMP.
+//
+//	xmlns:Iptc4xmpExt="http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+//
+// This implementation is incomplete and based on https://www.iptc.org/std/photometadata/specification/IPTC-PhotoMetadata
+type IptcExt struct {
+	// PersonInImage are the names of the persons shown in the image
+	PersonInImage []string
+	// LocationCreated is the location the image was created
+	LocationCreated []IptcLocation
+	// LocationShown are the locations shown in the image
+	LocationShown []IptcLocation
+	// ArtworkOrObject are the artworks or objects shown in the image
+	ArtworkOrObject []ArtworkOrObject
+	// DigitalSourceType is the IPTC Digital Source Type NewsCode URI,
+	// ex: "http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture"
+	DigitalSourceType string
+}
+
+// IptcLocation is an IPTC Extension location.
+type IptcLocation struct {
+	Name          string // Iptc4xmpExt:LocationName in the default language
+	Sublocation   string
+	City          string
+	ProvinceState string
+	CountryName   string
+	CountryCode   string
+	WorldRegion   string
+	LocationID    []string // Iptc4xmpExt:LocationId URIs
+}
+
+// ArtworkOrObject is an IPTC Extension artwork or object in the image.
+type ArtworkOrObject struct {
+	DateCreated           time.Time // Iptc4xmpExt:AODateCreated
+	Title                 string    // Iptc4xmpExt:AOTitle in the default language
+	Creator               []string  // Iptc4xmpExt:AOCreator
+	Source                string    // Iptc4xmpExt:AOSource
+	SourceInventoryNumber string    // Iptc4xmpExt:AOSourceInvNo
+	CopyrightNotice       string    // Iptc4xmpExt:AOCopyrightNotice
+}
+
+// parse parses the simple properties of the IPTC Extension. Structs are
+// parsed from the Document by parseDocument.
+func (ext *IptcExt) parse(p property) (err error) {
+	switch p.Name() {
+	case xmpns.PersonInImage:
+		ext.PersonInImage = append(ext.PersonInImage, parseString(p.Value()))
+	case xmpns.DigitalSourceType:
+		ext.DigitalSourceType = parseString(p.Value())
+	default:
+		return ErrPropertyNotSet
+	}
+	return
+}
+
+// parseDocument parses the IPTC Extension locations and artworks from the
+// Document.
+func (ext *IptcExt) parseDocument(d *Document) {
+	uri := xmpns.Iptc4xmpExtNS.URI()
+	for i := range d.Properties {
+		n := &d.Properties[i]
+		if n.Namespace != uri {
+			continue
+		}
+		switch n.Name {
+		case "LocationCreated":
+			for _, item := range structItems(n) {
+				ext.LocationCreated = append(ext.LocationCreated, iptcLocation(item))
+			}
+		case "LocationShown":
+			for _, item := range structItems(n) {
+				ext.LocationShown = append(ext.LocationShown, iptcLocation(item))
+			}
+		case "ArtworkOrObject":
+			for _, item := range structItems(n) {
+				ao := ArtworkOrObject{
+					Title:                 fieldValue(item, uri, "AOTitle"),
+					Creator:               fieldValues(item, uri, "AOCreator"),
+					Source:                fieldValue(item, uri, "AOSource"),
+					SourceInventoryNumber: fieldValue(item, uri, "AOSourceInvNo"),
+					CopyrightNotice:       fieldValue(item, uri, "AOCopyrightNotice"),
+				}
+				ao.DateCreated, _ = parseDate([]byte(fieldValue(item, uri, "AODateCreated")))
+				ext.ArtworkOrObject = append(ext.ArtworkOrObject, ao)
+			}
+		}
+	}
+}
+
+func iptcLocation(n *Node) IptcLocation {
+	uri := xmpns.Iptc4xmpExtNS.URI()
+	return IptcLocation{
+		Name:          fieldValue(n, uri, "LocationName"),
+		Sublocation:   fieldValue(n, uri, "Sublocation"),
+		City:          fieldValue(n, uri, "City"),
+		ProvinceState: fieldValue(n, uri, "ProvinceState"),
+		CountryName:   fieldValue(n, uri, "CountryName"),
+		CountryCode:   fieldValue(n, uri, "CountryCode"),
+		WorldRegion:   fieldValue(n, uri, "WorldRegion"),
+		LocationID:    fieldValues(n, uri, "LocationId"),
+	}
+}
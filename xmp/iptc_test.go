@@ -0,0 +1,148 @@
+package xmp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testIptcXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/"
+    xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+    xmlns:Iptc4xmpExt="http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+    xmlns:lr="http://ns.adobe.com/lightroom/1.0/"
+    xmlns:xmpRights="http://ns.adobe.com/xap/1.0/rights/"
+    photoshop:Headline="Tom &amp; Jerry"
+    photoshop:City="Paris"
+    photoshop:State="Ile-de-France"
+    photoshop:Country="France"
+    photoshop:Credit="Credit Line"
+    photoshop:Source="Source"
+    photoshop:Instructions="Embargo"
+    photoshop:DateCreated="2021-06-01T10:11:12"
+    photoshop:Urgency="2"
+    Iptc4xmpCore:Location="Eiffel Tower"
+    Iptc4xmpCore:CountryCode="FR"
+    Iptc4xmpCore:IntellectualGenre="Feature"
+    Iptc4xmpExt:DigitalSourceType="http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture"
+    xmpRights:Marked="True"
+    xmpRights:WebStatement="https://example.com/license">
+   <Iptc4xmpCore:CreatorContactInfo
+    Iptc4xmpCore:CiAdrCity="Paris"
+    Iptc4xmpCore:CiAdrCtry="France"
+    Iptc4xmpCore:CiEmailWork="name@example.com"/>
+   <Iptc4xmpCore:Scene>
+    <rdf:Bag>
+     <rdf:li>011900</rdf:li>
+     <rdf:li>010700</rdf:li>
+    </rdf:Bag>
+   </Iptc4xmpCore:Scene>
+   <Iptc4xmpCore:SubjectCode>
+    <rdf:Bag>
+     <rdf:li>15065000</rdf:li>
+    </rdf:Bag>
+   </Iptc4xmpCore:SubjectCode>
+   <Iptc4xmpExt:PersonInImage>
+    <rdf:Bag>
+     <rdf:li>Jane Doe</rdf:li>
+    </rdf:Bag>
+   </Iptc4xmpExt:PersonInImage>
+   <Iptc4xmpExt:LocationShown>
+    <rdf:Bag>
+     <rdf:li Iptc4xmpExt:City="Paris" Iptc4xmpExt:CountryCode="FR"/>
+     <rdf:li rdf:parseType="Resource">
+      <Iptc4xmpExt:City>Lyon</Iptc4xmpExt:City>
+      <Iptc4xmpExt:LocationName>
+       <rdf:Alt>
+        <rdf:li xml:lang="x-default">Old Town</rdf:li>
+       </rdf:Alt>
+      </Iptc4xmpExt:LocationName>
+     </rdf:li>
+    </rdf:Bag>
+   </Iptc4xmpExt:LocationShown>
+   <Iptc4xmpExt:LocationCreated Iptc4xmpExt:Sublocation="Champ de Mars" Iptc4xmpExt:WorldRegion="Europe"/>
+   <Iptc4xmpExt:ArtworkOrObject>
+    <rdf:Bag>
+     <rdf:li rdf:parseType="Resource">
+      <Iptc4xmpExt:AOTitle>
+       <rdf:Alt>
+        <rdf:li xml:lang="x-default">Mona Lisa</rdf:li>
+       </rdf:Alt>
+      </Iptc4xmpExt:AOTitle>
+      <Iptc4xmpExt:AOCreator>
+       <rdf:Seq>
+        <rdf:li>Leonardo da Vinci</rdf:li>
+       </rdf:Seq>
+      </Iptc4xmpExt:AOCreator>
+      <Iptc4xmpExt:AODateCreated>1503</Iptc4xmpExt:AODateCreated>
+      <Iptc4xmpExt:AOSourceInvNo>779</Iptc4xmpExt:AOSourceInvNo>
+     </rdf:li>
+    </rdf:Bag>
+   </Iptc4xmpExt:ArtworkOrObject>
+   <lr:hierarchicalSubject>
+    <rdf:Bag>
+     <rdf:li>Places|Europe|France</rdf:li>
+     <rdf:li>People| Jane Doe |</rdf:li>
+    </rdf:Bag>
+   </lr:hierarchicalSubject>
+   <xmpRights:UsageTerms>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">All rights reserved</rdf:li>
+    </rdf:Alt>
+   </xmpRights:UsageTerms>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func TestIptc(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testIptcXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, Photoshop{
+		DateCreated:  time.Date(2021, 6, 1, 10, 11, 12, 0, time.UTC),
+		Headline:     "Tom & Jerry",
+		City:         "Paris",
+		State:        "Ile-de-France",
+		Country:      "France",
+		Credit:       "Credit Line",
+		Source:       "Source",
+		Instructions: "Embargo",
+		Urgency:      2,
+	}, x.Photoshop)
+
+	assert.Equal(t, IptcCore{
+		CreatorContactInfo: CreatorContactInfo{City: "Paris", Country: "France", Email: "name@example.com"},
+		Location:           "Eiffel Tower",
+		CountryCode:        "FR",
+		IntellectualGenre:  "Feature",
+		Scene:              []string{"011900", "010700"},
+		SubjectCode:        []string{"15065000"},
+	}, x.IptcCore)
+
+	assert.Equal(t, []string{"Jane Doe"}, x.IptcExt.PersonInImage)
+	assert.Equal(t, "http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture", x.IptcExt.DigitalSourceType)
+	assert.Equal(t, []IptcLocation{{City: "Paris", CountryCode: "FR"}, {City: "Lyon", Name: "Old Town"}}, x.IptcExt.LocationShown)
+	assert.Equal(t, []IptcLocation{{Sublocation: "Champ de Mars", WorldRegion: "Europe"}}, x.IptcExt.LocationCreated)
+	if assert.Len(t, x.IptcExt.ArtworkOrObject, 1) {
+		ao := x.IptcExt.ArtworkOrObject[0]
+		assert.Equal(t, "Mona Lisa", ao.Title)
+		assert.Equal(t, []string{"Leonardo da Vinci"}, ao.Creator)
+		assert.Equal(t, "779", ao.SourceInventoryNumber)
+		assert.Equal(t, 1503, ao.DateCreated.Year())
+	}
+
+	assert.Equal(t, [][]string{{"Places", "Europe", "France"}, {"People", "Jane Doe"}}, x.Lr.HierarchicalSubject)
+
+	assert.Equal(t, XMPRights{
+		WebStatement: "https://example.com/license",
+		UsageTerms:   []string{"All rights reserved"},
+		Marked:       true,
+		MarkedSet:    true,
+	}, x.Rights)
+}
@@ -0,0 +1,42 @@
+package xmp
+
+import (
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// Lightroom attributes of an XMP Packet.
+//
+//	xmlns:lr="http://ns.adobe.com/lightroom/1.0/"
+type Lightroom struct {
+	// HierarchicalSubject are the keyword paths from the root keyword,
+	// ex: "Places|Europe|France" is ["Places" "Europe" "France"]
+	HierarchicalSubject [][]string
+}
+
+func (lr *Lightroom) parse(p property) (err error) {
+	switch p.Name() {
+	case xmpns.HierarchicalSubject:
+		if path := parseKeywordPath(p.Value()); len(path) > 0 {
+			lr.HierarchicalSubject = append(lr.HierarchicalSubject, path)
+		}
+	default:
+		return ErrPropertyNotSet
+	}
+	return
+}
+
+// parseKeywordPath parses a "|" separated Lightroom keyword path.
+// Empty keywords are removed.
+func parseKeywordPath(buf []byte) []string {
+	path := strings.Split(string(buf), "|")
+	n := 0
+	for _, k := range path {
+		if k = strings.TrimSpace(k); k != "" {
+			path[n] = k
+			n++
+		}
+	}
+	return path[:n]
+}
@@ -1,6 +1,7 @@
 package xmp
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"strconv"
@@ -17,7 +18,7 @@ func (xmp *XMP) parser(p property) (err error) {
 	switch p.Namespace() {
 	case xmpns.XMLnsNS:
 		return // Null operation
-	case xmpns.ExifNS:
+	case xmpns.ExifNS, xmpns.ExifEXNS:
 		err = xmp.Exif.parse(p)
 	case xmpns.AuxNS:
 		err = xmp.Aux.parse(p)
@@ -27,10 +28,16 @@ func (xmp *XMP) parser(p property) (err error) {
 		err = xmp.Basic.parse(p)
 	case xmpns.TiffNS:
 		err = xmp.Tiff.parse(p)
-	case xmpns.CrsNS:
-		err = xmp.CRS.parse(p)
-	case xmpns.XmpMMNS, xmpns.XapMMNS:
-		err = xmp.MM.parse(p)
+	case xmpns.PhotoshopNS:
+		err = xmp.Photoshop.parse(p)
+	case xmpns.Iptc4xmpCoreNS:
+		err = xmp.IptcCore.parse(p)
+	case xmpns.Iptc4xmpExtNS:
+		err = xmp.IptcExt.parse(p)
+	case xmpns.LrNS:
+		err = xmp.Lr.parse(p)
+	case xmpns.XmpRightsNS:
+		err = xmp.Rights.parse(p)
 	default:
 		//fmt.Println(p, ns)
 		return
@@ -43,21 +50,38 @@ func (xmp *XMP) parser(p property) (err error) {
 	return
 }
 
+// dateLayouts are the XMP Date layouts. Dates may be reduced to the year,
+// month, day or minutes.
+var dateLayouts = [...]string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
 // parseDate parses a Date and returns a time.Time or an error
 func parseDate(buf []byte) (t time.Time, err error) {
 	str := string(buf)
-	if t, err = time.Parse("2006-01-02T15:04:05Z07:00", str); err != nil {
-		if t, err = time.Parse("2006-01-02T15:04:05.00", str); err != nil {
-			return time.Parse("2006-01-02T15:04:05", str)
+	for _, layout := range dateLayouts {
+		if t, err = time.Parse(layout, str); err == nil {
+			return
 		}
 	}
 	return
 }
 
-// parseUUID parses a UUID and returns a meta.UUID
+// parseUUID parses a UUID and returns a meta.UUID. The scheme of the
+// identifier is removed, ex: "xmp.did:" or "adobe:docid:photoshop:".
 func parseUUID(buf []byte) (uuid meta.UUID) {
-	if _, b := readUntil(buf, ':'); len(b) > 0 {
-		buf = b
+	if len(buf) == 0 {
+		return
+	}
+	if i := bytes.LastIndexByte(buf, ':'); i >= 0 {
+		buf = buf[i+1:]
 	}
 	err := uuid.UnmarshalText(buf)
 	if err != nil {
@@ -70,12 +94,14 @@ func parseUUID(buf []byte) (uuid meta.UUID) {
 
 // parseInt parses a []byte of a string representation of an int64 value and returns the value
 func parseInt(buf []byte) (i int64) {
-	if buf[0] == '-' {
-		buf = buf[1:]
-		i = -1
+	if len(buf) > 0 && (buf[0] == '-' || buf[0] == '+') {
+		i = int64(parseUint(buf[1:]))
+		if buf[0] == '-' {
+			i = -i
+		}
+		return
 	}
-	i *= int64(parseUint(buf))
-	return
+	return int64(parseUint(buf))
 }
 
 // parseUint parses a []byte of a string representation of a uint64 value and returns the value.
@@ -114,6 +140,11 @@ func parseFloat64(buf []byte) (f float64) {
 	return
 }
 
+// parseBool parses a []byte of "True" or "False" and returns the value
+func parseBool(buf []byte) bool {
+	return len(buf) > 0 && (buf[0] == 'T' || buf[0] == 't' || buf[0] == '1')
+}
+
 // parseString parses a []byte and returns a string
 func parseString(buf []byte) string {
 	return string(buf)
@@ -135,6 +166,65 @@ func parseRational(buf []byte) (n uint32, d uint32) {
 	return
 }
 
+// parseRationalFloat parses an XMP Rational "n/d" or a decimal value and
+// returns the value. Returns 0 if the denominator is 0.
+func parseRationalFloat(buf []byte) float64 {
+	buf = bytes.TrimSpace(buf)
+	if i := bytes.IndexByte(buf, '/'); i >= 0 {
+		if d := parseFloat64(buf[i+1:]); d != 0 {
+			return parseFloat64(buf[:i]) / d
+		}
+		return 0
+	}
+	return parseFloat64(buf)
+}
+
+// parseGPSCoordinate parses an XMP GPSCoordinate "DDD,MM,SSk" or
+// "DDD,MM.mmk" and returns the coordinate in decimal degrees. k is the
+// hemisphere N, S, E or W, S and W are negative. Decimal degrees "DDD.dd"
+// are also accepted.
+func parseGPSCoordinate(buf []byte) (f float64, err error) {
+	buf = bytes.TrimSpace(buf)
+	if len(buf) == 0 {
+		return 0, ErrGPSCoordinate
+	}
+	sign := 1.0
+	switch buf[len(buf)-1] {
+	case 'S', 's', 'W', 'w':
+		sign = -1.0
+		fallthrough
+	case 'N', 'n', 'E', 'e':
+		buf = buf[:len(buf)-1]
+	}
+	parts := bytes.Split(buf, []byte{','})
+	if len(parts) > 3 {
+		return 0, ErrGPSCoordinate
+	}
+	var dms [3]float64
+	for i, part := range parts {
+		if dms[i], err = strconv.ParseFloat(string(bytes.TrimSpace(part)), 64); err != nil {
+			return 0, ErrGPSCoordinate
+		}
+	}
+	if dms[1] < 0 || dms[1] >= 60 || dms[2] < 0 || dms[2] >= 60 {
+		return 0, ErrGPSCoordinate
+	}
+	f = dms[0] + dms[1]/60 + dms[2]/3600
+	if f > 180 || f < -180 {
+		return 0, ErrGPSCoordinate
+	}
+	return sign * f, nil
+}
+
+// parseGPSRef parses a single character GPS reference, ex: "K" of
+// GPSSpeedRef. Returns 0 if buf is empty.
+func parseGPSRef(buf []byte) byte {
+	if buf = bytes.TrimSpace(buf); len(buf) > 0 {
+		return buf[0]
+	}
+	return 0
+}
+
 func readUntil(buf []byte, delimiter byte) (a []byte, b []byte) {
 	for i := 0; i < len(buf); i++ {
 		if buf[i] == delimiter || buf[i] == '>' {
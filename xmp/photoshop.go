@@ -0,0 +1,61 @@
+package xmp
+
+import (
+	"time"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// Photoshop attributes of an XMP Packet. These are the IPTC-IIM legacy fields.
+//
+//	xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/"
+//
+// This implementation is incomplete and based on https://exiftool.org/TagNames/XMP.html#photoshop
+type Photoshop struct {
+	// The date the intellectual content of the document was created
+	DateCreated           time.Time
+	Headline              string
+	City                  string
+	State                 string // Province or State
+	Country               string
+	Credit                string // Credit line
+	Source                string
+	Instructions          string // Special instructions
+	AuthorsPosition       string // By-line title
+	CaptionWriter         string
+	TransmissionReference string // Original transmission reference or job identifier
+	// Urgency from 1 (most urgent) to 8 (least urgent). 0 is not set.
+	Urgency uint8
+}
+
+func (ps *Photoshop) parse(p property) (err error) {
+	switch p.Name() {
+	case xmpns.DateCreated:
+		ps.DateCreated, err = parseDate(p.Value())
+	case xmpns.Headline:
+		ps.Headline = parseString(p.Value())
+	case xmpns.City:
+		ps.City = parseString(p.Value())
+	case xmpns.State:
+		ps.State = parseString(p.Value())
+	case xmpns.Country:
+		ps.Country = parseString(p.Value())
+	case xmpns.Credit:
+		ps.Credit = parseString(p.Value())
+	case xmpns.Source:
+		ps.Source = parseString(p.Value())
+	case xmpns.Instructions:
+		ps.Instructions = parseString(p.Value())
+	case xmpns.AuthorsPosition:
+		ps.AuthorsPosition = parseString(p.Value())
+	case xmpns.CaptionWriter:
+		ps.CaptionWriter = parseString(p.Value())
+	case xmpns.TransmissionReference:
+		ps.TransmissionReference = parseString(p.Value())
+	case xmpns.Urgency:
+		ps.Urgency = parseUint8(p.Value())
+	default:
+		return ErrPropertyNotSet
+	}
+	return
+}
@@ -0,0 +1,255 @@
+package xmp
+
+import "sort"
+
+// Relation is the relation of a provenance Link
+type Relation uint8
+
+// Provenance Relations
+const (
+	// RelationDerived is a Link to an Asset from the Asset of its xmpMM:DerivedFrom.
+	RelationDerived Relation = iota + 1
+	// RelationOriginal is a Link to an Asset from the Asset of its xmpMM:OriginalDocumentID.
+	RelationOriginal
+	// RelationIngredient is a Link to an Asset from the Asset of one of its xmpMM:Ingredients.
+	RelationIngredient
+	// RelationDocument is a Link between Assets with the same xmpMM:DocumentID.
+	RelationDocument
+	// RelationImageUniqueID is a Link between Assets with the same Exif ImageUniqueID.
+	RelationImageUniqueID
+)
+
+// String returns the Relation as a string
+func (r Relation) String() string {
+	switch r {
+	case RelationDerived:
+		return "DerivedFrom"
+	case RelationOriginal:
+		return "OriginalDocumentID"
+	case RelationIngredient:
+		return "Ingredient"
+	case RelationDocument:
+		return "DocumentID"
+	case RelationImageUniqueID:
+		return "ImageUniqueID"
+	}
+	return "Unknown"
+}
+
+// Asset is the decoded metadata of a file of a provenance Graph.
+type Asset struct {
+	Name string // ex: the path of the file
+	XMP  *XMP   // The embedded or sidecar XMP of the file, may be nil
+	// ImageUniqueID is the Exif ImageUniqueID of the file. The
+	// exif:ImageUniqueID of the XMP is used when empty.
+	ImageUniqueID string
+}
+
+func (a *Asset) imageUniqueID() string {
+	if a.ImageUniqueID == "" && a.XMP != nil {
+		return a.XMP.Exif.ImageUniqueID
+	}
+	return a.ImageUniqueID
+}
+
+// Link is an edge of a provenance Graph. From and To are indexes of the
+// Assets of the Graph, From is the source of To.
+type Link struct {
+	From, To int
+	Relation Relation
+}
+
+// Graph is a provenance graph that links original Assets to the Assets
+// derived from them, ex: a RAW file to its edited exports.
+type Graph struct {
+	Assets []Asset
+	Links  []Link
+}
+
+// NewGraph returns the provenance Graph of assets.
+//
+// Assets are linked by xmpMM:DerivedFrom, xmpMM:OriginalDocumentID and
+// xmpMM:Ingredients, which are resolved by InstanceID and then by
+// DocumentID. Identifiers are compared as written, they need not be UUIDs.
+// Assets with the same DocumentID or Exif ImageUniqueID are
+// linked from the first of them that has no source. Two Assets are linked
+// at most once.
+func NewGraph(assets []Asset) *Graph {
+	g := &Graph{Assets: assets}
+	docs := make(map[string][]int)
+	instances := make(map[string][]int)
+	images := make(map[string][]int)
+	for i := range assets {
+		if x := assets[i].XMP; x != nil {
+			if id := x.MM.RawDocumentID; id != "" {
+				docs[id] = append(docs[id], i)
+			}
+			if id := x.MM.RawInstanceID; id != "" {
+				instances[id] = append(instances[id], i)
+			}
+		}
+		if id := assets[i].imageUniqueID(); id != "" {
+			images[id] = append(images[id], i)
+		}
+	}
+
+	linked := make(map[[2]int]bool)
+	hasSource := make([]bool, len(assets))
+	link := func(from, to int, r Relation) {
+		if from == to || linked[[2]int{from, to}] || linked[[2]int{to, from}] {
+			return
+		}
+		linked[[2]int{from, to}] = true
+		hasSource[to] = true
+		g.Links = append(g.Links, Link{From: from, To: to, Relation: r})
+	}
+	resolve := func(ref ResourceRef) []int {
+		if l := instances[ref.RawInstanceID]; ref.RawInstanceID != "" && len(l) > 0 {
+			return l
+		}
+		if l := docs[ref.RawDocumentID]; ref.RawDocumentID != "" && len(l) > 0 {
+			return l
+		}
+		if ref.RawOriginalDocumentID != "" {
+			return docs[ref.RawOriginalDocumentID]
+		}
+		return nil
+	}
+
+	for i := range assets {
+		x := assets[i].XMP
+		if x == nil {
+			continue
+		}
+		for _, j := range resolve(x.MM.DerivedFrom) {
+			link(j, i, RelationDerived)
+		}
+		if id := x.MM.RawOriginalDocumentID; id != "" && id != x.MM.RawDocumentID {
+			for _, j := range docs[id] {
+				link(j, i, RelationOriginal)
+			}
+		}
+		for _, ref := range x.MM.Ingredients {
+			for _, j := range resolve(ref) {
+				link(j, i, RelationIngredient)
+			}
+		}
+	}
+
+	// Assets of the same document or image, in the order of the Assets
+	group := func(l []int, r Relation) {
+		if len(l) < 2 {
+			return
+		}
+		from := l[0]
+		for _, i := range l {
+			if !hasSource[i] {
+				from = i
+				break
+			}
+		}
+		for _, i := range l {
+			link(from, i, r)
+		}
+	}
+	for i := range assets {
+		if x := assets[i].XMP; x != nil {
+			if l := docs[x.MM.RawDocumentID]; len(l) > 0 && l[0] == i {
+				group(l, RelationDocument)
+			}
+		}
+		if l := images[assets[i].imageUniqueID()]; len(l) > 0 && l[0] == i {
+			group(l, RelationImageUniqueID)
+		}
+	}
+	return g
+}
+
+// Sources returns the Links to the Asset i.
+func (g *Graph) Sources(i int) (links []Link) {
+	for _, l := range g.Links {
+		if l.To == i {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// Derivatives returns the Links from the Asset i.
+func (g *Graph) Derivatives(i int) (links []Link) {
+	for _, l := range g.Links {
+		if l.From == i {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// Originals returns the Assets that Asset i is derived from that have no
+// sources, in the order of the Assets. Returns i when it has no sources.
+func (g *Graph) Originals(i int) []int {
+	visited := map[int]bool{i: true}
+	var originals []int
+	stack := []int{i}
+	for len(stack) > 0 {
+		j := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		sources := g.Sources(j)
+		if len(sources) == 0 {
+			originals = append(originals, j)
+		}
+		for _, l := range sources {
+			if !visited[l.From] {
+				visited[l.From] = true
+				stack = append(stack, l.From)
+			}
+		}
+	}
+	sort.Ints(originals)
+	return originals
+}
+
+// Stacks returns the Assets of the Graph grouped by the Links between them.
+// Every Asset is in one stack. The Assets of a stack without sources, the
+// originals, come first, the stacks are in the order of their first Asset.
+func (g *Graph) Stacks() [][]int {
+	parent := make([]int, len(g.Assets))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	hasSource := make([]bool, len(g.Assets))
+	for _, l := range g.Links {
+		hasSource[l.To] = true
+		a, b := find(l.From), find(l.To)
+		if a > b {
+			a, b = b, a
+		}
+		parent[b] = a
+	}
+
+	index := make(map[int]int)
+	var stacks [][]int
+	for i := range g.Assets {
+		root := find(i)
+		j, ok := index[root]
+		if !ok {
+			j = len(stacks)
+			index[root] = j
+			stacks = append(stacks, nil)
+		}
+		stacks[j] = append(stacks[j], i)
+	}
+	for _, s := range stacks {
+		sort.SliceStable(s, func(a, b int) bool {
+			return !hasSource[s[a]] && hasSource[s[b]]
+		})
+	}
+	return stacks
+}
@@ -0,0 +1,229 @@
+package xmp
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPantryXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
+    xmlns:stRef="http://ns.adobe.com/xap/1.0/sType/ResourceRef#"
+    xmlns:stEvt="http://ns.adobe.com/xap/1.0/sType/ResourceEvent#"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmpMM:DocumentID="adobe:docid:photoshop:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c01"
+    xmpMM:InstanceID="xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c02"
+    xmpMM:OriginalDocumentID="xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03">
+   <xmpMM:History>
+    <rdf:Seq>
+     <rdf:li stEvt:action="created" stEvt:instanceID="xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03"
+       stEvt:when="2021-05-01T10:00:00+02:00" stEvt:softwareAgent="Adobe Photoshop 22.3 (Windows)"/>
+     <rdf:li rdf:parseType="Resource">
+      <stEvt:action>converted</stEvt:action>
+      <stEvt:parameters>from image/tiff to application/vnd.adobe.photoshop</stEvt:parameters>
+     </rdf:li>
+    </rdf:Seq>
+   </xmpMM:History>
+   <xmpMM:DerivedFrom rdf:parseType="Resource">
+    <stRef:instanceID>xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c04</stRef:instanceID>
+    <stRef:documentID>xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03</stRef:documentID>
+    <stRef:originalDocumentID>xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03</stRef:originalDocumentID>
+   </xmpMM:DerivedFrom>
+   <xmpMM:Ingredients>
+    <rdf:Bag>
+     <rdf:li stRef:filePath="layer.jpg" stRef:documentID="xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05" stRef:toPart="/layer/1"/>
+    </rdf:Bag>
+   </xmpMM:Ingredients>
+   <xmpMM:Pantry>
+    <rdf:Bag>
+     <rdf:li>
+      <rdf:Description
+        xmpMM:InstanceID="xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c06"
+        xmpMM:DocumentID="xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05">
+       <dc:format>image/jpeg</dc:format>
+       <xmpMM:DerivedFrom stRef:documentID="xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c07"/>
+      </rdf:Description>
+     </rdf:li>
+    </rdf:Bag>
+   </xmpMM:Pantry>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func testUUID(str string) meta.UUID {
+	var u meta.UUID
+	_ = u.UnmarshalText([]byte(str))
+	return u
+}
+
+func TestXMPMM(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testPantryXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mm := x.MM
+	assert.Equal(t, testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c01"), mm.DocumentID)
+	assert.Equal(t, testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c02"), mm.InstanceID)
+	assert.Equal(t, testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03"), mm.OriginalDocumentID)
+	assert.Equal(t, []History{
+		{Action: "created", InstanceID: testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03"), Date: time.Date(2021, 5, 1, 8, 0, 0, 0, time.UTC), Software: "Adobe Photoshop 22.3 (Windows)"},
+		{Action: "converted", Parameters: "from image/tiff to application/vnd.adobe.photoshop"},
+	}, normalizeHistory(mm.History))
+	assert.Equal(t, "adobe:docid:photoshop:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c01", mm.RawDocumentID)
+	assert.Equal(t, "xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c02", mm.RawInstanceID)
+	assert.Equal(t, "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03", mm.RawOriginalDocumentID)
+	assert.Equal(t, ResourceRef{
+		InstanceID:            testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c04"),
+		DocumentID:            testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03"),
+		OriginalDocumentID:    testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03"),
+		RawInstanceID:         "xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c04",
+		RawDocumentID:         "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03",
+		RawOriginalDocumentID: "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c03",
+	}, mm.DerivedFrom)
+	assert.Equal(t, []ResourceRef{{
+		DocumentID:    testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05"),
+		RawDocumentID: "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05",
+		FilePath:      "layer.jpg",
+		ToPart:        "/layer/1",
+	}}, mm.Ingredients)
+	assert.Equal(t, []XMPMM{{
+		InstanceID:    testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c06"),
+		DocumentID:    testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05"),
+		RawInstanceID: "xmp.iid:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c06",
+		RawDocumentID: "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c05",
+		DerivedFrom: ResourceRef{
+			DocumentID:    testUUID("3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c07"),
+			RawDocumentID: "xmp.did:3a7e3a1c-1b7e-4a3e-9d55-7f0b8a5d1c07",
+		},
+	}}, mm.Pantry)
+	assert.True(t, ResourceRef{}.IsZero())
+	assert.False(t, mm.DerivedFrom.IsZero())
+
+	// Lightroom sidecar
+	f, err := os.Open("test/1.xmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if x, err = ParseXmp(f); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testUUID("EB7AD12036E4778E0DF6FCC99853F4B1"), x.MM.DocumentID)
+	assert.Equal(t, testUUID("679ec154-b2b4-465d-8787-c4be99869ee6"), x.MM.InstanceID)
+	assert.Equal(t, "_MG_1563.CR2", x.MM.PreservedFileName)
+	assert.Equal(t, []History{{
+		Action:     "saved",
+		InstanceID: testUUID("679ec154-b2b4-465d-8787-c4be99869ee6"),
+		Date:       time.Date(2021, 2, 3, 9, 34, 4, 0, time.UTC),
+		Software:   "Adobe Photoshop Lightroom Classic 8.4.1 (Macintosh)",
+		Changed:    "/metadata",
+	}}, normalizeHistory(x.MM.History))
+	assert.True(t, x.MM.DerivedFrom.IsZero())
+}
+
+// normalizeHistory returns the History with the dates in UTC
+func normalizeHistory(h []History) []History {
+	for i := range h {
+		if !h[i].Date.IsZero() {
+			h[i].Date = h[i].Date.UTC()
+		}
+	}
+	return h
+}
+
+// testAssetXMP returns an XMP packet with xmpMM and exif properties
+func testAssetXMP(t *testing.T, attrs string, body string) *XMP {
+	x, err := ParseXmp(strings.NewReader(`<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
+    xmlns:stRef="http://ns.adobe.com/xap/1.0/sType/ResourceRef#"
+    xmlns:exif="http://ns.adobe.com/exif/1.0/"
+    ` + attrs + `>
+   ` + body + `
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &x
+}
+
+func TestGraph(t *testing.T) {
+	assets := []Asset{
+		// 0: Lightroom export of the RAW
+		{Name: "IMG_0001.jpg", XMP: testAssetXMP(t,
+			`xmpMM:DocumentID="xmp.did:00000000-0000-0000-0000-0000000000b1"
+    xmpMM:InstanceID="xmp.iid:00000000-0000-0000-0000-0000000000b2"
+    xmpMM:OriginalDocumentID="00000000000000000000000000000a01"`,
+			`<xmpMM:DerivedFrom stRef:documentID="00000000000000000000000000000a01" stRef:originalDocumentID="00000000000000000000000000000a01"/>`)},
+		// 1: RAW sidecar
+		{Name: "IMG_0001.CR2", XMP: testAssetXMP(t,
+			`xmpMM:DocumentID="00000000000000000000000000000a01"
+    xmpMM:OriginalDocumentID="00000000000000000000000000000a01"
+    exif:ImageUniqueID="4f1e8a2b9c3d4e5f6a7b8c9d0e1f2a3b"`, ``)},
+		// 2: unrelated
+		{Name: "IMG_0002.CR2", XMP: testAssetXMP(t,
+			`xmpMM:DocumentID="00000000000000000000000000000c01"`, ``)},
+		// 3: Photoshop composite with the export as a layer
+		{Name: "composite.psd", XMP: testAssetXMP(t,
+			`xmpMM:DocumentID="adobe:docid:photoshop:00000000-0000-0000-0000-0000000000d1"`,
+			`<xmpMM:Ingredients>
+    <rdf:Bag>
+     <rdf:li stRef:instanceID="xmp.iid:00000000-0000-0000-0000-0000000000b2" stRef:documentID="xmp.did:00000000-0000-0000-0000-0000000000b1"/>
+     <rdf:li stRef:documentID="xmp.did:00000000-0000-0000-0000-0000000000e1"/>
+    </rdf:Bag>
+   </xmpMM:Ingredients>`)},
+		// 4: in camera JPEG of the RAW without XMP
+		{Name: "IMG_0001.JPG", ImageUniqueID: "4f1e8a2b9c3d4e5f6a7b8c9d0e1f2a3b"},
+		// 5: copy of the unrelated RAW sidecar
+		{Name: "IMG_0002 copy.CR2", XMP: testAssetXMP(t,
+			`xmpMM:DocumentID="00000000000000000000000000000c01"`, ``)},
+		// 6: without metadata
+		{Name: "notes.txt"},
+	}
+	g := NewGraph(assets)
+	assert.Equal(t, []Link{
+		{From: 1, To: 0, Relation: RelationDerived},
+		{From: 0, To: 3, Relation: RelationIngredient},
+		{From: 1, To: 4, Relation: RelationImageUniqueID},
+		{From: 2, To: 5, Relation: RelationDocument},
+	}, g.Links)
+
+	assert.Equal(t, []Link{{From: 1, To: 0, Relation: RelationDerived}}, g.Sources(0))
+	assert.Equal(t, []Link{{From: 1, To: 0, Relation: RelationDerived}, {From: 1, To: 4, Relation: RelationImageUniqueID}}, g.Derivatives(1))
+	assert.Equal(t, []int{1}, g.Originals(3))
+	assert.Equal(t, []int{1}, g.Originals(1))
+	assert.Equal(t, []int{6}, g.Originals(6))
+	assert.Equal(t, [][]int{{1, 0, 3, 4}, {2, 5}, {6}}, g.Stacks())
+	assert.Equal(t, "DerivedFrom", RelationDerived.String())
+	assert.Equal(t, "ImageUniqueID", RelationImageUniqueID.String())
+
+	// the OriginalDocumentID links without DerivedFrom
+	assets[0].XMP.MM.DerivedFrom = ResourceRef{}
+	g = NewGraph(assets[:2])
+	assert.Equal(t, []Link{{From: 1, To: 0, Relation: RelationOriginal}}, g.Links)
+
+	// identifiers that are not UUIDs
+	assets = []Asset{
+		{Name: "a.tif", XMP: testAssetXMP(t, `xmpMM:DocumentID="xmp.did:1234"`, ``)},
+		{Name: "b.tif", XMP: testAssetXMP(t, `xmpMM:DocumentID="xmp.did:5678"`, ``)},
+		{Name: "a.jpg", XMP: testAssetXMP(t, `xmpMM:DocumentID="xmp.did:9999"`,
+			`<xmpMM:DerivedFrom stRef:documentID="xmp.did:5678"/>`)},
+	}
+	assert.Equal(t, meta.NilUUID, assets[0].XMP.MM.DocumentID)
+	g = NewGraph(assets)
+	assert.Equal(t, []Link{{From: 1, To: 2, Relation: RelationDerived}}, g.Links)
+
+	// cycles
+	g = &Graph{Assets: make([]Asset, 2), Links: []Link{{From: 0, To: 1}, {From: 1, To: 0}}}
+	assert.Nil(t, g.Originals(0))
+	assert.Equal(t, [][]int{{0, 1}}, g.Stacks())
+}
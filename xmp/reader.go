@@ -3,9 +3,11 @@ package xmp
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/pkg/errors"
+	"golang.org/x/text/transform"
 
 	"github.com/evanoberholster/imagemeta/xmp/xmpns"
 )
@@ -14,7 +16,6 @@ const (
 	xmpBufferLength = 1538 // (1.5kb)
 
 	// Reader blocksizes
-	maxTagValueSize  = 512
 	maxTagHeaderSize = 128
 )
 
@@ -23,15 +24,168 @@ var (
 	ErrNoValue      = errors.New("error property has no value")
 	ErrNegativeRead = errors.New("error negative read")
 	ErrBufferFull   = bufio.ErrBufferFull
+	ErrSyntax       = errors.New("error xml syntax")
 
 	// xmpRootTag starts with "<x:xmpmeta" and ends with "</x:xmpmeta>"
 	xmpRootTag      = [...]byte{'<', 'x', ':', 'x', 'm', 'p', 'm', 'e', 't', 'a'}
 	xmpRootCloseTag = [...]byte{'<', '/', 'x', ':', 'x', 'm', 'p', 'm', 'e', 't', 'a', '>'}
+
+	commentStart = []byte("<!--")
+	cdataStart   = []byte("<![CDATA[")
+	cdataEnd     = []byte("]]>")
 )
 
+// ReadError is an error reading an XMP packet with the byte offset and the
+// element path where it occurred. Offsets of UTF-16 and UTF-32 packets are
+// offsets of the packet decoded as UTF-8.
+type ReadError struct {
+	Err    error
+	Path   string // ex: "x:xmpmeta/rdf:RDF/rdf:Description"
+	Offset int64
+}
+
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("xmp: %s at offset %d in %s", e.Err, e.Offset, e.Path)
+}
+
+// Cause returns the underlying error
+func (e *ReadError) Cause() error { return e.Err }
+
+// Unwrap returns the underlying error
+func (e *ReadError) Unwrap() error { return e.Err }
+
 type xmpReader struct {
-	r *bufio.Reader
-	a bool
+	r   *bufio.Reader
+	a   bool
+	off int64 // offset of the next byte of r
+
+	text    []byte // value of the last Attribute or Tag
+	path    []byte // element path
+	pathLen []int  // lengths of path of the parent elements
+
+	// xmlns namespace bindings in scope, see bindNamespace
+	ns        []nsBinding
+	depth     int
+	tagPrefix []byte // prefix of the last start tag
+	attrName  []byte
+
+	doc docBuilder
+}
+
+// nsBinding is an xmlns declaration of a prefix that does not resolve to
+// the namespace of the standard prefix.
+type nsBinding struct {
+	prefix string
+	uri    string // only for namespaces not known by xmpns
+	ns     xmpns.Namespace
+	depth  int
+}
+
+// namespace returns the Namespace of the prefix. Prefixes bound by xmlns
+// declarations in scope are resolved by their namespace URI, the standard
+// prefixes are resolved directly. The empty prefix is the default namespace.
+func (br *xmpReader) namespace(prefix []byte) xmpns.Namespace {
+	for i := len(br.ns) - 1; i >= 0; i-- {
+		if br.ns[i].prefix == string(prefix) {
+			return br.ns[i].ns
+		}
+	}
+	return xmpns.IdentifyNamespace(prefix)
+}
+
+// bindNamespace adds the xmlns declaration of prefix to uri to the scope of
+// the current element. Declarations of a standard prefix to its own
+// namespace URI are resolved by prefix and are not added.
+func (br *xmpReader) bindNamespace(prefix []byte, uri []byte) {
+	if ns := xmpns.IdentifyNamespace(prefix); ns != xmpns.UnknownNS && ns.URI() == string(uri) {
+		shadowed := false
+		for i := range br.ns {
+			shadowed = shadowed || br.ns[i].prefix == string(prefix)
+		}
+		if !shadowed {
+			return
+		}
+	}
+	b := nsBinding{prefix: string(prefix), ns: xmpns.IdentifyNamespaceURI(uri), depth: br.depth}
+	if b.ns == xmpns.UnknownNS {
+		b.uri = string(uri)
+		if br.doc.d != nil && len(prefix) > 0 {
+			br.doc.d.declare(b.prefix, b.uri)
+		}
+	}
+	br.ns = append(br.ns, b)
+}
+
+// node returns a Node named by the prefix and name of the element or
+// attribute p. Known namespaces and names do not allocate.
+func (br *xmpReader) node(p xmpns.Property, prefix []byte, name []byte) (n Node) {
+	if br.doc.d == nil {
+		return
+	}
+	if ns := p.Namespace(); ns != xmpns.UnknownNS {
+		n.Namespace, n.Prefix = ns.URI(), ns.String()
+	} else {
+		for i := len(br.ns) - 1; i >= 0; i-- {
+			if br.ns[i].prefix == string(prefix) {
+				n.Namespace = br.ns[i].uri
+				break
+			}
+		}
+		n.Prefix = string(prefix)
+	}
+	if s := p.Name().String(); s == string(name) {
+		n.Name = s
+	} else {
+		n.Name = string(name)
+	}
+	return
+}
+
+// readError returns err as a ReadError at the current offset and element
+// path. The end of the packet within an element is io.ErrUnexpectedEOF.
+func (br *xmpReader) readError(err error) error {
+	if errors.Cause(err) == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return &ReadError{Err: err, Path: string(br.path), Offset: br.off}
+}
+
+// pushPath adds the element name to the element path.
+func (br *xmpReader) pushPath(name []byte) {
+	br.pathLen = append(br.pathLen, len(br.path))
+	if len(br.path) > 0 {
+		br.path = append(br.path, '/')
+	}
+	br.path = append(br.path, name...)
+}
+
+// isPath returns true when prefix and name are the name of the current
+// element, or there is no current element.
+func (br *xmpReader) isPath(prefix []byte, name []byte) bool {
+	if len(br.pathLen) == 0 {
+		return true
+	}
+	cur := br.path[br.pathLen[len(br.pathLen)-1]:]
+	if len(cur) > 0 && cur[0] == '/' {
+		cur = cur[1:]
+	}
+	if len(prefix) == 0 {
+		return bytes.Equal(cur, name)
+	}
+	return len(cur) == len(prefix)+1+len(name) && bytes.HasPrefix(cur, prefix) && cur[len(prefix)] == ':' && bytes.HasSuffix(cur, name)
+}
+
+// endElement removes the xmlns declarations of the current element.
+func (br *xmpReader) endElement() {
+	if n := len(br.pathLen); n > 0 {
+		br.path = br.path[:br.pathLen[n-1]]
+		br.pathLen = br.pathLen[:n-1]
+	}
+	for len(br.ns) > 0 && br.ns[len(br.ns)-1].depth >= br.depth {
+		br.ns = br.ns[:len(br.ns)-1]
+	}
+	br.depth--
+	br.doc.end()
 }
 
 func newXMPReader(r io.Reader) xmpReader {
@@ -39,7 +193,16 @@ func newXMPReader(r io.Reader) xmpReader {
 	if !ok || br.Size() < xmpBufferLength {
 		br = bufio.NewReaderSize(r, xmpBufferLength)
 	}
-	return xmpReader{r: br}
+	if enc := packetEncoding(br); enc != nil {
+		br = bufio.NewReaderSize(transform.NewReader(br, enc.NewDecoder()), xmpBufferLength)
+	}
+	return xmpReader{
+		r:       br,
+		text:    make([]byte, 0, maxTagHeaderSize*2),
+		path:    make([]byte, 0, maxTagHeaderSize),
+		pathLen: make([]int, 0, 8),
+		doc:     docBuilder{frames: make([]docFrame, 0, 8)},
+	}
 }
 
 // readRootTag reads and returns the xmpRootTag from the bufReader.
@@ -47,31 +210,46 @@ func newXMPReader(r io.Reader) xmpReader {
 func (br *xmpReader) readRootTag() (tag Tag, err error) {
 	var buf []byte
 	for {
-		if _, err = br.r.ReadSlice(xmpRootTag[0]); err != nil {
+		if _, err = br.readSlice(xmpRootTag[0]); err != nil {
 			if err == io.EOF {
-				err = ErrNoXMP
-				return
+				return tag, ErrNoXMP
 			}
 			if err == bufio.ErrBufferFull {
 				continue
 			}
-		}
-		if buf, err = br.r.Peek(10); err != nil {
 			return
 		}
-
-		if bytes.Equal(xmpRootTag[1:], buf[0:9]) {
-			_, err = br.r.ReadSlice('>') // Read until end of the StartTag (RootTag)
-			tag.t = startTag
-			tag.self = xmpns.XMPRootProperty
-			//fmt.Println("XMP Discarded:", discarded)
-			return tag, err
+		if buf, err = br.Peek(len(xmpRootTag)); err != nil || len(buf) < len(xmpRootTag) {
+			return tag, ErrNoXMP
+		}
+		if bytes.Equal(xmpRootTag[1:], buf[:9]) && (isSpace(buf[9]) || buf[9] == '>' || buf[9] == '/') {
+			break
+		}
+	}
+	// Read the attributes of the StartTag (RootTag)
+	tag.t = startTag
+	tag.self = xmpns.XMPRootProperty
+	br.pushPath(buf[:9])
+	br.tagPrefix = append(br.tagPrefix[:0], 'x')
+	if _, err = br.Discard(9); err != nil {
+		return
+	}
+	if err = br.readTagEnd(&tag); err != nil {
+		return
+	}
+	for br.hasAttribute() {
+		if _, err = br.readAttribute(&tag); err != nil {
+			return
 		}
 	}
+	return tag, nil
 }
 
+// Discard discards the next n bytes of the bufReader.
 func (br *xmpReader) Discard(n int) (discarded int, err error) {
-	return br.r.Discard(n)
+	discarded, err = br.r.Discard(n)
+	br.off += int64(discarded)
+	return
 }
 
 // hasAttribute returns true when the bufReader's next read is
@@ -80,13 +258,95 @@ func (br *xmpReader) hasAttribute() bool {
 	return br.a
 }
 
+// Peek returns the next n bytes of the bufReader or fewer at the end of the
+// packet.
 func (br *xmpReader) Peek(n int) (buf []byte, err error) {
-	if buf, err = br.r.Peek(n); err == io.EOF {
-		if len(buf) > 4 {
-			return buf, nil
+	if buf, err = br.r.Peek(n); err == io.EOF && len(buf) > 0 {
+		return buf, nil
+	}
+	return
+}
+
+func (br *xmpReader) readSlice(delim byte) (buf []byte, err error) {
+	buf, err = br.r.ReadSlice(delim)
+	br.off += int64(len(buf))
+	return
+}
+
+func (br *xmpReader) readByte() (c byte, err error) {
+	if c, err = br.r.ReadByte(); err == nil {
+		br.off++
+	}
+	return
+}
+
+func (br *xmpReader) unreadByte() {
+	if br.r.UnreadByte() == nil {
+		br.off--
+	}
+}
+
+// skipSpace discards white space.
+func (br *xmpReader) skipSpace() error {
+	for {
+		n := br.r.Buffered()
+		if n == 0 {
+			n = 1
+		}
+		buf, err := br.r.Peek(n)
+		if len(buf) == 0 {
+			return err
+		}
+		i := 0
+		for i < len(buf) && isSpace(buf[i]) {
+			i++
+		}
+		if _, err = br.Discard(i); err != nil || i < len(buf) {
+			return err
 		}
-		return buf, err
 	}
+}
+
+// skipPast discards up to and including end. Used for comments, processing
+// instructions and declarations. end is at most 3 bytes.
+func (br *xmpReader) skipPast(end string) error {
+	var w [3]byte // the last bytes read
+	for {
+		buf, err := br.readSlice(end[len(end)-1])
+		if len(buf) > len(w) {
+			buf = buf[len(buf)-len(w):]
+		}
+		for _, c := range buf {
+			w[0], w[1], w[2] = w[1], w[2], c
+		}
+		if err == nil && string(w[len(w)-len(end):]) == end {
+			return nil
+		}
+		if err != nil && err != bufio.ErrBufferFull {
+			return err
+		}
+	}
+}
+
+// readTagEnd reads the white space after the name or an attribute of a
+// start tag, and the end of the start tag ">" or "/>" of a solo tag.
+func (br *xmpReader) readTagEnd(tag *Tag) (err error) {
+	if err = br.skipSpace(); err != nil {
+		return
+	}
+	buf, err := br.Peek(2)
+	if len(buf) > 0 && buf[0] == '>' {
+		br.a = false // No Attributes
+		_, err = br.Discard(1)
+		return
+	}
+	if len(buf) > 1 && buf[0] == '/' && buf[1] == '>' {
+		br.a = false // SoloTag
+		tag.t = soloTag
+		_, err = br.Discard(2)
+		return
+	}
+	br.a = err == nil // Attributes
 	return
 }
 
@@ -102,152 +362,286 @@ func (br *xmpReader) readAttribute(tag *Tag) (attr Attribute, err error) {
 		return
 	}
 
-	var d int
-	if attr.self, d, err = parseAttrName(buf); err != nil {
-		err = errors.Wrap(ErrNegativeRead, "Attr (name)")
+	prefix, name, d, err := parseAttrName(buf)
+	if err != nil {
+		err = errors.Wrap(err, "Attr (name)")
 		return
 	}
+	// unprefixed attributes are not in the default namespace
+	ns := xmpns.UnknownNS
+	if len(prefix) > 0 {
+		ns = br.namespace(prefix)
+	}
+	attr.self = xmpns.NewProperty(ns, xmpns.IdentifyName(name))
+	var n Node
+	isXMLns := string(prefix) == "xmlns"
+	if isXMLns {
+		br.attrName = append(br.attrName[:0], name...)
+	} else if len(prefix) == 0 && string(name) == "xmlns" {
+		// the default namespace of unprefixed elements
+		isXMLns = true
+		br.attrName = br.attrName[:0]
+		attr.self = xmpns.NewProperty(xmpns.XMLnsNS, xmpns.UnknownPropertyName)
+	} else if n = br.node(attr.self, prefix, name); len(prefix) == 0 {
+		n.Namespace = ""
+	}
 	if _, err = br.Discard(d); err != nil {
 		err = errors.Wrap(err, "Attr (discard)")
 		return
 	}
 
 	// Attribute Value
-	attr.val, err = br.readAttrValue(tag)
-
-	return attr, err
+	if attr.val, err = br.readAttrValue(tag); err != nil {
+		return attr, err
+	}
+	if !isXMLns {
+		if br.doc.d != nil {
+			n.Value = string(attr.val)
+			br.doc.attr(attr.self, n)
+		}
+		return attr, nil
+	}
+	br.bindNamespace(br.attrName, attr.val)
+	if bytes.Equal(br.attrName, br.tagPrefix) {
+		// the namespace of the tag is declared by its own attributes
+		tag.self = xmpns.NewProperty(br.namespace(br.tagPrefix), tag.Name())
+		br.doc.rename(br.node(tag.self, br.tagPrefix, nil))
+	}
+	return attr, nil
 }
 
-// readAttrValue reada an Attributes value from the Tag.
-// Needs improvement for performance
+// readAttrValue reads an Attribute's quoted value and the end of the Tag
+// when it is the last Attribute. Returns a temporary []byte with the
+// entities decoded.
 func (br *xmpReader) readAttrValue(tag *Tag) (buf []byte, err error) {
-	d, i := 0, 2
-	s := maxTagValueSize / 2
-	for {
-		if buf, err = br.Peek(s); err != nil {
-			err = errors.Wrap(err, "Attr Value")
-			return
+	// Fast path for buffered values without white space around '='
+	if buf, _ = br.r.Peek(br.r.Buffered()); len(buf) > 2 && buf[0] == '=' && (buf[1] == '"' || buf[1] == '\'') {
+		if i := bytes.IndexByte(buf[2:], buf[1]); i >= 0 {
+			br.text = append(br.text[:0], buf[2:2+i]...)
+			if _, err = br.Discard(i + 3); err != nil {
+				return nil, errors.Wrap(err, "Attr Value")
+			}
+			return br.attrValueEnd(tag)
 		}
+	}
 
-		if buf[0] == '=' && (buf[1] == '"' || buf[1] == '\'') {
-			delim := buf[1]
-			if b := bytes.IndexByte(buf[i:], delim); b >= 0 {
-				i += b
-				d = i + 1
-				if buf[i+1] == '>' {
-					d++
-					br.a = false
-				} else if buf[i+1] == '/' && buf[i+2] == '>' {
-					d += 2
-					tag.t = soloTag
-					br.a = false
-				}
-				if _, err = br.Discard(d); err != nil {
-					err = errors.Wrap(err, "Attr Value (discard)")
-				}
-				return buf[2:i], err
+	var c byte
+	if err = br.skipSpace(); err == nil {
+		if c, err = br.readByte(); err == nil && c != '=' {
+			err = ErrSyntax
+		}
+	}
+	if err == nil {
+		if err = br.skipSpace(); err == nil {
+			if c, err = br.readByte(); err == nil && c != '"' && c != '\'' {
+				err = ErrSyntax
 			}
 		}
-		s += maxTagValueSize
 	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Attr Value")
+	}
+
+	br.text = br.text[:0]
+	for {
+		if buf, err = br.readSlice(c); err == nil {
+			br.text = append(br.text, buf[:len(buf)-1]...)
+			break
+		}
+		if err != bufio.ErrBufferFull {
+			return nil, errors.Wrap(err, "Attr Value")
+		}
+		br.text = append(br.text, buf...)
+	}
+	return br.attrValueEnd(tag)
+}
+
+// attrValueEnd decodes the Attribute value and reads the end of the Tag when
+// it is the last Attribute.
+func (br *xmpReader) attrValueEnd(tag *Tag) (buf []byte, err error) {
+	br.text = decodeEntities(br.text)
+	if err = br.readTagEnd(tag); err != nil {
+		err = errors.Wrap(err, "Attr Value (tag end)")
+	}
+	return br.text, err
 }
 
-// readTagHeader reads an xmp tag's header and returns the tag.
+// readTagHeader reads an xmp tag's header and returns the tag. Character
+// data, comments, processing instructions and declarations before the tag
+// are discarded.
 func (br *xmpReader) readTagHeader(parent Tag) (tag Tag, err error) {
 	tag.pt = tagPType
 	tag.parent = parent.self
 
-	s := maxTagHeaderSize
-	// Read Tag Header
 	var buf []byte
 	var i int
 	for {
-		if buf, err = br.Peek(s); err != nil {
+		if err = br.skipText(); err != nil {
 			err = errors.Wrap(err, "Tag Header")
 			return
 		}
-
-		// Find Start of Tag
-		for ; i < len(buf); i++ {
-			if buf[i] == '<' {
-				if buf[i+1] == '/' {
-					tag.t = stopTag
-					i += 2
-				} else if buf[i+1] == '?' {
-					err = io.EOF
-					return
-				} else {
-					tag.t = startTag
-					i++
-				}
-				buf = buf[i:]
-				goto end
-			}
+		if buf, err = br.Peek(maxTagHeaderSize); err != nil {
+			err = errors.Wrap(err, "Tag Header")
+			return
+		}
+		if len(buf) < 2 {
+			err = errors.Wrap(io.EOF, "Tag Header")
+			return
+		}
+		switch {
+		case buf[1] == '?':
+			err = br.skipPast("?>")
+		case bytes.HasPrefix(buf, commentStart):
+			err = br.skipPast("-->")
+		case bytes.HasPrefix(buf, cdataStart):
+			err = br.skipPast("]]>")
+		case buf[1] == '!':
+			err = br.skipPast(">")
+		case buf[1] == '/':
+			tag.t = stopTag
+			i = 2
+		default:
+			tag.t = startTag
+			i = 1
+		}
+		if err != nil {
+			err = errors.Wrap(err, "Tag Header")
+			return
+		}
+		if i > 0 {
+			break
 		}
-		// large white spaces in xmp files
-
-		s += maxTagHeaderSize
 	}
-end:
-	var d int
-	tag.self, d, err = parseTagName(buf)
+
+	prefix, name, d, err := parseTagName(buf[i:])
 	if err != nil {
 		err = errors.Wrap(err, "Tag Header (tag name)") // Err finding tag name
 		return
 	}
-	if buf[d] == '>' {
-		br.a = false // No Attributes
-		d++
-	} else if buf[d] == ' ' || buf[d] == '\n' { // Attributes
-		br.a = true
-	} else if buf[d] == '/' && buf[d+1] == '>' { // SoloTag
-		br.a = false // No Attributes
-		tag.t = soloTag
-		d += 2
+	tag.self = xmpns.NewProperty(br.namespace(prefix), xmpns.IdentifyName(name))
+	if tag.t == stopTag {
+		if !br.isPath(prefix, name) {
+			err = errors.Wrap(ErrSyntax, "Tag Header (mismatched stop tag)")
+			return
+		}
+	} else {
+		br.depth++
+		br.tagPrefix = append(br.tagPrefix[:0], prefix...)
+		br.doc.start(tag.self, br.node(tag.self, prefix, name))
+		br.pushPath(buf[i : i+d])
 	}
-	if _, err = br.Discard(d + i); err != nil {
+	if _, err = br.Discard(i + d); err != nil {
 		err = errors.Wrap(err, "Tag Header (discard)")
+		return
+	}
+	if tag.t == stopTag {
+		var c byte
+		if err = br.skipSpace(); err == nil {
+			if c, err = br.readByte(); err == nil && c != '>' {
+				err = ErrSyntax
+			}
+		}
+		if err != nil {
+			err = errors.Wrap(err, "Tag Header (stop tag)")
+			return
+		}
+		br.endElement()
+		return
+	}
+	if err = br.readTagEnd(&tag); err != nil {
+		err = errors.Wrap(err, "Tag Header")
 	}
 	return
 }
 
+// skipText discards character data until the next '<'.
+func (br *xmpReader) skipText() (err error) {
+	if buf, _ := br.r.Peek(br.r.Buffered()); len(buf) > 0 {
+		if i := bytes.IndexByte(buf, '<'); i >= 0 {
+			_, err = br.Discard(i)
+			return
+		}
+	}
+	for {
+		if _, err = br.readSlice('<'); err == nil {
+			br.unreadByte()
+			return nil
+		}
+		if err != bufio.ErrBufferFull {
+			return
+		}
+	}
+}
+
 // readTagValue reads the Tag's Value from the bufReader. Returns
-// a temporary []byte.
+// a temporary []byte with entities decoded, CDATA sections included
+// and comments removed.
 func (br *xmpReader) readTagValue() (buf []byte, err error) {
-	var i, j int
-	s := maxTagValueSize
+	// Fast path for buffered values without entities, comments and CDATA,
+	// the value is not copied.
+	if buf, _ = br.r.Peek(br.r.Buffered()); len(buf) > 0 {
+		if i := bytes.IndexByte(buf, '<'); i >= 0 && i+1 < len(buf) && buf[i+1] != '!' && bytes.IndexByte(buf[:i], '&') < 0 {
+			if _, err = br.Discard(i); err != nil {
+				return nil, errors.Wrap(err, "Tag Value")
+			}
+			return br.tagValueEnd(buf[:i]), nil
+		}
+	}
+
+	br.text = br.text[:0]
+	var decoded int // br.text[:decoded] is decoded
 	for {
-		if buf, err = br.Peek(s); err != nil {
-			err = errors.Wrap(err, "Tag Value")
-			return
+		if buf, err = br.readSlice('<'); err != nil {
+			if err == bufio.ErrBufferFull {
+				br.text = append(br.text, buf...)
+				continue
+			}
+			return nil, errors.Wrap(err, "Tag Value")
 		}
-		if i == 0 {
-			if buf[i] == '>' {
-				i++
-			} else if buf[i] == '/' && buf[i+1] == '>' {
-				i += 2
+		br.text = append(br.text, buf[:len(buf)-1]...)
+		br.unreadByte()
+		if buf, err = br.Peek(len(cdataStart)); err != nil {
+			return nil, errors.Wrap(err, "Tag Value")
+		}
+		if bytes.HasPrefix(buf, commentStart) {
+			if err = br.skipPast("-->"); err != nil {
+				return nil, errors.Wrap(err, "Tag Value (comment)")
 			}
-			// removes white space and new lines prefixes
-			for ; i < len(buf); i++ {
-				if buf[i] == ' ' || buf[i] == '\n' {
-					continue
-				}
+			continue
+		}
+		if !bytes.HasPrefix(buf, cdataStart) {
+			break
+		}
+		// CDATA sections are not decoded
+		br.text = append(br.text[:decoded], decodeEntities(br.text[decoded:])...)
+		if _, err = br.Discard(len(cdataStart)); err != nil {
+			return nil, errors.Wrap(err, "Tag Value (CDATA)")
+		}
+		for start := len(br.text); ; {
+			buf, err = br.readSlice(cdataEnd[len(cdataEnd)-1])
+			br.text = append(br.text, buf...)
+			if err == nil && bytes.HasSuffix(br.text[start:], cdataEnd) {
 				break
 			}
-			j = i
-		}
-		// Search buffer.
-		for ; j < len(buf); j++ {
-			if buf[j] == '<' {
-				if _, err = br.Discard(j); err != nil {
-					err = errors.Wrap(err, "Tag Value (discard)")
-					return nil, err
-				}
-				return buf[i:j], nil
+			if err != nil && err != bufio.ErrBufferFull {
+				return nil, errors.Wrap(err, "Tag Value (CDATA)")
 			}
 		}
-		s += maxTagValueSize
+		br.text = br.text[:len(br.text)-len(cdataEnd)]
+		decoded = len(br.text)
 	}
+	br.text = append(br.text[:decoded], decodeEntities(br.text[decoded:])...)
+	return br.tagValueEnd(br.text), nil
+}
+
+// tagValueEnd removes the white space and new line prefixes of the Tag's
+// Value buf and adds it to the Document.
+func (br *xmpReader) tagValueEnd(buf []byte) []byte {
+	for len(buf) > 0 && isSpace(buf[0]) {
+		buf = buf[1:]
+	}
+	br.doc.text(buf)
+	return buf
 }
 
 func (br *xmpReader) readTag(xmp *XMP, parent Tag) (tag Tag, err error) {
@@ -268,6 +662,9 @@ func (br *xmpReader) readTag(xmp *XMP, parent Tag) (tag Tag, err error) {
 				return
 			}
 		}
+		if tag.t == soloTag {
+			br.endElement()
+		}
 		if tag.isStartTag() {
 			if tag.Is(xmpns.RDFSeq) || tag.Is(xmpns.RDFAlt) || tag.Is(xmpns.RDFBag) {
 				if err = br.readSeqTags(xmp, tag); err != nil {
@@ -325,6 +722,10 @@ func (br *xmpReader) readSeqTags(xmp *XMP, parent Tag) (err error) {
 					return
 				}
 			}
+			if tag.t == soloTag {
+				br.endElement()
+				continue
+			}
 
 			if tag.val, err = br.readTagValue(); err != nil {
 				return
@@ -336,42 +737,53 @@ func (br *xmpReader) readSeqTags(xmp *XMP, parent Tag) (err error) {
 				return
 			}
 		}
+		if tag.t == soloTag {
+			br.endElement()
+		}
 	}
 	return
 }
 
-func parseAttrName(buf []byte) (xmpns.Property, int, error) {
-	var a, b, c int
-	for ; a < len(buf); a++ {
-		if buf[a] == ' ' || buf[a] == '\n' {
-			continue
-		}
-		break
-	}
-	for b = a + 1; b < len(buf); b++ {
-		if buf[b] == ':' {
-			break
-		}
-	}
-	for c = b + 2; c < len(buf); c++ {
-		if buf[c] == '=' || buf[c] == ' ' {
-			return xmpns.IdentifyProperty(buf[a:b], buf[b+1:c]), c, nil
+// parseAttrName returns the prefix and name of the attribute at the start
+// of buf and the length read. Attributes without a prefix have a nil prefix.
+func parseAttrName(buf []byte) (prefix []byte, name []byte, d int, err error) {
+	a := -1
+	for ; d < len(buf); d++ {
+		switch c := buf[d]; {
+		case c == ':' && a < 0:
+			a = d
+		case c == '=' || isSpace(c):
+			if d == 0 || a == d-1 {
+				return nil, nil, -1, ErrNegativeRead
+			}
+			if a < 0 {
+				return nil, buf[:d], d, nil
+			}
+			return buf[:a], buf[a+1 : d], d, nil
+		case c == '>' || c == '/' || c == '<' || c == '"' || c == '\'':
+			return nil, nil, -1, ErrNegativeRead
 		}
 	}
-	return xmpns.Property{}, -1, ErrNegativeRead
+	return nil, nil, -1, ErrNegativeRead
 }
 
-func parseTagName(buf []byte) (xmpns.Property, int, error) {
-	var a, b int
-	for ; a < len(buf); a++ {
-		if buf[a] == ':' {
-			break
-		}
-	}
-	for b = a + 1; b < len(buf); b++ {
-		if buf[b] == '>' || buf[b] == ' ' || buf[b] == '\n' || buf[b] == '/' {
-			return xmpns.IdentifyProperty(buf[:a], buf[a+1:b]), b, nil
+// parseTagName returns the prefix and name of the tag at the start of buf
+// and the length read. Tags without a prefix have a nil prefix.
+func parseTagName(buf []byte) (prefix []byte, name []byte, d int, err error) {
+	a := -1
+	for ; d < len(buf); d++ {
+		switch c := buf[d]; {
+		case c == ':' && a < 0:
+			a = d
+		case c == '>' || c == '/' || isSpace(c):
+			if d == 0 || a == 0 || a == d-1 {
+				return nil, nil, -1, ErrNegativeRead
+			}
+			if a < 0 {
+				return nil, buf[:d], d, nil
+			}
+			return buf[:a], buf[a+1 : d], d, nil
 		}
 	}
-	return xmpns.Property{}, -1, ErrNegativeRead
+	return nil, nil, -1, ErrNegativeRead
 }
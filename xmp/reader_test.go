@@ -5,8 +5,10 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/evanoberholster/imagemeta/imagetype"
 	"github.com/evanoberholster/imagemeta/xmp/xmpns"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -20,11 +22,12 @@ func TestReadTagHeader(t *testing.T) {
 		assert bool
 	}{
 		{"", io.EOF, Tag{}, false},
-		{"         ", ErrBufferFull, Tag{}, false},
+		{"         ", io.EOF, Tag{}, false},
 		{"<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">", nil, Tag{property: property{parent: xmpns.XMPRootProperty, self: xmpns.NewProperty(xmpns.RdfNS, xmpns.RDF), pt: tagPType}, t: startTag}, true},
 		{"<rdf:description/>", nil, Tag{property: property{parent: xmpns.XMPRootProperty, self: xmpns.NewProperty(xmpns.RdfNS, xmpns.Description), pt: tagPType}, t: soloTag}, true},
 		{"\n" + "</rdf:Description>", nil, Tag{property: property{parent: xmpns.XMPRootProperty, self: xmpns.NewProperty(xmpns.RdfNS, xmpns.Description), pt: tagPType}, t: stopTag}, true},
-		{"<hello >               ", ErrNegativeRead, Tag{}, false},
+		{"<hello >               ", nil, Tag{property: property{parent: xmpns.XMPRootProperty, self: xmpns.NewProperty(xmpns.UnknownNS, xmpns.UnknownPropertyName), pt: tagPType}, t: startTag}, true},
+		{"<:hello >               ", ErrNegativeRead, Tag{}, false},
 		{"<? >               ", io.EOF, Tag{}, false},
 	}
 
@@ -93,3 +96,185 @@ func TestReadAttribute(t *testing.T) {
 	//	}
 	//}
 }
+
+func TestReadNamespaceScope(t *testing.T) {
+	data := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:ns1="http://purl.org/dc/elements/1.1/" xmlns:tiff="http://ns.adobe.com/tiff/1.0/" ns1:format="image/jpeg" tiff:Make="Canon">
+   <ns1:subject>
+    <rdf:Bag>
+     <rdf:li>one</rdf:li>
+     <rdf:li>two</rdf:li>
+    </rdf:Bag>
+   </ns1:subject>
+   <dc:creator xmlns:dc="http://example.com/other/">
+    <rdf:Seq>
+     <rdf:li>ignored</rdf:li>
+    </rdf:Seq>
+   </dc:creator>
+   <ns2:title xmlns:ns2="http://purl.org/dc/elements/1.1/">
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Title</rdf:li>
+    </rdf:Alt>
+   </ns2:title>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+	x, err := ParseXmp(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"one", "two"}, x.DC.Subject)
+	assert.Equal(t, imagetype.ImageJPEG, x.DC.Format)
+	assert.Equal(t, "Canon", x.Tiff.Make)
+	assert.Equal(t, []string(nil), x.DC.Creator)
+	assert.Equal(t, []string{"Title"}, x.DC.Title)
+}
+
+func TestReadDefaultNamespace(t *testing.T) {
+	data := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+   <foo xmlns="http://x/">v</foo>
+   <bar>w</bar>
+   <format xmlns="http://purl.org/dc/elements/1.1/" format="image/png">image/tiff</format>
+   <dc:format>image/jpeg</dc:format>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+	x, err := ParseXmp(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, imagetype.ImageJPEG, x.DC.Format)
+	if n := x.Document.Get("foo"); assert.NotNil(t, n) {
+		assert.Equal(t, "http://x/", n.Namespace)
+		assert.Equal(t, "v", n.Value)
+	}
+	if n := x.Document.Get("bar"); assert.NotNil(t, n) {
+		assert.Equal(t, "", n.Namespace)
+	}
+	// unprefixed attributes are not in the default namespace
+	if n := x.Document.Get("dc:format"); assert.NotNil(t, n) && assert.Len(t, n.Fields, 1) {
+		assert.Equal(t, Node{Name: "format", Value: "image/png"}, n.Fields[0])
+	}
+}
+
+func TestDecodeEntities(t *testing.T) {
+	tests := []struct {
+		data  string
+		value string
+	}{
+		{"Fish &amp; Chips", "Fish & Chips"},
+		{"&lt;b&gt; &quot;a&quot; &apos;b&apos;", "<b> \"a\" 'b'"},
+		{"&#65;&#x42;&#X43;&#x1F600;", "AB&#X43;\U0001F600"},
+		{"&unknown; &amp &#xZZ; &#;", "&unknown; &amp &#xZZ; &#;"},
+		{"no entities", "no entities"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.value, string(decodeEntities([]byte(tt.data))), tt.data)
+	}
+}
+
+var testTokenizerXMP = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 5.6.0">
+ <!-- comment <rdf:RDF> -->
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <?custom instruction?>
+  <rdf:Description rdf:about=""
+	xmlns:tiff="http://ns.adobe.com/tiff/1.0/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	tiff:Make = 'Fish &amp; Chips'
+	tiff:Model="&#x4E;ikon"
+	>
+   <dc:description>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">A <!-- hidden -->caption &amp; <![CDATA[<b>bold</b> &amp;]]> text</rdf:li>
+    </rdf:Alt>
+   </dc:description>
+   <dc:creator>
+    <rdf:Seq>
+     <rdf:li >` + strings.Repeat("a", 3000) + `</rdf:li>
+    </rdf:Seq>
+   </dc:creator>
+   <dc:rights rdf:resource="` + strings.Repeat("b", 2000) + `" />
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+func TestReadTokenizer(t *testing.T) {
+	utf16le := func(s string) []byte {
+		buf := []byte{0xff, 0xfe}
+		for _, r := range s {
+			buf = append(buf, byte(r), byte(r>>8))
+		}
+		return buf
+	}
+	utf16be := func(s string) []byte {
+		var buf []byte
+		for _, r := range s {
+			buf = append(buf, byte(r>>8), byte(r))
+		}
+		return buf
+	}
+	utf32le := func(s string) []byte {
+		buf := []byte{0xff, 0xfe, 0, 0}
+		for _, r := range s {
+			buf = append(buf, byte(r), byte(r>>8), 0, 0)
+		}
+		return buf
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"UTF-8", []byte(testTokenizerXMP)},
+		{"UTF-16LE", utf16le(testTokenizerXMP)},
+		{"UTF-16BE", utf16be(testTokenizerXMP)},
+		{"UTF-32LE", utf32le(testTokenizerXMP)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := ParseXmp(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "Fish & Chips", x.Tiff.Make)
+			assert.Equal(t, "Nikon", x.Tiff.Model)
+			assert.Equal(t, []string{"A caption & <b>bold</b> &amp; text"}, x.DC.Description)
+			assert.Equal(t, []string{strings.Repeat("a", 3000)}, x.DC.Creator)
+			assert.Equal(t, strings.Repeat("b", 2000), x.Document.Get("dc:rights").Value)
+		})
+	}
+}
+
+func TestReadError(t *testing.T) {
+	tests := []struct {
+		data   string
+		err    error
+		path   string
+		offset int64
+	}{
+		{`<x:xmpmeta><rdf:RDF><rdf:Description><tiff:Make>Canon`, io.ErrUnexpectedEOF, "x:xmpmeta/rdf:RDF/rdf:Description/tiff:Make", 53},
+		{`<x:xmpmeta><rdf:RDF><rdf:Description></rdf:RDF>`, ErrSyntax, "x:xmpmeta/rdf:RDF/rdf:Description", 37},
+		{`<x:xmpmeta><rdf:RDF><rdf:Description tiff:Make="Canon></rdf:RDF>`, io.ErrUnexpectedEOF, "x:xmpmeta/rdf:RDF/rdf:Description", 64},
+		{`<x:xmpmeta><rdf:RDF><rdf:Description tiff:Make>`, ErrNegativeRead, "x:xmpmeta/rdf:RDF/rdf:Description", 37},
+		{`<x:xmpmeta><rdf:RDF><:hello>`, ErrNegativeRead, "x:xmpmeta/rdf:RDF", 20},
+		{`<x:xmpmeta><rdf:RDF><hello>`, io.ErrUnexpectedEOF, "x:xmpmeta/rdf:RDF/hello", 27},
+	}
+	for _, tt := range tests {
+		_, err := ParseXmp(strings.NewReader(tt.data))
+		var re *ReadError
+		if !errors.As(err, &re) {
+			t.Errorf("Incorrect error for %q wanted ReadError got %v", tt.data, err)
+			continue
+		}
+		assert.Equal(t, tt.err, errors.Cause(err), tt.data)
+		assert.Equal(t, tt.path, re.Path, tt.data)
+		assert.Equal(t, tt.offset, re.Offset, tt.data)
+	}
+
+	_, err := ParseXmp(strings.NewReader("<x:xmp"))
+	assert.Equal(t, ErrNoXMP, err)
+}
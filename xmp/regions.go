@@ -0,0 +1,254 @@
+package xmp
+
+import (
+	"strings"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// RegionType is the type of an image Region
+type RegionType uint8
+
+// Region Types
+const (
+	RegionTypeUnknown RegionType = iota
+	RegionTypeFace
+	RegionTypePet
+	RegionTypeFocus
+	RegionTypeBarCode
+)
+
+// String returns the RegionType as a string
+func (rt RegionType) String() string {
+	switch rt {
+	case RegionTypeFace:
+		return "Face"
+	case RegionTypePet:
+		return "Pet"
+	case RegionTypeFocus:
+		return "Focus"
+	case RegionTypeBarCode:
+		return "BarCode"
+	}
+	return "Unknown"
+}
+
+func parseRegionType(str string) RegionType {
+	switch str {
+	case "Face":
+		return RegionTypeFace
+	case "Pet":
+		return RegionTypePet
+	case "Focus":
+		return RegionTypeFocus
+	case "BarCode":
+		return RegionTypeBarCode
+	}
+	return RegionTypeUnknown
+}
+
+// RegionSource is the schema an image Region was read from
+type RegionSource uint8
+
+// Region Sources
+const (
+	// RegionSourceMWG is the Metadata Working Group regions schema, written by
+	// Lightroom, Picasa and digiKam.
+	//	xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/"
+	RegionSourceMWG RegionSource = iota
+	// RegionSourceMP is the Microsoft Photo region schema, written by Windows
+	// Photo Gallery.
+	//	xmlns:MP="http://ns.microsoft.com/photo/1.2/"
+	RegionSourceMP
+)
+
+// Area is a rectangle normalized to the width and height of an image. X and
+// Y are the top left corner, from 0.0 to 1.0. A point has a W and H of 0.
+type Area struct {
+	X, Y, W, H float64
+}
+
+// orient returns the Area of the displayed image of an image stored with the
+// Orientation o.
+func (a Area) orient(o meta.Orientation) Area {
+	x0, y0 := orientPoint(o, a.X, a.Y)
+	x1, y1 := orientPoint(o, a.X+a.W, a.Y+a.H)
+	if x1 < x0 {
+		x0, x1 = x1, x0
+	}
+	if y1 < y0 {
+		y0, y1 = y1, y0
+	}
+	return Area{X: x0, Y: y0, W: x1 - x0, H: y1 - y0}
+}
+
+// orientPoint returns the normalized point x, y of the stored image on the
+// displayed image.
+func orientPoint(o meta.Orientation, x, y float64) (float64, float64) {
+	switch o {
+	case meta.OrientationMirrorHorizontal:
+		return 1 - x, y
+	case meta.OrientationRotate180:
+		return 1 - x, 1 - y
+	case meta.OrientationMirrorVertical:
+		return x, 1 - y
+	case meta.OrientationMirrorHorizontalRotate270:
+		return y, x
+	case meta.OrientationRotate90:
+		return 1 - y, x
+	case meta.OrientationMirrorHorizontalRotate90:
+		return 1 - y, 1 - x
+	case meta.OrientationRotate270:
+		return y, 1 - x
+	}
+	return x, y
+}
+
+// Region is a named area of an image, ex: a face.
+type Region struct {
+	Name        string
+	Description string
+	Type        RegionType
+	Source      RegionSource
+	// Area is normalized to the image as stored for MWG Regions, and to the
+	// displayed image for MP Regions. Use Regions.Display for the Area on the
+	// displayed image.
+	Area Area
+}
+
+// Dimensions are the dimensions of an image.
+//
+//	xmlns:stDim="http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+type Dimensions struct {
+	W, H float64
+	Unit string // ex: "pixel"
+}
+
+// Regions are the MWG and MP image regions of an XMP Packet.
+type Regions struct {
+	// AppliedToDimensions are the dimensions of the image the MWG Regions
+	// were applied to.
+	AppliedToDimensions Dimensions
+	List                []Region
+}
+
+// Display returns the Regions with their Area normalized to the displayed
+// image of an image stored with the EXIF Orientation o and the width and
+// height in pixels.
+//
+// MWG Regions are rotated and mirrored by the Orientation, unless the
+// AppliedToDimensions show that they were applied to the displayed image.
+// A width or height of 0 is unknown.
+func (r Regions) Display(o meta.Orientation, width, height uint32) []Region {
+	list := make([]Region, len(r.List))
+	copy(list, r.List)
+	if !r.stored(o, width, height) {
+		return list
+	}
+	for i := range list {
+		if list[i].Source == RegionSourceMWG {
+			list[i].Area = list[i].Area.orient(o)
+		}
+	}
+	return list
+}
+
+// stored returns true when the MWG Regions are relative to the image as
+// stored. Some writers apply the Regions to the displayed image, the
+// AppliedToDimensions of these are swapped when the Orientation rotates the
+// image.
+func (r Regions) stored(o meta.Orientation, width, height uint32) bool {
+	d := r.AppliedToDimensions
+	if width == 0 || height == 0 || width == height || d.W == 0 || d.H == 0 {
+		return true
+	}
+	if o < meta.OrientationMirrorHorizontalRotate270 || o > meta.OrientationRotate270 {
+		return true
+	}
+	return !(d.W == float64(height) && d.H == float64(width))
+}
+
+// parseDocument parses the MWG and MP Regions from the Document.
+func (r *Regions) parseDocument(d *Document) {
+	for i := range d.Properties {
+		n := &d.Properties[i]
+		switch {
+		case n.Namespace == xmpns.MwgRsNS.URI() && n.Name == "Regions":
+			r.parseMWG(n)
+		case n.Namespace == xmpns.MPNS.URI() && n.Name == "RegionInfo":
+			r.parseMP(n)
+		}
+	}
+}
+
+// parseMWG parses the mwg-rs:Regions struct n. MWG Areas are centered on X
+// and Y, and are normalized or in the pixels of the AppliedToDimensions.
+// Regions in pixels without AppliedToDimensions are skipped.
+func (r *Regions) parseMWG(n *Node) {
+	uri, dimURI, areaURI := xmpns.MwgRsNS.URI(), xmpns.StDimNS.URI(), xmpns.StAreaNS.URI()
+	if dim := n.Field(uri, "AppliedToDimensions"); dim != nil {
+		r.AppliedToDimensions = Dimensions{
+			W:    parseFloat64([]byte(fieldValue(dim, dimURI, "w"))),
+			H:    parseFloat64([]byte(fieldValue(dim, dimURI, "h"))),
+			Unit: fieldValue(dim, dimURI, "unit"),
+		}
+	}
+	list := n.Field(uri, "RegionList")
+	if list == nil {
+		return
+	}
+	for _, item := range structItems(list) {
+		region := Region{
+			Name:        fieldValue(item, uri, "Name"),
+			Description: fieldValue(item, uri, "Description"),
+			Type:        parseRegionType(fieldValue(item, uri, "Type")),
+			Source:      RegionSourceMWG,
+		}
+		if area := item.Field(uri, "Area"); area != nil {
+			a := Area{
+				X: parseFloat64([]byte(fieldValue(area, areaURI, "x"))),
+				Y: parseFloat64([]byte(fieldValue(area, areaURI, "y"))),
+				W: parseFloat64([]byte(fieldValue(area, areaURI, "w"))),
+				H: parseFloat64([]byte(fieldValue(area, areaURI, "h"))),
+			}
+			if fieldValue(area, areaURI, "unit") == "pixel" {
+				if r.AppliedToDimensions.W <= 0 || r.AppliedToDimensions.H <= 0 {
+					// pixel Areas can not be normalized, skip the Region
+					continue
+				}
+				a.X, a.W = a.X/r.AppliedToDimensions.W, a.W/r.AppliedToDimensions.W
+				a.Y, a.H = a.Y/r.AppliedToDimensions.H, a.H/r.AppliedToDimensions.H
+			}
+			a.X, a.Y = a.X-a.W/2, a.Y-a.H/2
+			region.Area = a
+		}
+		r.List = append(r.List, region)
+	}
+}
+
+// parseMP parses the MP:RegionInfo struct n. MP Rectangles are "x, y, w, h"
+// normalized to the displayed image.
+func (r *Regions) parseMP(n *Node) {
+	list := n.Field(xmpns.MPRINS.URI(), "Regions")
+	if list == nil {
+		return
+	}
+	uri := xmpns.MPRegNS.URI()
+	for _, item := range structItems(list) {
+		region := Region{
+			Name:   fieldValue(item, uri, "PersonDisplayName"),
+			Type:   RegionTypeFace,
+			Source: RegionSourceMP,
+		}
+		if rect := strings.Split(fieldValue(item, uri, "Rectangle"), ","); len(rect) == 4 {
+			region.Area = Area{
+				X: parseFloat64([]byte(strings.TrimSpace(rect[0]))),
+				Y: parseFloat64([]byte(strings.TrimSpace(rect[1]))),
+				W: parseFloat64([]byte(strings.TrimSpace(rect[2]))),
+				H: parseFloat64([]byte(strings.TrimSpace(rect[3]))),
+			}
+		}
+		r.List = append(r.List, region)
+	}
+}
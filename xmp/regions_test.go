@@ -0,0 +1,111 @@
+package xmp
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/evanoberholster/imagemeta/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRegionsXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/"
+    xmlns:stDim="http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+    xmlns:stArea="http://ns.adobe.com/xmp/sType/Area#"
+    xmlns:MP="http://ns.microsoft.com/photo/1.2/"
+    xmlns:MPRI="http://ns.microsoft.com/photo/1.2/t/RegionInfo#"
+    xmlns:MPReg="http://ns.microsoft.com/photo/1.2/t/Region#">
+   <mwg-rs:Regions rdf:parseType="Resource">
+    <mwg-rs:AppliedToDimensions stDim:w="4000" stDim:h="3000" stDim:unit="pixel"/>
+    <mwg-rs:RegionList>
+     <rdf:Bag>
+      <rdf:li>
+       <rdf:Description mwg-rs:Name="Jane Doe" mwg-rs:Type="Face">
+        <mwg-rs:Area stArea:x="0.25" stArea:y="0.5" stArea:w="0.1" stArea:h="0.2" stArea:unit="normalized"/>
+       </rdf:Description>
+      </rdf:li>
+      <rdf:li rdf:parseType="Resource">
+       <mwg-rs:Type>Pet</mwg-rs:Type>
+       <mwg-rs:Description>Dog</mwg-rs:Description>
+       <mwg-rs:Area stArea:x="2000" stArea:y="1500" stArea:w="400" stArea:h="300" stArea:unit="pixel"/>
+      </rdf:li>
+     </rdf:Bag>
+    </mwg-rs:RegionList>
+   </mwg-rs:Regions>
+   <MP:RegionInfo rdf:parseType="Resource">
+    <MPRI:Regions>
+     <rdf:Bag>
+      <rdf:li MPReg:PersonDisplayName="John Doe" MPReg:Rectangle="0.1, 0.2, 0.3, 0.4"/>
+     </rdf:Bag>
+    </MPRI:Regions>
+   </MP:RegionInfo>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+func assertArea(t *testing.T, expected Area, actual Area, msg string) {
+	t.Helper()
+	for i, v := range [][2]float64{{expected.X, actual.X}, {expected.Y, actual.Y}, {expected.W, actual.W}, {expected.H, actual.H}} {
+		if math.Abs(v[0]-v[1]) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v (%d)", msg, expected, actual, i)
+			return
+		}
+	}
+}
+
+func TestRegions(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testRegionsXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := x.Regions
+	assert.Equal(t, Dimensions{W: 4000, H: 3000, Unit: "pixel"}, r.AppliedToDimensions)
+	if !assert.Len(t, r.List, 3) {
+		return
+	}
+	assert.Equal(t, "Jane Doe", r.List[0].Name)
+	assert.Equal(t, RegionTypeFace, r.List[0].Type)
+	assertArea(t, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}, r.List[0].Area, "mwg normalized")
+	assert.Equal(t, "Dog", r.List[1].Description)
+	assert.Equal(t, "Pet", r.List[1].Type.String())
+	assertArea(t, Area{X: 0.45, Y: 0.45, W: 0.1, H: 0.1}, r.List[1].Area, "mwg pixel")
+	assert.Equal(t, Region{Name: "John Doe", Type: RegionTypeFace, Source: RegionSourceMP, Area: Area{X: 0.1, Y: 0.2, W: 0.3, H: 0.4}}, r.List[2])
+
+	tests := []struct {
+		o             meta.Orientation
+		width, height uint32
+		area          Area
+	}{
+		{meta.OrientationHorizontal, 4000, 3000, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}},
+		{meta.OrientationMirrorHorizontal, 4000, 3000, Area{X: 0.7, Y: 0.4, W: 0.1, H: 0.2}},
+		{meta.OrientationRotate180, 4000, 3000, Area{X: 0.7, Y: 0.4, W: 0.1, H: 0.2}},
+		{meta.OrientationMirrorVertical, 4000, 3000, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}},
+		{meta.OrientationMirrorHorizontalRotate270, 4000, 3000, Area{X: 0.4, Y: 0.2, W: 0.2, H: 0.1}},
+		{meta.OrientationRotate90, 4000, 3000, Area{X: 0.4, Y: 0.2, W: 0.2, H: 0.1}},
+		{meta.OrientationMirrorHorizontalRotate90, 4000, 3000, Area{X: 0.4, Y: 0.7, W: 0.2, H: 0.1}},
+		{meta.OrientationRotate270, 4000, 3000, Area{X: 0.4, Y: 0.7, W: 0.2, H: 0.1}},
+		{meta.OrientationRotate90, 0, 0, Area{X: 0.4, Y: 0.2, W: 0.2, H: 0.1}},
+		// AppliedToDimensions of the displayed image
+		{meta.OrientationRotate90, 3000, 4000, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}},
+	}
+	for _, tt := range tests {
+		list := r.Display(tt.o, tt.width, tt.height)
+		assertArea(t, tt.area, list[0].Area, tt.o.String())
+		// MP Regions are relative to the displayed image
+		assert.Equal(t, r.List[2], list[2], tt.o.String())
+	}
+	assertArea(t, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}, r.List[0].Area, "unchanged")
+
+	// pixel Areas without AppliedToDimensions are skipped
+	x, err = ParseXmp(strings.NewReader(strings.Replace(testRegionsXMP, `<mwg-rs:AppliedToDimensions stDim:w="4000" stDim:h="3000" stDim:unit="pixel"/>`, "", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, x.Regions.List, 2) {
+		assert.Equal(t, "Jane Doe", x.Regions.List[0].Name)
+		assert.Equal(t, RegionSourceMP, x.Regions.List[1].Source)
+	}
+}
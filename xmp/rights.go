@@ -0,0 +1,41 @@
+package xmp
+
+import (
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// XMPRights is the XMP Rights Management namespace.
+// XMP spec Section 8.5
+//
+//	xmlns:xmpRights="http://ns.adobe.com/xap/1.0/rights/"
+type XMPRights struct {
+	// WebStatement is a Web URL for a rights management certificate
+	WebStatement string
+	// UsageTerms are the instructions on how the resource can be legally used,
+	// given in various languages.
+	UsageTerms []string
+	// Owner is a list of the legal owners of the resource
+	Owner []string
+	// Marked is true when the resource is a rights-managed resource, and false
+	// when it is public domain. Use MarkedSet to distinguish unknown.
+	Marked    bool
+	MarkedSet bool
+}
+
+func (r *XMPRights) parse(p property) (err error) {
+	switch p.Name() {
+	case xmpns.Marked:
+		r.Marked, r.MarkedSet = parseBool(p.Value()), true
+	case xmpns.WebStatement:
+		r.WebStatement = parseString(p.Value())
+	case xmpns.UsageTerms:
+		if p.pt == tagPType {
+			r.UsageTerms = append(r.UsageTerms, parseString(p.Value()))
+		}
+	case xmpns.Owner:
+		r.Owner = append(r.Owner, parseString(p.Value()))
+	default:
+		return ErrPropertyNotSet
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package xmp
+
+import (
+	"bufio"
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// isSpace returns true for XML white space
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t' || c == '\r'
+}
+
+// packetEncoding returns the UTF-16 or UTF-32 encoding of the XMP packet
+// from its byte order mark, or from the encoding of its first '<'. Returns
+// nil for UTF-8 packets.
+func packetEncoding(br *bufio.Reader) encoding.Encoding {
+	buf, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(buf, []byte{0, 0, 0xfe, 0xff}), bytes.HasPrefix(buf, []byte{0, 0, 0, '<'}):
+		return utf32.UTF32(utf32.BigEndian, utf32.UseBOM)
+	case bytes.HasPrefix(buf, []byte{0xff, 0xfe, 0, 0}), bytes.HasPrefix(buf, []byte{'<', 0, 0, 0}):
+		return utf32.UTF32(utf32.LittleEndian, utf32.UseBOM)
+	case bytes.HasPrefix(buf, []byte{0xfe, 0xff}), bytes.HasPrefix(buf, []byte{0, '<'}):
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	case bytes.HasPrefix(buf, []byte{0xff, 0xfe}), bytes.HasPrefix(buf, []byte{'<', 0}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	}
+	return nil
+}
+
+// decodeEntities decodes the predefined XML entities and character
+// references of buf in place and returns the decoded buf. Unknown entities
+// are not decoded.
+func decodeEntities(buf []byte) []byte {
+	i := bytes.IndexByte(buf, '&')
+	if i < 0 {
+		return buf
+	}
+	w := i
+	for i < len(buf) {
+		if buf[i] != '&' {
+			buf[w] = buf[i]
+			w++
+			i++
+			continue
+		}
+		end := bytes.IndexByte(buf[i:], ';')
+		if end < 0 {
+			w += copy(buf[w:], buf[i:])
+			break
+		}
+		if r, ok := entityRune(buf[i+1 : i+end]); ok {
+			w += utf8.EncodeRune(buf[w:], r)
+			i += end + 1
+			continue
+		}
+		buf[w] = buf[i]
+		w++
+		i++
+	}
+	return buf[:w]
+}
+
+// entityRune returns the rune of the entity name, ex: "amp" or "#x26".
+func entityRune(name []byte) (r rune, ok bool) {
+	switch string(name) {
+	case "amp":
+		return '&', true
+	case "lt":
+		return '<', true
+	case "gt":
+		return '>', true
+	case "quot":
+		return '"', true
+	case "apos":
+		return '\'', true
+	}
+	if len(name) < 2 || name[0] != '#' {
+		return 0, false
+	}
+	base, digits := rune(10), name[1:]
+	if digits[0] == 'x' {
+		base, digits = 16, digits[1:]
+	}
+	if len(digits) == 0 || len(digits) > 8 {
+		return 0, false
+	}
+	for _, c := range digits {
+		var v rune
+		switch {
+		case c >= '0' && c <= '9':
+			v = rune(c - '0')
+		case base == 16 && c >= 'a' && c <= 'f':
+			v = rune(c-'a') + 10
+		case base == 16 && c >= 'A' && c <= 'F':
+			v = rune(c-'A') + 10
+		default:
+			return 0, false
+		}
+		r = r*base + v
+	}
+	return r, utf8.ValidRune(r)
+}
@@ -0,0 +1,255 @@
+package xmp
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// update merges the writable typed values of xmp into the Document d.
+// Properties that decode to the typed value are not replaced.
+func (xmp *XMP) update(d *Document) {
+	u := docUpdater{d: d}
+
+	// xmp:
+	u.date(xmpns.XmpNS, "CreateDate", xmp.Basic.CreateDate)
+	u.date(xmpns.XmpNS, "ModifyDate", xmp.Basic.ModifyDate)
+	u.date(xmpns.XmpNS, "MetadataDate", xmp.Basic.MetadataDate)
+	u.text(xmpns.XmpNS, "CreatorTool", xmp.Basic.CreatorTool)
+	u.text(xmpns.XmpNS, "Label", xmp.Basic.Label)
+	u.int(xmpns.XmpNS, "Rating", int64(xmp.Basic.Rating))
+
+	// dc:
+	u.array(xmpns.DcNS, "creator", SeqKind, xmp.DC.Creator, (*Node).Values)
+	u.array(xmpns.DcNS, "subject", BagKind, xmp.DC.Subject, (*Node).Values)
+	u.alt(xmpns.DcNS, "title", xmp.DC.Title, xmp.DC.TitleLang)
+	u.alt(xmpns.DcNS, "description", xmp.DC.Description, nil)
+	u.alt(xmpns.DcNS, "rights", xmp.DC.Rights, nil)
+
+	// lr:
+	paths := make([]string, 0, len(xmp.Lr.HierarchicalSubject))
+	for _, path := range xmp.Lr.HierarchicalSubject {
+		paths = append(paths, strings.Join(path, "|"))
+	}
+	u.array(xmpns.LrNS, "hierarchicalSubject", BagKind, paths, func(n *Node) (v []string) {
+		for _, path := range n.Values() {
+			if p := parseKeywordPath([]byte(path)); len(p) > 0 {
+				v = append(v, strings.Join(p, "|"))
+			}
+		}
+		return v
+	})
+
+	// photoshop:
+	ps := &xmp.Photoshop
+	u.date(xmpns.PhotoshopNS, "DateCreated", ps.DateCreated)
+	u.text(xmpns.PhotoshopNS, "Headline", ps.Headline)
+	u.text(xmpns.PhotoshopNS, "City", ps.City)
+	u.text(xmpns.PhotoshopNS, "State", ps.State)
+	u.text(xmpns.PhotoshopNS, "Country", ps.Country)
+	u.text(xmpns.PhotoshopNS, "Credit", ps.Credit)
+	u.text(xmpns.PhotoshopNS, "Source", ps.Source)
+	u.text(xmpns.PhotoshopNS, "Instructions", ps.Instructions)
+	u.text(xmpns.PhotoshopNS, "AuthorsPosition", ps.AuthorsPosition)
+	u.text(xmpns.PhotoshopNS, "CaptionWriter", ps.CaptionWriter)
+	u.text(xmpns.PhotoshopNS, "TransmissionReference", ps.TransmissionReference)
+	u.int(xmpns.PhotoshopNS, "Urgency", int64(ps.Urgency))
+
+	// Iptc4xmpCore: and Iptc4xmpExt:
+	u.text(xmpns.Iptc4xmpCoreNS, "Location", xmp.IptcCore.Location)
+	u.text(xmpns.Iptc4xmpCoreNS, "CountryCode", xmp.IptcCore.CountryCode)
+	u.text(xmpns.Iptc4xmpCoreNS, "IntellectualGenre", xmp.IptcCore.IntellectualGenre)
+	u.array(xmpns.Iptc4xmpCoreNS, "Scene", BagKind, xmp.IptcCore.Scene, (*Node).Values)
+	u.array(xmpns.Iptc4xmpCoreNS, "SubjectCode", BagKind, xmp.IptcCore.SubjectCode, (*Node).Values)
+	u.array(xmpns.Iptc4xmpExtNS, "PersonInImage", BagKind, xmp.IptcExt.PersonInImage, (*Node).Values)
+	u.text(xmpns.Iptc4xmpExtNS, "DigitalSourceType", xmp.IptcExt.DigitalSourceType)
+
+	// xmpRights:
+	u.text(xmpns.XmpRightsNS, "WebStatement", xmp.Rights.WebStatement)
+	u.alt(xmpns.XmpRightsNS, "UsageTerms", xmp.Rights.UsageTerms, nil)
+	u.array(xmpns.XmpRightsNS, "Owner", BagKind, xmp.Rights.Owner, (*Node).Values)
+	u.update(xmpns.XmpRightsNS, "Marked", !xmp.Rights.MarkedSet, func(n *Node) bool {
+		return xmp.Rights.MarkedSet && n.Kind == SimpleKind && parseBool([]byte(n.Value)) == xmp.Rights.Marked
+	}, func() Node {
+		if xmp.Rights.Marked {
+			return Node{Value: "True"}
+		}
+		return Node{Value: "False"}
+	})
+
+	// mwg-rs:
+	xmp.Regions.update(&u)
+}
+
+// docUpdater sets the top level properties of a Document from typed values.
+type docUpdater struct {
+	d *Document
+}
+
+// update sets the property ns:name to the Node returned by node. The
+// property is removed when empty is true, and is not changed when equal
+// returns true.
+func (u *docUpdater) update(ns xmpns.Namespace, name string, empty bool, equal func(n *Node) bool, node func() Node) {
+	uri := ns.URI()
+	if n := u.d.Property(uri, name); n != nil && equal(n) {
+		return
+	}
+	if empty {
+		u.d.Delete(uri, name)
+		return
+	}
+	n := node()
+	n.Namespace, n.Prefix, n.Name = uri, ns.String(), name
+	u.d.Set(n)
+}
+
+func (u *docUpdater) text(ns xmpns.Namespace, name string, v string) {
+	u.update(ns, name, v == "", func(n *Node) bool {
+		return n.Kind == SimpleKind && n.Value == v
+	}, func() Node { return Node{Value: v} })
+}
+
+func (u *docUpdater) int(ns xmpns.Namespace, name string, v int64) {
+	u.update(ns, name, v == 0, func(n *Node) bool {
+		return n.Kind == SimpleKind && parseInt([]byte(n.Value)) == v
+	}, func() Node { return Node{Value: strconv.FormatInt(v, 10)} })
+}
+
+func (u *docUpdater) date(ns xmpns.Namespace, name string, t time.Time) {
+	u.update(ns, name, t.IsZero(), func(n *Node) bool {
+		d, err := parseDate([]byte(n.Value))
+		return n.Kind == SimpleKind && err == nil && d.Equal(t)
+	}, func() Node { return Node{Value: t.Format("2006-01-02T15:04:05.999999999Z07:00")} })
+}
+
+// array sets an array of text values. decode returns the values of an
+// existing property.
+func (u *docUpdater) array(ns xmpns.Namespace, name string, kind Kind, v []string, decode func(n *Node) []string) {
+	u.update(ns, name, len(v) == 0, func(n *Node) bool {
+		return equalStrings(decode(n), v)
+	}, func() Node {
+		n := Node{Kind: kind, Items: make([]Node, len(v))}
+		for i := range v {
+			n.Items[i].Value = v[i]
+		}
+		return n
+	})
+}
+
+// alt sets a language alternative. The first value is the "x-default"
+// value when langs is shorter than v.
+func (u *docUpdater) alt(ns xmpns.Namespace, name string, v []string, langs []string) {
+	u.update(ns, name, len(v) == 0, func(n *Node) bool {
+		if !equalStrings(n.Values(), v) {
+			return false
+		}
+		if len(langs) == 0 {
+			return true
+		}
+		var l []string
+		for i := range n.Items {
+			if lang := n.Items[i].Lang(); lang != "" {
+				l = append(l, lang)
+			}
+		}
+		return equalStrings(l, langs)
+	}, func() Node {
+		n := Node{Kind: AltKind, Items: make([]Node, len(v))}
+		for i := range v {
+			lang := "x-default"
+			if len(langs) == len(v) {
+				lang = langs[i]
+			} else if i > 0 {
+				lang = ""
+			}
+			n.Items[i].Value = v[i]
+			if lang != "" {
+				n.Items[i].Qualifiers = []Node{{Namespace: xmpns.XMLNS.URI(), Prefix: "xml", Name: "lang", Value: lang}}
+			}
+		}
+		return n
+	})
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// update sets the mwg-rs:Regions of the Document to the MWG Regions of r.
+// MP Regions are not written, MP:RegionInfo is kept as it was read.
+func (r *Regions) update(u *docUpdater) {
+	mwg := Regions{AppliedToDimensions: r.AppliedToDimensions}
+	for _, region := range r.List {
+		if region.Source == RegionSourceMWG {
+			mwg.List = append(mwg.List, region)
+		}
+	}
+	u.update(xmpns.MwgRsNS, "Regions", len(mwg.List) == 0, func(n *Node) bool {
+		var current Regions
+		current.parseMWG(n)
+		return reflect.DeepEqual(current, mwg)
+	}, mwg.mwgNode)
+}
+
+// mwgNode returns the mwg-rs:Regions struct of the Regions.
+func (r Regions) mwgNode() Node {
+	field := func(ns xmpns.Namespace, name string, value string) Node {
+		return Node{Namespace: ns.URI(), Prefix: ns.String(), Name: name, Value: value}
+	}
+	float := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	list := field(xmpns.MwgRsNS, "RegionList", "")
+	list.Kind = BagKind
+	for _, region := range r.List {
+		a := region.Area
+		area := field(xmpns.MwgRsNS, "Area", "")
+		area.Kind = StructKind
+		area.Fields = []Node{
+			field(xmpns.StAreaNS, "x", float(a.X+a.W/2)),
+			field(xmpns.StAreaNS, "y", float(a.Y+a.H/2)),
+			field(xmpns.StAreaNS, "w", float(a.W)),
+			field(xmpns.StAreaNS, "h", float(a.H)),
+			field(xmpns.StAreaNS, "unit", "normalized"),
+		}
+		item := Node{Kind: StructKind}
+		if region.Name != "" {
+			item.Fields = append(item.Fields, field(xmpns.MwgRsNS, "Name", region.Name))
+		}
+		if region.Type != RegionTypeUnknown {
+			item.Fields = append(item.Fields, field(xmpns.MwgRsNS, "Type", region.Type.String()))
+		}
+		if region.Description != "" {
+			item.Fields = append(item.Fields, field(xmpns.MwgRsNS, "Description", region.Description))
+		}
+		item.Fields = append(item.Fields, area)
+		list.Items = append(list.Items, item)
+	}
+
+	n := Node{Kind: StructKind}
+	if d := r.AppliedToDimensions; d.W > 0 && d.H > 0 {
+		dim := field(xmpns.MwgRsNS, "AppliedToDimensions", "")
+		dim.Kind = StructKind
+		dim.Fields = []Node{
+			field(xmpns.StDimNS, "w", float(d.W)),
+			field(xmpns.StDimNS, "h", float(d.H)),
+		}
+		if d.Unit != "" {
+			dim.Fields = append(dim.Fields, field(xmpns.StDimNS, "unit", d.Unit))
+		}
+		n.Fields = append(n.Fields, dim)
+	}
+	n.Fields = append(n.Fields, list)
+	return n
+}
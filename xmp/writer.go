@@ -0,0 +1,354 @@
+package xmp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evanoberholster/imagemeta/xmp/xmpns"
+)
+
+// DefaultPadding is the default number of bytes of whitespace padding of an
+// XMP packet.
+const DefaultPadding = 2048
+
+// xpacket wrappers. The begin attribute is the UTF-8 byte order mark.
+const (
+	xpacketBegin = "<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n"
+	xpacketEnd   = "<?xpacket end=\"w\"?>"
+)
+
+// Encoder writes XMP packets to an output stream.
+type Encoder struct {
+	w io.Writer
+	// Padding is the number of bytes of whitespace written at the end of the
+	// packet, so that the packet can be updated in place without moving the
+	// data that follows it.
+	Padding int
+}
+
+// NewEncoder returns a new Encoder that writes to w with DefaultPadding.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, Padding: DefaultPadding}
+}
+
+// Encode writes x as a UTF-8 XMP packet with <?xpacket?> wrappers.
+//
+// The properties of x.Document are written, including the properties of
+// unknown namespaces that are read by ParseXmp. The writable typed values of
+// x are merged into a copy of the Document first: a property is only
+// replaced when its value differs from the typed value, properties that are
+// not changed are written as they were read. x is not modified.
+//
+// When x.Document is empty, ex: an XMP that is not read by ParseXmp, only
+// the writable typed values of x are written.
+func (e *Encoder) Encode(x *XMP) error {
+	d := Document{
+		Properties: make([]Node, len(x.Document.Properties)),
+		Namespaces: x.Document.Namespaces,
+	}
+	copy(d.Properties, x.Document.Properties)
+	x.update(&d)
+	return e.EncodeDocument(&d)
+}
+
+// EncodeDocument writes the properties of d as a UTF-8 XMP packet with
+// <?xpacket?> wrappers.
+func (e *Encoder) EncodeDocument(d *Document) error {
+	pw := packetWriter{w: bufio.NewWriter(e.w), d: d}
+	pw.declare(d.Properties)
+	pw.packet(e.Padding)
+	if pw.err != nil {
+		return pw.err
+	}
+	return pw.w.Flush()
+}
+
+// packetWriter writes the RDF/XML of a Document. Properties are written as
+// elements, structs with rdf:parseType="Resource" and every namespace is
+// declared on the rdf:Description.
+type packetWriter struct {
+	w        *bufio.Writer
+	d        *Document
+	err      error
+	prefixes map[string]string // namespace URI to prefix
+	uris     map[string]string // prefix to namespace URI
+}
+
+// declare assigns a unique prefix to the namespace of every Node. The
+// prefix of the Node is kept when possible.
+func (pw *packetWriter) declare(nodes []Node) {
+	if pw.prefixes == nil {
+		pw.prefixes = make(map[string]string)
+		pw.uris = map[string]string{
+			"x":   "adobe:ns:meta/",
+			"rdf": xmpns.RdfNS.URI(),
+			"xml": xmpns.XMLNS.URI(),
+		}
+		for prefix, uri := range pw.uris {
+			pw.prefixes[uri] = prefix
+		}
+	}
+	for i := range nodes {
+		n := &nodes[i]
+		if n.Namespace != "" {
+			if _, ok := pw.prefixes[n.Namespace]; !ok {
+				pw.addPrefix(n)
+			}
+		}
+		pw.declare(n.Qualifiers)
+		pw.declare(n.Fields)
+		pw.declare(n.Items)
+	}
+}
+
+func (pw *packetWriter) addPrefix(n *Node) {
+	hints := []string{n.Prefix}
+	if ns := xmpns.IdentifyNamespaceURI([]byte(n.Namespace)); ns != xmpns.UnknownNS {
+		hints = append(hints, ns.String())
+	}
+	for prefix, uri := range pw.d.Namespaces {
+		if uri == n.Namespace {
+			hints = append(hints, prefix)
+		}
+	}
+	for _, prefix := range hints {
+		if _, ok := pw.uris[prefix]; !ok && isNCName(prefix) {
+			pw.prefixes[n.Namespace], pw.uris[prefix] = prefix, n.Namespace
+			return
+		}
+	}
+	for i := 1; ; i++ {
+		prefix := "ns" + strconv.Itoa(i)
+		if _, ok := pw.uris[prefix]; !ok {
+			pw.prefixes[n.Namespace], pw.uris[prefix] = prefix, n.Namespace
+			return
+		}
+	}
+}
+
+// isNCName returns true if str can be used as a namespace prefix.
+func isNCName(str string) bool {
+	if str == "" || strings.HasPrefix(strings.ToLower(str), "xml") {
+		return false
+	}
+	for i, c := range str {
+		switch {
+		case c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= 0x80:
+		case i > 0 && (c == '-' || c == '.' || c >= '0' && c <= '9'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (pw *packetWriter) packet(padding int) {
+	pw.str(xpacketBegin)
+	pw.str("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	pw.str(" <rdf:RDF xmlns:rdf=\"" + xmpns.RdfNS.URI() + "\">\n")
+	pw.str("  <rdf:Description rdf:about=\"\"")
+
+	// namespace declarations sorted by prefix
+	prefixes := make([]string, 0, len(pw.uris))
+	for prefix := range pw.uris {
+		if prefix != "x" && prefix != "rdf" && prefix != "xml" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		pw.str("\n    xmlns:" + prefix + "=\"")
+		pw.escape(pw.uris[prefix], true)
+		pw.str("\"")
+	}
+
+	if pw.hasProperties() {
+		pw.str(">\n")
+		for i := range pw.d.Properties {
+			if pw.d.Properties[i].Namespace != "" {
+				pw.node(&pw.d.Properties[i], 3, false)
+			}
+		}
+		pw.str("  </rdf:Description>\n")
+	} else {
+		pw.str("/>\n")
+	}
+	pw.str(" </rdf:RDF>\n")
+	pw.str("</x:xmpmeta>\n")
+
+	// padding in lines of 100 bytes
+	for padding > 0 {
+		n := padding
+		if n > 100 {
+			n = 100
+		}
+		pw.str(strings.Repeat(" ", n-1) + "\n")
+		padding -= n
+	}
+	pw.str(xpacketEnd)
+}
+
+func (pw *packetWriter) hasProperties() bool {
+	for i := range pw.d.Properties {
+		if pw.d.Properties[i].Namespace != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// node writes n as an element, or as an rdf:li array item when item is true.
+func (pw *packetWriter) node(n *Node, indent int, item bool) {
+	name := "rdf:li"
+	if !item {
+		name = pw.prefixes[n.Namespace] + ":" + n.Name
+	}
+	pad := strings.Repeat(" ", indent)
+	pw.str(pad + "<" + name)
+	var qualifiers []Node
+	for _, q := range n.Qualifiers {
+		if q.Namespace == xmpns.XMLNS.URI() && q.Name == "lang" {
+			pw.str(" xml:lang=\"")
+			pw.escape(q.Value, true)
+			pw.str("\"")
+		} else if q.Namespace != "" {
+			qualifiers = append(qualifiers, q)
+		}
+	}
+
+	switch {
+	case len(qualifiers) > 0:
+		// value with qualifiers
+		value := *n
+		value.Namespace, value.Name, value.Qualifiers = xmpns.RdfNS.URI(), "value", nil
+		pw.str(" rdf:parseType=\"Resource\">\n")
+		pw.node(&value, indent+1, false)
+		for i := range qualifiers {
+			pw.node(&qualifiers[i], indent+1, false)
+		}
+		pw.str(pad + "</" + name + ">\n")
+	case n.Kind == StructKind:
+		pw.str(" rdf:parseType=\"Resource\"")
+		if len(n.Fields) == 0 {
+			pw.str("/>\n")
+			return
+		}
+		pw.str(">\n")
+		for i := range n.Fields {
+			if n.Fields[i].Namespace != "" {
+				pw.node(&n.Fields[i], indent+1, false)
+			}
+		}
+		pw.str(pad + "</" + name + ">\n")
+	case n.Kind.IsArray():
+		array := "rdf:" + n.Kind.String()
+		pw.str(">\n" + pad + " <" + array)
+		if len(n.Items) == 0 {
+			pw.str("/>\n")
+		} else {
+			pw.str(">\n")
+			for i := range n.Items {
+				pw.node(&n.Items[i], indent+2, true)
+			}
+			pw.str(pad + " </" + array + ">\n")
+		}
+		pw.str(pad + "</" + name + ">\n")
+	default:
+		pw.str(">")
+		pw.escape(n.Value, false)
+		pw.str("</" + name + ">\n")
+	}
+}
+
+func (pw *packetWriter) str(s string) {
+	if pw.err == nil {
+		_, pw.err = pw.w.WriteString(s)
+	}
+}
+
+// escape writes the XML escaped s. Quotes are escaped in attributes.
+// Characters that are not allowed in XML are removed.
+func (pw *packetWriter) escape(s string, attr bool) {
+	if pw.err != nil {
+		return
+	}
+	last := 0
+	for i := 0; i < len(s); {
+		c, size := utf8.DecodeRuneInString(s[i:])
+		var esc string
+		switch {
+		case c == '&':
+			esc = "&amp;"
+		case c == '<':
+			esc = "&lt;"
+		case c == '>':
+			esc = "&gt;"
+		case c == '"' && attr:
+			esc = "&quot;"
+		case c == '\r':
+			esc = "&#xD;"
+		case c == '\n' && attr:
+			esc = "&#xA;"
+		case c == '\t' && attr:
+			esc = "&#x9;"
+		case c < 0x20 && c != '\n' && c != '\t', c == 0xFFFE, c == 0xFFFF, c == utf8.RuneError && size == 1:
+			esc = ""
+		default:
+			i += size
+			continue
+		}
+		pw.w.WriteString(s[last:i])
+		pw.w.WriteString(esc)
+		i += size
+		last = i
+	}
+	_, pw.err = pw.w.WriteString(s[last:])
+}
+
+// UpdateSidecar updates the XMP sidecar file at path with fn. The sidecar is
+// created when it does not exist. The properties of the sidecar that are not
+// changed by fn are preserved. The file is replaced atomically.
+func UpdateSidecar(path string, fn func(x *XMP) error) error {
+	var x XMP
+	mode := os.FileMode(0o644)
+	buf, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if x, err = ParseXmp(bytes.NewReader(buf)); err != nil {
+			return err
+		}
+		if fi, err := os.Stat(path); err == nil {
+			mode = fi.Mode().Perm()
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	if err = fn(&x); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err = NewEncoder(f).Encode(&x); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Chmod(mode); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,187 @@
+package xmp
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, src := range []string{"test/1.xmp", "test/jpeg.xmp"} {
+		buf, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := ParseXmp(bytes.NewReader(buf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err = NewEncoder(&out).Encode(&x); err != nil {
+			t.Fatal(err)
+		}
+		x1, err := ParseXmp(bytes.NewReader(out.Bytes()))
+		if err != nil {
+			t.Fatal(src, err)
+		}
+		assert.Equal(t, x.Document, x1.Document, src)
+		assert.Equal(t, x.DC, x1.DC, src)
+		assert.Equal(t, x.Basic, x1.Basic, src)
+		assert.Equal(t, x.CRS, x1.CRS, src)
+		assert.Equal(t, x.Exif, x1.Exif, src)
+
+		// the output of an unchanged packet is stable
+		var out2 bytes.Buffer
+		if err = NewEncoder(&out2).Encode(&x1); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, out.String(), out2.String(), src)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	x, err := ParseXmp(strings.NewReader(testDocumentXMP))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x.Basic.Rating = -1
+	x.Basic.Label = "Red"
+	x.DC.Subject = append(x.DC.Subject, "three & <four>")
+	x.DC.Title = []string{"New Title", "Neuer Titel"}
+	x.Lr.HierarchicalSubject = [][]string{{"Places", "France"}}
+	x.Regions = Regions{
+		AppliedToDimensions: Dimensions{W: 4000, H: 3000, Unit: "pixel"},
+		List:                []Region{{Name: "Jane \"JD\" Doe", Type: RegionTypeFace, Area: Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}}},
+	}
+
+	var out bytes.Buffer
+	e := NewEncoder(&out)
+	e.Padding = 250
+	if err = e.Encode(&x); err != nil {
+		t.Fatal(err)
+	}
+	packet := out.String()
+	assert.True(t, strings.HasPrefix(packet, "<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n<x:xmpmeta"))
+	assert.True(t, strings.HasSuffix(packet, "</x:xmpmeta>\n"+strings.Repeat(" ", 99)+"\n"+strings.Repeat(" ", 99)+"\n"+strings.Repeat(" ", 49)+"\n<?xpacket end=\"w\"?>"))
+	assert.Contains(t, packet, "<rdf:li>three &amp; &lt;four&gt;</rdf:li>")
+	assert.Contains(t, packet, "<rdf:li xml:lang=\"x-default\">New Title</rdf:li>")
+	assert.Contains(t, packet, "xmlns:ex=\"http://example.com/ns/1.0/\"")
+
+	x1, err := ParseXmp(strings.NewReader(packet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int8(-1), x1.Basic.Rating)
+	assert.Equal(t, "Red", x1.Basic.Label)
+	assert.Equal(t, []string{"one", "two", "three & <four>"}, x1.DC.Subject)
+	assert.Equal(t, []string{"New Title", "Neuer Titel"}, x1.DC.Title)
+	assert.Equal(t, []string{"x-default", "de-DE"}, x1.DC.TitleLang)
+	assert.Equal(t, [][]string{{"Places", "France"}}, x1.Lr.HierarchicalSubject)
+	assert.Equal(t, x.Regions.AppliedToDimensions, x1.Regions.AppliedToDimensions)
+	if assert.Len(t, x1.Regions.List, 1) {
+		r := x1.Regions.List[0]
+		assert.Equal(t, "Jane \"JD\" Doe", r.Name)
+		assert.Equal(t, RegionTypeFace, r.Type)
+		assertArea(t, Area{X: 0.2, Y: 0.4, W: 0.1, H: 0.2}, r.Area, "region")
+	}
+
+	// untouched and unknown properties are preserved
+	d := &x1.Document
+	for _, path := range []string{"ex:Simple", "ex:Qualified", "xmpMM:DerivedFrom/stRef:instanceID", "xmpMM:Ingredients[1]/stRef:filePath", "Iptc4xmpCore:CreatorContactInfo/Iptc4xmpCore:CiAdrCity"} {
+		assert.Equal(t, x.Document.Get(path), d.Get(path), path)
+	}
+	assert.Equal(t, "de-DE", d.Get("dc:title[2]").Lang())
+
+	// removed values are deleted
+	x1.Basic.Label = ""
+	x1.DC.Subject = nil
+	out.Reset()
+	if err = NewEncoder(&out).Encode(&x1); err != nil {
+		t.Fatal(err)
+	}
+	x2, err := ParseXmp(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, x2.Document.Get("xmp:Label"))
+	assert.Nil(t, x2.Document.Get("dc:subject"))
+	assert.NotNil(t, x2.Document.Get("ex:Simple"))
+
+	// an XMP without a Document writes its typed values only
+	var x3 XMP
+	x3.Basic.Label = "Blue"
+	out.Reset()
+	if err = NewEncoder(&out).Encode(&x3); err != nil {
+		t.Fatal(err)
+	}
+	x4, err := ParseXmp(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Blue", x4.Basic.Label)
+	assert.Len(t, x4.Document.Properties, 1)
+}
+
+func TestUpdateSidecar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "IMG_0001.CR2.xmp")
+
+	// new sidecar
+	err := UpdateSidecar(path, func(x *XMP) error {
+		x.Basic.Rating = 3
+		x.Basic.ModifyDate = time.Date(2021, 6, 1, 10, 11, 12, 0, time.UTC)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// existing sidecar
+	src, err := os.ReadFile("test/1.xmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(path, src, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chmod(path, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err = UpdateSidecar(path, func(x *XMP) error {
+		assert.Equal(t, "_MG_1563.CR2", x.CRS.RawFileName)
+		x.Basic.Rating = 5
+		x.DC.Subject = []string{"keyword"}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	x, err := ParseXmp(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int8(5), x.Basic.Rating)
+	assert.Equal(t, []string{"keyword"}, x.DC.Subject)
+	assert.Equal(t, "_MG_1563.CR2", x.CRS.RawFileName)
+	assert.Equal(t, []Double{{0, 0}, {32, 22}, {64, 56}, {128, 128}, {192, 196}, {255, 255}}, x.CRS.ToneCurve.Master)
+	assert.NotNil(t, x.Document.Get("crs:Look/crs:Parameters/crs:ToneCurvePV2012"))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, entries, 1)
+}
@@ -12,6 +12,8 @@ var (
 	// ErrNoXMP is returned when no XMP Root Tag is found.
 	ErrNoXMP          = errors.New("xmp: error no XMP Tag found")
 	ErrPropertyNotSet = errors.New("xmp: error property not set")
+	// ErrGPSCoordinate is returned when a GPS coordinate is not valid.
+	ErrGPSCoordinate = errors.New("xmp: error invalid GPS coordinate")
 
 	// DebugMode when true would print items not parsed in XMP
 	DebugMode = false
@@ -24,35 +26,65 @@ type XMP struct {
 	Tiff  Tiff       // xmlns:tiff="http://ns.adobe.com/tiff/1.0/"
 	Basic Basic      // xmlns:xmp="http://ns.adobe.com/xap/1.0/"
 	DC    DublinCore // xmlns:dc="http://purl.org/dc/elements/1.1/"
-	CRS   CRS
-	MM    XMPMM
+	CRS   CRS        // xmlns:crs="http://ns.adobe.com/camera-raw-settings/1.0/"
+	MM    XMPMM      // xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
+
+	Photoshop Photoshop // xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/"
+	IptcCore  IptcCore  // xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+	IptcExt   IptcExt   // xmlns:Iptc4xmpExt="http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	Lr        Lightroom // xmlns:lr="http://ns.adobe.com/lightroom/1.0/"
+	Rights    XMPRights // xmlns:xmpRights="http://ns.adobe.com/xap/1.0/rights/"
+	Darktable Darktable // xmlns:darktable="http://darktable.sf.net/"
+	Regions   Regions   // xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/" and xmlns:MP="http://ns.microsoft.com/photo/1.2/"
+
+	// Document is the property tree of every property of the XMP packet.
+	Document Document
 }
 
 // ParseXmp reads XMP Metadata from the given reader and returns XMP.
+// UTF-8, UTF-16 and UTF-32 packets are supported. Errors within the
+// packet are returned as a *ReadError.
 //
+// The Document of the XMP holds every property of the packet, including
+// the properties of unknown namespaces, and is written by Encoder.Encode.
 func ParseXmp(r io.Reader) (xmp XMP, err error) {
-	defer func() {
-		if state := recover(); state != nil {
-			err = state.(error)
-		}
-	}()
 	xr := newXMPReader(r)
+	var doc Document // not within xmp, so that xmp does not escape
+	xr.doc.d = &doc
 	rootTag, err := xr.readRootTag()
 	if err != nil {
-		return XMP{}, err
+		if err == ErrNoXMP {
+			return XMP{}, err
+		}
+		return XMP{}, xr.readError(err)
+	}
+	if rootTag.t == soloTag {
+		return
 	}
 
 	var tag Tag
 	for {
 		if tag, err = xr.readTag(&xmp, rootTag); err != nil {
-			return xmp, err
+			return xmp, xr.readError(err)
 		}
 		if tag.isRootStopTag() {
+			xmp.parseDocument(&doc)
+			xmp.Document = doc
 			return
 		}
 	}
 }
 
+// parseDocument parses the structured properties that are not decoded while
+// reading the packet from the Document.
+func (xmp *XMP) parseDocument(d *Document) {
+	xmp.CRS.parseDocument(d)
+	xmp.MM.parseDocument(d)
+	xmp.IptcExt.parseDocument(d)
+	xmp.Darktable.parseDocument(d)
+	xmp.Regions.parseDocument(d)
+}
+
 // CleanXMPSuffixWhiteSpace returns the same slice with the whitespace after "</x:xmpmeta>" removed.
 func CleanXMPSuffixWhiteSpace(buf []byte) []byte {
 	for i := len(buf) - 1; i > 12; i-- {
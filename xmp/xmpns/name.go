@@ -1,12 +1,10 @@
 package xmpns
 
-import "fmt"
-
 // Name is the XMP Property name
-type Name uint8
+type Name uint16
 
 func (n Name) String() string {
-	return fmt.Sprintf(mapNameString[n])
+	return mapNameString[n]
 }
 
 // IdentifyName returns the XMP Property Name correspondent to buf.
@@ -27,21 +25,37 @@ const (
 	AltTimecode
 	ApertureValue
 	ApproximateFocusDistance
+	ArtworkOrObject
+	AuthorsPosition
 	Bag
 	BitsPerSample
 	BodySerialNumber
 	BrightnessValue
 	CameraOwnerName
+	CaptionWriter
 	Changed
+	CiAdrCity
+	CiAdrCtry
+	CiAdrExtadr
+	CiAdrPcode
+	CiAdrRegion
+	CiEmailWork
+	CiTelWork
+	City
+	CiUrlWork
 	ColorMode
 	ColorSpace
 	ComponentsConfiguration
 	CompressedBitsPerPixel
 	Compression
 	Contrast
+	Country
+	CountryCode
 	CreateDate
 	Creator
+	CreatorContactInfo
 	CreatorTool
+	Credit
 	CustomRendered
 	DateCreated
 	DateTimeDigitized
@@ -49,6 +63,7 @@ const (
 	Dc
 	DerivedFrom
 	Description
+	DigitalSourceType
 	DigitalZoomRatio
 	DocumentID
 	EmbeddedXMPDigest
@@ -74,22 +89,39 @@ const (
 	GainControl
 	GPSAltitude
 	GPSAltitudeRef
+	GPSDestBearing
+	GPSDestBearingRef
 	GPSDifferential
+	GPSDOP
+	GPSHPositioningError
+	GPSImgDirection
+	GPSImgDirectionRef
 	GPSLatitude
 	GPSLongitude
 	GPSMapDatum
+	GPSMeasureMode
+	GPSProcessingMethod
+	GPSSatellites
+	GPSSpeed
+	GPSSpeedRef
 	GPSStatus
 	GPSTimeStamp
+	GPSTrack
+	GPSTrackRef
 	GPSVersionID
 	H
+	Headline
 	HierarchicalSubject
 	History
 	ICCProfile
 	ImageDescription
 	ImageLength
 	ImageNumber
+	ImageUniqueID
 	ImageWidth
 	InstanceID
+	Instructions
+	IntellectualGenre
 	InteroperabilityIndex
 	ISOSpeedRatings
 	Label
@@ -102,7 +134,11 @@ const (
 	LensSerialNumber
 	Li
 	LightSource
+	Location
+	LocationCreated
+	LocationShown
 	Make
+	Marked
 	MaxApertureValue
 	MetadataDate
 	MeteringMode
@@ -112,7 +148,9 @@ const (
 	NativeDigest
 	Orientation
 	OriginalDocumentID
+	Owner
 	ParseType // parseType
+	PersonInImage
 	PhotometricInterpretation
 	PixelXDimension
 	PixelYDimension
@@ -128,6 +166,7 @@ const (
 	Rights
 	SamplesPerPixel
 	Saturation
+	Scene
 	SceneCaptureType
 	SceneType
 	SensitivityType
@@ -138,9 +177,12 @@ const (
 	SidecarForExtension
 	Software
 	SoftwareAgent
+	Source
 	StartTimecode
+	State
 	StDim
 	Subject
+	SubjectCode
 	SubjectDistance
 	TapeName
 	Temperature
@@ -154,6 +196,9 @@ const (
 	ToneCurvePV2012Green
 	ToneCurvePV2012Red
 	ToneCurveRed
+	TransmissionReference
+	Urgency
+	UsageTerms
 	UserComment
 	VideoFieldOrder
 	VideoFrameRate
@@ -161,6 +206,7 @@ const (
 	VideoPixelAspectRatio
 	VideoPixelDepth
 	W
+	WebStatement
 	When
 	WhiteBalance
 	Xap
@@ -182,21 +228,37 @@ var mapNameString = map[Name]string{
 	AltTimecode:               "altTimecode",
 	ApertureValue:             "ApertureValue",
 	ApproximateFocusDistance:  "ApproximateFocusDistance",
+	ArtworkOrObject:           "ArtworkOrObject",
+	AuthorsPosition:           "AuthorsPosition",
 	Bag:                       "Bag",
 	BitsPerSample:             "BitsPerSample",
 	BodySerialNumber:          "BodySerialNumber",
 	BrightnessValue:           "BrightnessValue",
 	CameraOwnerName:           "CameraOwnerName",
+	CaptionWriter:             "CaptionWriter",
 	Changed:                   "changed",
+	CiAdrCity:                 "CiAdrCity",
+	CiAdrCtry:                 "CiAdrCtry",
+	CiAdrExtadr:               "CiAdrExtadr",
+	CiAdrPcode:                "CiAdrPcode",
+	CiAdrRegion:               "CiAdrRegion",
+	CiEmailWork:               "CiEmailWork",
+	CiTelWork:                 "CiTelWork",
+	City:                      "City",
+	CiUrlWork:                 "CiUrlWork",
 	ColorMode:                 "ColorMode",
 	ColorSpace:                "ColorSpace",
 	ComponentsConfiguration:   "ComponentsConfiguration",
 	CompressedBitsPerPixel:    "CompressedBitsPerPixel",
 	Compression:               "Compression",
 	Contrast:                  "Contrast",
+	Country:                   "Country",
+	CountryCode:               "CountryCode",
 	CreateDate:                "CreateDate",
 	Creator:                   "creator",
+	CreatorContactInfo:        "CreatorContactInfo",
 	CreatorTool:               "CreatorTool",
+	Credit:                    "Credit",
 	CustomRendered:            "CustomRendered",
 	DateCreated:               "DateCreated",
 	DateTimeDigitized:         "DateTimeDigitized",
@@ -204,6 +266,7 @@ var mapNameString = map[Name]string{
 	Dc:                        "dc",
 	DerivedFrom:               "DerivedFrom",
 	Description:               "Description",
+	DigitalSourceType:         "DigitalSourceType",
 	DigitalZoomRatio:          "DigitalZoomRatio",
 	DocumentID:                "DocumentID",
 	EmbeddedXMPDigest:         "EmbeddedXMPDigest",
@@ -229,22 +292,39 @@ var mapNameString = map[Name]string{
 	GainControl:               "GainControl",
 	GPSAltitude:               "GPSAltitude",
 	GPSAltitudeRef:            "GPSAltitudeRef",
+	GPSDestBearing:            "GPSDestBearing",
+	GPSDestBearingRef:         "GPSDestBearingRef",
 	GPSDifferential:           "GPSDifferential",
+	GPSDOP:                    "GPSDOP",
+	GPSHPositioningError:      "GPSHPositioningError",
+	GPSImgDirection:           "GPSImgDirection",
+	GPSImgDirectionRef:        "GPSImgDirectionRef",
 	GPSLatitude:               "GPSLatitude",
 	GPSLongitude:              "GPSLongitude",
 	GPSMapDatum:               "GPSMapDatum",
+	GPSMeasureMode:            "GPSMeasureMode",
+	GPSProcessingMethod:       "GPSProcessingMethod",
+	GPSSatellites:             "GPSSatellites",
+	GPSSpeed:                  "GPSSpeed",
+	GPSSpeedRef:               "GPSSpeedRef",
 	GPSStatus:                 "GPSStatus",
 	GPSTimeStamp:              "GPSTimeStamp",
+	GPSTrack:                  "GPSTrack",
+	GPSTrackRef:               "GPSTrackRef",
 	GPSVersionID:              "GPSVersionID",
 	H:                         "h",
+	Headline:                  "Headline",
 	HierarchicalSubject:       "hierarchicalSubject",
 	History:                   "History",
 	ICCProfile:                "ICCProfile",
 	ImageDescription:          "ImageDescription",
 	ImageLength:               "ImageLength",
 	ImageNumber:               "ImageNumber",
+	ImageUniqueID:             "ImageUniqueID",
 	ImageWidth:                "ImageWidth",
 	InstanceID:                "InstanceID",
+	Instructions:              "Instructions",
+	IntellectualGenre:         "IntellectualGenre",
 	InteroperabilityIndex:     "InteroperabilityIndex",
 	ISOSpeedRatings:           "ISOSpeedRatings",
 	Label:                     "Label",
@@ -257,7 +337,11 @@ var mapNameString = map[Name]string{
 	LensSerialNumber:          "LensSerialNumber",
 	Li:                        "li",
 	LightSource:               "LightSource",
+	Location:                  "Location",
+	LocationCreated:           "LocationCreated",
+	LocationShown:             "LocationShown",
 	Make:                      "Make",
+	Marked:                    "Marked",
 	MaxApertureValue:          "MaxApertureValue",
 	MetadataDate:              "MetadataDate",
 	MeteringMode:              "MeteringMode",
@@ -267,7 +351,9 @@ var mapNameString = map[Name]string{
 	NativeDigest:              "NativeDigest",
 	Orientation:               "Orientation",
 	OriginalDocumentID:        "OriginalDocumentID",
+	Owner:                     "Owner",
 	ParseType:                 "parseType",
+	PersonInImage:             "PersonInImage",
 	PhotometricInterpretation: "PhotometricInterpretation",
 	PixelXDimension:           "PixelXDimension",
 	PixelYDimension:           "PixelYDimension",
@@ -283,6 +369,7 @@ var mapNameString = map[Name]string{
 	Rights:                    "rights",
 	SamplesPerPixel:           "SamplesPerPixel",
 	Saturation:                "Saturation",
+	Scene:                     "Scene",
 	SceneCaptureType:          "SceneCaptureType",
 	SceneType:                 "SceneType",
 	SensitivityType:           "SensitivityType",
@@ -293,9 +380,12 @@ var mapNameString = map[Name]string{
 	SidecarForExtension:       "SidecarForExtension",
 	Software:                  "Software",
 	SoftwareAgent:             "softwareAgent",
+	Source:                    "Source",
 	StartTimecode:             "startTimecode",
+	State:                     "State",
 	StDim:                     "stDim",
 	Subject:                   "subject",
+	SubjectCode:               "SubjectCode",
 	SubjectDistance:           "SubjectDistance",
 	TapeName:                  "tapeName",
 	Temperature:               "Temperature",
@@ -309,6 +399,9 @@ var mapNameString = map[Name]string{
 	ToneCurvePV2012Green:      "ToneCurvePV2012Green",
 	ToneCurvePV2012Red:        "ToneCurvePV2012Red",
 	ToneCurveRed:              "ToneCurveRed",
+	TransmissionReference:     "TransmissionReference",
+	Urgency:                   "Urgency",
+	UsageTerms:                "UsageTerms",
 	UserComment:               "UserComment",
 	VideoFieldOrder:           "videoFieldOrder",
 	VideoFrameRate:            "videoFrameRate",
@@ -316,6 +409,7 @@ var mapNameString = map[Name]string{
 	VideoPixelAspectRatio:     "videoPixelAspectRatio",
 	VideoPixelDepth:           "videoPixelDepth",
 	W:                         "w",
+	WebStatement:              "WebStatement",
 	When:                      "when",
 	WhiteBalance:              "WhiteBalance",
 	Xap:                       "xap",
@@ -336,21 +430,37 @@ var mapStringName = map[string]Name{
 	"altTimecode":               AltTimecode,
 	"ApertureValue":             ApertureValue,
 	"ApproximateFocusDistance":  ApproximateFocusDistance,
+	"ArtworkOrObject":           ArtworkOrObject,
+	"AuthorsPosition":           AuthorsPosition,
 	"Bag":                       Bag,
 	"BitsPerSample":             BitsPerSample,
 	"BodySerialNumber":          BodySerialNumber,
 	"BrightnessValue":           BrightnessValue,
 	"CameraOwnerName":           CameraOwnerName,
+	"CaptionWriter":             CaptionWriter,
 	"changed":                   Changed,
+	"CiAdrCity":                 CiAdrCity,
+	"CiAdrCtry":                 CiAdrCtry,
+	"CiAdrExtadr":               CiAdrExtadr,
+	"CiAdrPcode":                CiAdrPcode,
+	"CiAdrRegion":               CiAdrRegion,
+	"CiEmailWork":               CiEmailWork,
+	"CiTelWork":                 CiTelWork,
+	"City":                      City,
+	"CiUrlWork":                 CiUrlWork,
 	"ColorMode":                 ColorMode,
 	"ColorSpace":                ColorSpace,
 	"ComponentsConfiguration":   ComponentsConfiguration,
 	"CompressedBitsPerPixel":    CompressedBitsPerPixel,
 	"Compression":               Compression,
 	"Contrast":                  Contrast,
+	"Country":                   Country,
+	"CountryCode":               CountryCode,
 	"CreateDate":                CreateDate,
 	"creator":                   Creator,
+	"CreatorContactInfo":        CreatorContactInfo,
 	"CreatorTool":               CreatorTool,
+	"Credit":                    Credit,
 	"CustomRendered":            CustomRendered,
 	"DateCreated":               DateCreated,
 	"DateTimeDigitized":         DateTimeDigitized,
@@ -359,6 +469,7 @@ var mapStringName = map[string]Name{
 	"DerivedFrom":               DerivedFrom,
 	"Description":               Description,
 	"description":               Description,
+	"DigitalSourceType":         DigitalSourceType,
 	"DigitalZoomRatio":          DigitalZoomRatio,
 	"DocumentID":                DocumentID,
 	"EmbeddedXMPDigest":         EmbeddedXMPDigest,
@@ -384,23 +495,40 @@ var mapStringName = map[string]Name{
 	"GainControl":               GainControl,
 	"GPSAltitude":               GPSAltitude,
 	"GPSAltitudeRef":            GPSAltitudeRef,
+	"GPSDestBearing":            GPSDestBearing,
+	"GPSDestBearingRef":         GPSDestBearingRef,
 	"GPSDifferential":           GPSDifferential,
+	"GPSDOP":                    GPSDOP,
+	"GPSHPositioningError":      GPSHPositioningError,
+	"GPSImgDirection":           GPSImgDirection,
+	"GPSImgDirectionRef":        GPSImgDirectionRef,
 	"GPSLatitude":               GPSLatitude,
 	"GPSLongitude":              GPSLongitude,
 	"GPSMapDatum":               GPSMapDatum,
+	"GPSMeasureMode":            GPSMeasureMode,
+	"GPSProcessingMethod":       GPSProcessingMethod,
+	"GPSSatellites":             GPSSatellites,
+	"GPSSpeed":                  GPSSpeed,
+	"GPSSpeedRef":               GPSSpeedRef,
 	"GPSStatus":                 GPSStatus,
 	"GPSTimeStamp":              GPSTimeStamp,
+	"GPSTrack":                  GPSTrack,
+	"GPSTrackRef":               GPSTrackRef,
 	"GPSVersionID":              GPSVersionID,
 	"h":                         H,
+	"Headline":                  Headline,
 	"hierarchicalSubject":       HierarchicalSubject,
 	"History":                   History,
 	"ICCProfile":                ICCProfile,
 	"ImageDescription":          ImageDescription,
 	"ImageLength":               ImageLength,
 	"ImageNumber":               ImageNumber,
+	"ImageUniqueID":             ImageUniqueID,
 	"ImageWidth":                ImageWidth,
 	"instanceID":                InstanceID,
 	"InstanceID":                InstanceID,
+	"Instructions":              Instructions,
+	"IntellectualGenre":         IntellectualGenre,
 	"InteroperabilityIndex":     InteroperabilityIndex,
 	"ISOSpeedRatings":           ISOSpeedRatings,
 	"Label":                     Label,
@@ -413,7 +541,11 @@ var mapStringName = map[string]Name{
 	"LensSerialNumber":          LensSerialNumber,
 	"li":                        Li,
 	"LightSource":               LightSource,
+	"Location":                  Location,
+	"LocationCreated":           LocationCreated,
+	"LocationShown":             LocationShown,
 	"Make":                      Make,
+	"Marked":                    Marked,
 	"MaxApertureValue":          MaxApertureValue,
 	"MetadataDate":              MetadataDate,
 	"MeteringMode":              MeteringMode,
@@ -423,7 +555,9 @@ var mapStringName = map[string]Name{
 	"NativeDigest":              NativeDigest,
 	"Orientation":               Orientation,
 	"OriginalDocumentID":        OriginalDocumentID,
+	"Owner":                     Owner,
 	"parseType":                 ParseType,
+	"PersonInImage":             PersonInImage,
 	"PhotometricInterpretation": PhotometricInterpretation,
 	"PixelXDimension":           PixelXDimension,
 	"PixelYDimension":           PixelYDimension,
@@ -440,6 +574,7 @@ var mapStringName = map[string]Name{
 	"rights":                    Rights,
 	"SamplesPerPixel":           SamplesPerPixel,
 	"Saturation":                Saturation,
+	"Scene":                     Scene,
 	"SceneCaptureType":          SceneCaptureType,
 	"SceneType":                 SceneType,
 	"SensitivityType":           SensitivityType,
@@ -450,9 +585,12 @@ var mapStringName = map[string]Name{
 	"SidecarForExtension":       SidecarForExtension,
 	"Software":                  Software,
 	"softwareAgent":             SoftwareAgent,
+	"Source":                    Source,
 	"startTimecode":             StartTimecode,
+	"State":                     State,
 	"stDim":                     StDim,
 	"subject":                   Subject,
+	"SubjectCode":               SubjectCode,
 	"SubjectDistance":           SubjectDistance,
 	"tapeName":                  TapeName,
 	"Temperature":               Temperature,
@@ -467,7 +605,10 @@ var mapStringName = map[string]Name{
 	"ToneCurvePV2012Green":      ToneCurvePV2012Green,
 	"ToneCurvePV2012Red":        ToneCurvePV2012Red,
 	"ToneCurveRed":              ToneCurveRed,
+	"TransmissionReference":     TransmissionReference,
 	"Unknown":                   UnknownPropertyName,
+	"Urgency":                   Urgency,
+	"UsageTerms":                UsageTerms,
 	"UserComment":               UserComment,
 	"videoFieldOrder":           VideoFieldOrder,
 	"videoFrameRate":            VideoFrameRate,
@@ -475,6 +616,7 @@ var mapStringName = map[string]Name{
 	"videoPixelAspectRatio":     VideoPixelAspectRatio,
 	"videoPixelDepth":           VideoPixelDepth,
 	"w":                         W,
+	"WebStatement":              WebStatement,
 	"when":                      When,
 	"WhiteBalance":              WhiteBalance,
 	"xap":                       Xap,
@@ -18,11 +18,11 @@ var (
 )
 
 // Property is an XMP Namespace with the associated Property Name
-type Property [2]uint8
+type Property [2]uint16
 
 // NewProperty returns the correspoding Property for the given Namespace and Name.
 func NewProperty(ns Namespace, name Name) Property {
-	return Property{uint8(ns), uint8(name)}
+	return Property{uint16(ns), uint16(name)}
 }
 
 // Equals returns true if one property is equal to the other.
@@ -32,7 +32,7 @@ func (p Property) Equals(p1 Property) bool {
 
 // IdentifyProperty returns a Property correspondent to the "space" and "name" byte values.
 func IdentifyProperty(space []byte, name []byte) Property {
-	return Property{uint8(IdentifyNamespace(space)), uint8(IdentifyName(name))}
+	return Property{uint16(IdentifyNamespace(space)), uint16(IdentifyName(name))}
 }
 
 // Namespace returns the property's XMP Namespace
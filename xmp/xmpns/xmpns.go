@@ -1,13 +1,11 @@
 // Package xmpns provides XMP Namespace information
 package xmpns
 
-import "fmt"
-
 // Namespace is the Namespace portion of an XMP Property
 type Namespace uint8
 
 func (ns Namespace) String() string {
-	return fmt.Sprintf(mapNSString[ns])
+	return mapNSString[ns]
 }
 
 // IdentifyNamespace returns the (Namespace) XML Namespace correspondent to buf.
@@ -16,6 +14,17 @@ func IdentifyNamespace(buf []byte) (n Namespace) {
 	return mapStringNS[string(buf)]
 }
 
+// IdentifyNamespaceURI returns the (Namespace) XML Namespace correspondent to
+// the namespace URI in buf. If NS was not identified returns UnknownNS.
+func IdentifyNamespaceURI(buf []byte) (n Namespace) {
+	return mapURINS[string(buf)]
+}
+
+// URI returns the canonical namespace URI of the Namespace
+func (ns Namespace) URI() string {
+	return mapNSURI[ns]
+}
+
 // XML Namespaces supported
 const (
 	UnknownNS Namespace = iota
@@ -31,14 +40,29 @@ const (
 	ExifNS
 	// xmlns:exifEX="http://cipa.jp/exif/1.0/"
 	ExifEXNS
+	// xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+	Iptc4xmpCoreNS
+	// xmlns:Iptc4xmpExt="http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	Iptc4xmpExtNS
 	// xmlns:lr="http://ns.adobe.com/lightroom/1.0/"
 	LrNS
+	// xmlns:MP="http://ns.microsoft.com/photo/1.2/"
+	MPNS
+	// xmlns:MPReg="http://ns.microsoft.com/photo/1.2/t/Region#"
+	MPRegNS
+	// xmlns:MPRI="http://ns.microsoft.com/photo/1.2/t/RegionInfo#"
+	MPRINS
+	// xmlns:mwg-rs="http://www.metadataworkinggroup.com/schemas/regions/"
+	MwgRsNS
 	// xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/"
 	PhotoshopNS
 	// xmlns:pmi="http://prismstandard.org/namespaces/pmi/2.2/"
 	PmiNS
 	// xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
 	RdfNS
+	// xmlns:stArea="http://ns.adobe.com/xmp/sType/Area#"
+	StAreaNS
+	// xmlns:stDim="http://ns.adobe.com/xap/1.0/sType/Dimensions#"
 	StDimNS
 	// xmlns:stEvt="http://ns.adobe.com/xap/1.0/sType/ResourceEvent#"
 	StEvtNS
@@ -52,63 +76,131 @@ const (
 	XapNS
 	// xmlns:xapMM="http://ns.adobe.com/xap/1.0/mm/"
 	XapMMNS
+	// xmlns:xml="http://www.w3.org/XML/1998/namespace"
 	XMLNS
+	// xmlns:xmlns="http://www.w3.org/2000/xmlns/"
 	XMLnsNS
 	// xmlns:xmp="http://ns.adobe.com/xap/1.0/"
 	XmpNS
+	// xmlns:xmpDM="http://ns.adobe.com/xmp/1.0/DynamicMedia/"
 	XmpDMNS
 	// xmlns:xmpMM="http://ns.adobe.com/xap/1.0/mm/"
 	XmpMMNS
+	// xmlns:xmpRights="http://ns.adobe.com/xap/1.0/rights/"
+	XmpRightsNS
 )
 
 var mapStringNS = map[string]Namespace{
-	"Unknown":   UnknownNS,
-	"aux":       AuxNS,
-	"crs":       CrsNS,
-	"darktable": DarktableNS,
-	"dc":        DcNS,
-	"exif":      ExifNS,
-	"exifEX":    ExifEXNS,
-	"lr":        LrNS,
-	"photoshop": PhotoshopNS,
-	"pmi":       PmiNS,
-	"rdf":       RdfNS,
-	"stDim":     StDimNS,
-	"stEvt":     StEvtNS,
-	"stRef":     StRefNS,
-	"tiff":      TiffNS,
-	"x":         XNS,
-	"xap":       XapNS,
-	"xapMM":     XapMMNS,
-	"xml":       XMLNS,
-	"xmlns":     XMLnsNS,
-	"xmp":       XmpNS,
-	"xmpDM":     XmpDMNS,
-	"xmpMM":     XmpMMNS,
+	"Unknown":      UnknownNS,
+	"aux":          AuxNS,
+	"crs":          CrsNS,
+	"darktable":    DarktableNS,
+	"dc":           DcNS,
+	"exif":         ExifNS,
+	"exifEX":       ExifEXNS,
+	"Iptc4xmpCore": Iptc4xmpCoreNS,
+	"Iptc4xmpExt":  Iptc4xmpExtNS,
+	"lr":           LrNS,
+	"mwg-rs":       MwgRsNS,
+	"MP":           MPNS,
+	"MPReg":        MPRegNS,
+	"MPRI":         MPRINS,
+	"photoshop":    PhotoshopNS,
+	"pmi":          PmiNS,
+	"rdf":          RdfNS,
+	"stArea":       StAreaNS,
+	"stDim":        StDimNS,
+	"stEvt":        StEvtNS,
+	"stRef":        StRefNS,
+	"tiff":         TiffNS,
+	"x":            XNS,
+	"xap":          XapNS,
+	"xapMM":        XapMMNS,
+	"xml":          XMLNS,
+	"xmlns":        XMLnsNS,
+	"xmp":          XmpNS,
+	"xmpDM":        XmpDMNS,
+	"xmpMM":        XmpMMNS,
+	"xmpRights":    XmpRightsNS,
 }
 
 var mapNSString = map[Namespace]string{
-	UnknownNS:   "Unknown",
-	AuxNS:       "aux",
-	CrsNS:       "crs",
-	DarktableNS: "darktable",
-	DcNS:        "dc",
-	ExifNS:      "exif",
-	ExifEXNS:    "exifEX",
-	LrNS:        "lr",
-	PhotoshopNS: "photoshop",
-	PmiNS:       "pmi",
-	RdfNS:       "rdf",
-	StDimNS:     "stDim",
-	StEvtNS:     "stEvt",
-	StRefNS:     "stRef",
-	TiffNS:      "tiff",
-	XNS:         "x",
-	XapNS:       "xap",
-	XapMMNS:     "xapMM",
-	XMLNS:       "xml",
-	XMLnsNS:     "xmlns",
-	XmpNS:       "xmp",
-	XmpDMNS:     "xmpDM",
-	XmpMMNS:     "xmpMM",
+	UnknownNS:      "Unknown",
+	AuxNS:          "aux",
+	CrsNS:          "crs",
+	DarktableNS:    "darktable",
+	DcNS:           "dc",
+	ExifNS:         "exif",
+	ExifEXNS:       "exifEX",
+	Iptc4xmpCoreNS: "Iptc4xmpCore",
+	Iptc4xmpExtNS:  "Iptc4xmpExt",
+	LrNS:           "lr",
+	MPNS:           "MP",
+	MPRegNS:        "MPReg",
+	MPRINS:         "MPRI",
+	MwgRsNS:        "mwg-rs",
+	PhotoshopNS:    "photoshop",
+	PmiNS:          "pmi",
+	RdfNS:          "rdf",
+	StAreaNS:       "stArea",
+	StDimNS:        "stDim",
+	StEvtNS:        "stEvt",
+	StRefNS:        "stRef",
+	TiffNS:         "tiff",
+	XNS:            "x",
+	XapNS:          "xap",
+	XapMMNS:        "xapMM",
+	XMLNS:          "xml",
+	XMLnsNS:        "xmlns",
+	XmpNS:          "xmp",
+	XmpDMNS:        "xmpDM",
+	XmpMMNS:        "xmpMM",
+	XmpRightsNS:    "xmpRights",
+}
+
+// mapNSURI are the canonical namespace URIs. Namespaces with more than one
+// prefix (xap and xmp, xapMM and xmpMM) share a URI and resolve to XmpNS and
+// XmpMMNS.
+var mapNSURI = map[Namespace]string{
+	AuxNS:          "http://ns.adobe.com/exif/1.0/aux/",
+	CrsNS:          "http://ns.adobe.com/camera-raw-settings/1.0/",
+	DarktableNS:    "http://darktable.sf.net/",
+	DcNS:           "http://purl.org/dc/elements/1.1/",
+	ExifNS:         "http://ns.adobe.com/exif/1.0/",
+	ExifEXNS:       "http://cipa.jp/exif/1.0/",
+	Iptc4xmpCoreNS: "http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/",
+	Iptc4xmpExtNS:  "http://iptc.org/std/Iptc4xmpExt/2008-02-29/",
+	LrNS:           "http://ns.adobe.com/lightroom/1.0/",
+	MPNS:           "http://ns.microsoft.com/photo/1.2/",
+	MPRegNS:        "http://ns.microsoft.com/photo/1.2/t/Region#",
+	MPRINS:         "http://ns.microsoft.com/photo/1.2/t/RegionInfo#",
+	MwgRsNS:        "http://www.metadataworkinggroup.com/schemas/regions/",
+	PhotoshopNS:    "http://ns.adobe.com/photoshop/1.0/",
+	PmiNS:          "http://prismstandard.org/namespaces/pmi/2.2/",
+	RdfNS:          "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	StAreaNS:       "http://ns.adobe.com/xmp/sType/Area#",
+	StDimNS:        "http://ns.adobe.com/xap/1.0/sType/Dimensions#",
+	StEvtNS:        "http://ns.adobe.com/xap/1.0/sType/ResourceEvent#",
+	StRefNS:        "http://ns.adobe.com/xap/1.0/sType/ResourceRef#",
+	TiffNS:         "http://ns.adobe.com/tiff/1.0/",
+	XNS:            "adobe:ns:meta/",
+	XapNS:          "http://ns.adobe.com/xap/1.0/",
+	XapMMNS:        "http://ns.adobe.com/xap/1.0/mm/",
+	XMLNS:          "http://www.w3.org/XML/1998/namespace",
+	XMLnsNS:        "http://www.w3.org/2000/xmlns/",
+	XmpNS:          "http://ns.adobe.com/xap/1.0/",
+	XmpDMNS:        "http://ns.adobe.com/xmp/1.0/DynamicMedia/",
+	XmpMMNS:        "http://ns.adobe.com/xap/1.0/mm/",
+	XmpRightsNS:    "http://ns.adobe.com/xap/1.0/rights/",
 }
+
+var mapURINS = func() map[string]Namespace {
+	m := make(map[string]Namespace, len(mapNSURI))
+	for ns, uri := range mapNSURI {
+		if ns == XapNS || ns == XapMMNS {
+			continue
+		}
+		m[uri] = ns
+	}
+	return m
+}()
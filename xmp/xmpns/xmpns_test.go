@@ -43,3 +43,16 @@ func TestNamespace(t *testing.T) {
 		t.Errorf("Incorrect Name String wanted %s got %s", "exif", ns.String())
 	}
 }
+
+func TestNamespaceURI(t *testing.T) {
+	ns := IdentifyNamespaceURI([]byte("http://purl.org/dc/elements/1.1/"))
+	if ns != DcNS || ns.URI() != "http://purl.org/dc/elements/1.1/" {
+		t.Errorf("Incorrect Namespace wanted %s got %s", DcNS, ns)
+	}
+	if ns := IdentifyNamespaceURI([]byte("http://ns.adobe.com/xap/1.0/")); ns != XmpNS || XapNS.URI() != XmpNS.URI() {
+		t.Errorf("Incorrect Namespace wanted %s got %s", XmpNS, ns)
+	}
+	if ns := IdentifyNamespaceURI([]byte("http://example.com/ns/")); ns != UnknownNS {
+		t.Errorf("Incorrect Namespace wanted %s got %s", UnknownNS, ns)
+	}
+}